@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"lms-backend/internal/app"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/pkg/marc"
+	"log"
+	"os"
+)
+
+func main() {
+	path := flag.String("file", "", "Path to the MARC21 or MARCXML file")
+	format := flag.String("format", "", "Format of the file: marc or marcxml (detected if empty)")
+	dryRun := flag.Bool("dry-run", false, "Report mapping problems without importing anything")
+	flag.Parse()
+
+	if *path == "" {
+		log.Fatal("A file must be given with -file")
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	reader, err := marc.NewRecordReader(file, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	records, err := marc.ReadAll(reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = app.LoadEnvAndConnectToDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tx := database.GetDB().Begin()
+
+	report, err := book.ImportMARC(tx, records, *dryRun)
+	if err != nil {
+		tx.Rollback()
+		log.Fatal(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.Fatal(err)
+	}
+
+	printReport(report)
+}
+
+//nolint:revive // ignore print errors
+func printReport(report *viewmodel.MARCImportReportViewModel) {
+	for _, r := range report.Records {
+		status := "ok"
+		if !r.Accepted {
+			status = "skipped"
+		}
+
+		fmt.Printf("record %d [%s] %s (isbn %s): %s\n", r.Index, r.ControlNumber, r.Title, r.ISBN, status)
+		for _, p := range r.Problems {
+			level := "warning"
+			if p.Fatal {
+				level = "error"
+			}
+			fmt.Printf("  %s %s: %s\n", level, p.Field, p.Message)
+		}
+	}
+
+	if report.DryRun {
+		fmt.Printf("Dry run: %d records can be imported, %d would be skipped.\n", report.Accepted, report.Skipped)
+		return
+	}
+
+	fmt.Printf("Imported %d records, skipped %d.\n", report.Accepted, report.Skipped)
+}
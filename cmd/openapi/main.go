@@ -0,0 +1,39 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"lms-backend/internal/apidocs"
+	"lms-backend/internal/router"
+	"log"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Writes the OpenAPI document of the API.
+// The tests of internal/apidocs check that every route is documented.
+func main() {
+	out := flag.String("out", "", "Path to write the document to (stdout if empty)")
+	flag.Parse()
+
+	app := fiber.New()
+	router.SetUpAPIRoutes(app)
+
+	doc, _ := apidocs.Generate(app.GetRoutes(true))
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*out, data, 0o644) //nolint:gosec // the document is public
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
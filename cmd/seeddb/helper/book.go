@@ -2,6 +2,7 @@ package shelper
 
 import (
 	"fmt"
+	"lms-backend/internal/config"
 	"lms-backend/internal/model"
 	"lms-backend/util/random"
 	"strings"
@@ -48,21 +49,50 @@ func GenerateBulkInsertBooksSQL(num int64) string {
 	return stmt
 }
 
-func GenerateBulkInsertBookCopiesSQL(count, max int64) string {
+func GenerateBulkInsertBookCopiesSQL(count, max, branchCount int64) string {
 	var valueStrings []string
 
+	// Same format as model.SequentialAccessionNumber
+	accessionNumber := fmt.Sprintf(
+		"'%s' || LPAD(NEXTVAL('book_copy_accession_seq')::TEXT, %d, '0')",
+		escapeSQL(config.AccessionNumberPrefix), config.AccessionNumberDigits,
+	)
+
 	for i := 0; i < int(count); i++ {
 		numOfCopies := random.RandInt(1, int(max+1))
 		for j := 0; j < numOfCopies; j++ {
 			status := model.BookStatusAvailable
+			branchID := random.RandInt(1, int(branchCount+1))
 			createdAt := time.Now().Format("2006-01-02 15:04:05")
 
-			valueString := fmt.Sprintf("(%d, '%s', '%s')", i+1, status, createdAt)
+			valueString := fmt.Sprintf("(%d, '%s', %d, %d, %s, '%s')", i+1, status, branchID, branchID, accessionNumber, createdAt)
 			valueStrings = append(valueStrings, valueString)
 		}
 	}
 
-	return fmt.Sprintf("INSERT INTO book_copies (book_id, status, created_at) VALUES %s", strings.Join(valueStrings, ","))
+	return fmt.Sprintf("INSERT INTO book_copies (book_id, status, home_branch_id, current_branch_id, accession_number, created_at) VALUES %s", strings.Join(valueStrings, ","))
+}
+
+// Seeded books get one author, publisher and subject each, taken from their display strings.
+var bookEntitiesSQL = []string{
+	`INSERT INTO contributors (name)
+	SELECT DISTINCT author FROM books ON CONFLICT DO NOTHING`,
+	`INSERT INTO book_contributors (book_id, contributor_id, role, position)
+	SELECT books.id, contributors.id, 'author', 0 FROM books
+	INNER JOIN contributors ON contributors.name = books.author
+	ON CONFLICT DO NOTHING`,
+	`INSERT INTO publishers (name)
+	SELECT DISTINCT publisher FROM books ON CONFLICT DO NOTHING`,
+	`INSERT INTO book_publishers (book_id, publisher_id)
+	SELECT books.id, publishers.id FROM books
+	INNER JOIN publishers ON publishers.name = books.publisher
+	ON CONFLICT DO NOTHING`,
+	`INSERT INTO subjects (name)
+	SELECT DISTINCT genre FROM books ON CONFLICT DO NOTHING`,
+	`INSERT INTO book_subjects (book_id, subject_id)
+	SELECT books.id, subjects.id FROM books
+	INNER JOIN subjects ON subjects.name = books.genre
+	ON CONFLICT DO NOTHING`,
 }
 
 func SeedBookAndCopies(db *gorm.DB, num int64) error {
@@ -83,11 +113,24 @@ func SeedBookAndCopies(db *gorm.DB, num int64) error {
 		return err
 	}
 
-	stmt = GenerateBulkInsertBookCopiesSQL(num, 5)
+	var branchCount int64
+
+	result = db.Model(&model.Branch{}).Count(&branchCount)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	stmt = GenerateBulkInsertBookCopiesSQL(num, 5, branchCount)
 	err = db.Exec(stmt).Error
 	if err != nil {
 		return err
 	}
 
+	for _, stmt := range bookEntitiesSQL {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
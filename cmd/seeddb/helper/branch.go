@@ -0,0 +1,36 @@
+package shelper
+
+import (
+	"lms-backend/internal/model"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// The default branch is created by the migration.
+	Branches []model.Branch = []model.Branch{
+		{
+			Name:    "East Branch",
+			Address: "1 East Road",
+		},
+		{
+			Name:    "West Branch",
+			Address: "1 West Road",
+		},
+	}
+)
+
+func SeedBranches(db *gorm.DB) error {
+	var count int64
+
+	result := db.Model(&model.Branch{}).Count(&count)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if count > 1 {
+		return nil
+	}
+
+	return db.Create(&Branches).Error
+}
@@ -53,7 +53,7 @@ func SeedLoanAndReservations(db *gorm.DB) error {
 			copyID := ids[0]
 			ids = ids[1:]
 
-			_, err := bookcopy.ReserveCopy(db, int64(userID), copyID)
+			_, err := bookcopy.ReserveCopy(db, int64(userID), copyID, 0)
 			if err != nil {
 				return err
 			}
@@ -51,6 +51,9 @@ func LinkRoleAndAbility(db *gorm.DB) error {
 				abilities.CanCreateBook.Name,
 				abilities.CanUpdateBook.Name,
 				abilities.CanDeleteBook.Name,
+				abilities.CanMergeBook.Name,
+				abilities.CanImportBook.Name,
+				abilities.CanExportBook.Name,
 
 				abilities.CanLoanBook.Name,
 				abilities.CanReturnBook.Name,
@@ -69,6 +72,10 @@ func LinkRoleAndAbility(db *gorm.DB) error {
 				abilities.CanDeleteBookMark.Name,
 
 				abilities.CanManageBookRecords.Name,
+
+				abilities.CanManageBranch.Name,
+				abilities.CanTransferBookCopy.Name,
+				abilities.CanUpdateUserBranch.Name,
 			},
 
 			roles.Staff.Name: {
@@ -94,6 +101,8 @@ func LinkRoleAndAbility(db *gorm.DB) error {
 				abilities.CanDeleteBookMark.Name,
 
 				abilities.CanManageBookRecords.Name,
+
+				abilities.CanTransferBookCopy.Name,
 			},
 
 			roles.Basic.Name: {
@@ -55,6 +55,12 @@ func main() {
 		panic(err)
 	}
 
+	lgr.Println("Seeding branches...")
+	err = shelper.SeedBranches(tx)
+	if err != nil {
+		panic(err)
+	}
+
 	lgr.Println("Seeding books and copies...")
 	err = shelper.SeedBookAndCopies(tx, NumberOfBooks)
 	if err != nil {
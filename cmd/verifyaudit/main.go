@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"lms-backend/internal/app"
+	"lms-backend/internal/dataaccess/auditlog"
+	"lms-backend/internal/database"
+	"lms-backend/internal/viewmodel"
+	"log"
+	"os"
+)
+
+// Checks the hash chain of the audit log, and fails at the first entry that was changed
+// or does not follow the entry before it, e.g. because that entry was removed.
+func main() {
+	err := app.LoadEnvAndConnectToDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	verification, err := auditlog.Verify(database.GetDB())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	printVerification(verification)
+	if !verification.Valid() {
+		os.Exit(1)
+	}
+}
+
+//nolint:revive // ignore print errors
+func printVerification(v *viewmodel.AuditLogVerificationViewModel) {
+	fmt.Printf("%d entries from before the chain were not checked\n", v.Unchained)
+	fmt.Printf("%d chained entries checked\n", v.Checked)
+	if v.Checked > 0 {
+		fmt.Printf("Last entry checked: %d, hash %s\n", v.LastID, v.LastHash)
+	}
+
+	if !v.Valid() {
+		fmt.Printf("Chain broken at entry %d: %s\n", v.BrokenID, v.Reason)
+		return
+	}
+	fmt.Println("No entry was changed or removed")
+}
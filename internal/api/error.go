@@ -2,19 +2,78 @@ package api
 
 import (
 	"errors"
+	"lms-backend/internal/i18n"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
+// Error is an entry of the errors of a response. Field is the JSON path of the field
+// at fault for validation errors, e.g. contributors[0].name.
+type Error struct {
+	Code    externalerrors.Code `json:"code"`
+	Message string              `json:"message"`
+	Field   string              `json:"field,omitempty"`
+}
+
+// ErrorHandler responds with the status of the error and its codes in errors, one per
+// field for validation errors. Errors with a translated code are shown to the user in
+// messages in their language, while error keeps the original message.
 func ErrorHandler(c *fiber.Ctx, err error) error {
-	code := fiber.StatusInternalServerError
-	var e *fiber.Error
-	if errors.As(err, &e) {
-		code = e.Code
-	}
+	status, res := errorResponse(c, err)
+
 	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
-	return c.Status(code).JSON(Response{
-		Messages: []Message{ErrorMessage("Something went wrong: " + err.Error())},
+	return c.Status(status).JSON(res)
+}
+
+func errorResponse(c *fiber.Ctx, err error) (int, Response) {
+	l := i18n.For(c)
+	status := fiber.StatusInternalServerError
+	errs := []Error{{Code: externalerrors.InternalServerErrorCode, Message: err.Error()}}
+	message := err.Error()
+
+	var externalErr *externalerrors.Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &externalErr):
+		status = externalErr.Status
+		errs = toErrors(l, externalErr)
+		message = strings.Join(sliceutil.Map(errs, func(e Error) string { return e.Message }), "; ")
+	case errors.As(err, &fiberErr):
+		status = fiberErr.Code
+		errs = []Error{{Code: externalerrors.StatusCode(fiberErr.Code), Message: fiberErr.Message}}
+	}
+
+	return status, Response{
+		Messages: []Message{ErrorMessage(l.T(i18n.SomethingWentWrong, message))},
 		Error:    err.Error(),
-	})
+		Errors:   errs,
+	}
+}
+
+func toErrors(l *i18n.Localizer, err *externalerrors.Error) []Error {
+	if len(err.Fields) == 0 {
+		message := err.Message
+		if key := i18n.ErrorKey(err.Code); l.Has(key) {
+			message = l.T(key)
+		}
+		return []Error{{Code: err.Code, Message: message}}
+	}
+
+	errs := make([]Error, 0, len(err.Fields))
+	for _, fieldErr := range err.Fields {
+		message := fieldErr.Message
+		if key := i18n.FieldErrorKey(fieldErr.Code); l.Has(key) {
+			message = l.T(key, fieldErr.Field)
+		}
+		errs = append(errs, Error{
+			Code:    fieldErr.Code,
+			Message: message,
+			Field:   fieldErr.Field,
+		})
+	}
+
+	return errs
 }
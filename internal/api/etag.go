@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETag is the entity tag of a record at a version, e.g. "3".
+func ETag(version uint) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+func SetETag(c *fiber.Ctx, version uint) {
+	c.Set(fiber.HeaderETag, ETag(version))
+}
+
+// IfMatch is the version of the record that the request was made from, as of the
+// If-Match header, which is required. It is 0 for *, which matches any version.
+//
+// Records have one strong ETag, so weak ETags, which never match, and lists of several
+// versions fail the precondition with a version conflict.
+func IfMatch(c *fiber.Ctx) (uint, error) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return 0, externalerrors.PreconditionRequired(fmt.Sprintf(
+			"%s is required, with the ETag of the record as last read", fiber.HeaderIfMatch,
+		))
+	}
+
+	if header == "*" {
+		return 0, nil
+	}
+
+	var version uint
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		weak := strings.HasPrefix(tag, "W/")
+
+		v, err := parseETag(strings.TrimPrefix(tag, "W/"))
+		if err != nil {
+			return 0, err
+		}
+
+		if weak || (version != 0 && v != version) {
+			return 0, externalerrors.PreconditionFailed(fmt.Sprintf(
+				"%s must be the one strong ETag of the record as last read, e.g. %s", fiber.HeaderIfMatch, ETag(v),
+			)).WithCode(externalerrors.VersionConflict)
+		}
+		version = v
+	}
+
+	return version, nil
+}
+
+// parseETag is the version of the record of the ETag.
+func parseETag(tag string) (uint, error) {
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) {
+		return 0, externalerrors.BadRequest(fmt.Sprintf("%s must be a quoted ETag, e.g. %s", fiber.HeaderIfMatch, ETag(1)))
+	}
+
+	version, err := strconv.ParseUint(unquoted, 10, 0)
+	if err != nil || version == 0 {
+		return 0, externalerrors.BadRequest(fmt.Sprintf("%s is not an ETag of a record", tag))
+	}
+
+	return uint(version), nil
+}
+
+// PreconditionFailed responds to a request made from an outdated version of the record
+// with the error and the current representation of the record in data.
+func PreconditionFailed(c *fiber.Ctx, err error, version uint, data interface{}) error {
+	_, res := errorResponse(c, err)
+	res.Data = data
+
+	SetETag(c, version)
+	return c.Status(fiber.StatusPreconditionFailed).JSON(res)
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"lms-backend/pkg/error/externalerrors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		header     string
+		want       uint
+		wantStatus int
+		wantCode   externalerrors.Code
+	}{
+		{name: "missing", header: "", wantStatus: fiber.StatusPreconditionRequired},
+		{name: "any version", header: "*", want: 0},
+		{name: "quoted version", header: `"3"`, want: 3},
+		{name: "spaces", header: ` "3" `, want: 3},
+		{name: "repeated version", header: `"3", "3"`, want: 3},
+		{
+			name:       "weak",
+			header:     `W/"3"`,
+			wantStatus: fiber.StatusPreconditionFailed,
+			wantCode:   externalerrors.VersionConflict,
+		},
+		{
+			name:       "list of versions",
+			header:     `"2", "3"`,
+			wantStatus: fiber.StatusPreconditionFailed,
+			wantCode:   externalerrors.VersionConflict,
+		},
+		{
+			name:       "list with a weak version",
+			header:     `"3", W/"3"`,
+			wantStatus: fiber.StatusPreconditionFailed,
+			wantCode:   externalerrors.VersionConflict,
+		},
+		{name: "unquoted", header: "3", wantStatus: fiber.StatusBadRequest},
+		{name: "single quoted", header: "'3'", wantStatus: fiber.StatusBadRequest},
+		{name: "not a version", header: `"abc"`, wantStatus: fiber.StatusBadRequest},
+		{name: "version 0", header: `"0"`, wantStatus: fiber.StatusBadRequest},
+		{name: "list with an invalid tag", header: `"3", 4`, wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				got uint
+				err error
+			)
+			app := fiber.New()
+			app.Patch("/", func(c *fiber.Ctx) error {
+				got, err = IfMatch(c)
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodPatch, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.header)
+			}
+			res, testErr := app.Test(req)
+			if testErr != nil {
+				t.Fatal(testErr)
+			}
+			res.Body.Close()
+
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("IfMatch() error = %v, want nil", err)
+				}
+				if got != tt.want {
+					t.Errorf("IfMatch() = %d, want %d", got, tt.want)
+				}
+				return
+			}
+
+			var externalErr *externalerrors.Error
+			if !errors.As(err, &externalErr) {
+				t.Fatalf("IfMatch() error = %v, want an external error", err)
+			}
+			if externalErr.Status != tt.wantStatus {
+				t.Errorf("IfMatch() error status = %d, want %d", externalErr.Status, tt.wantStatus)
+			}
+			if tt.wantCode != "" && externalErr.Code != tt.wantCode {
+				t.Errorf("IfMatch() error code = %s, want %s", externalErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+// TestPreconditionFailed handles requests as the handlers of versioned records do, with
+// a record at version 4.
+func TestPreconditionFailed(t *testing.T) {
+	type record struct {
+		Title   string `json:"title"`
+		Version uint   `json:"version"`
+	}
+	current := record{Title: "Dune", Version: 4}
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantRecord bool
+	}{
+		{name: "missing", wantStatus: fiber.StatusPreconditionRequired},
+		{name: "current version", header: `"4"`, wantStatus: fiber.StatusOK},
+		{name: "any version", header: "*", wantStatus: fiber.StatusOK},
+		{name: "stale version", header: `"3"`, wantStatus: fiber.StatusPreconditionFailed, wantRecord: true},
+		{name: "weak version", header: `W/"4"`, wantStatus: fiber.StatusPreconditionFailed, wantRecord: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+			app.Patch("/", func(c *fiber.Ctx) error {
+				version, err := IfMatch(c)
+				if err == nil && version != 0 && version != current.Version {
+					err = externalerrors.PreconditionFailed("record was changed since it was read").
+						WithCode(externalerrors.VersionConflict)
+				}
+
+				var externalErr *externalerrors.Error
+				if errors.As(err, &externalErr) && externalErr.Code == externalerrors.VersionConflict {
+					return PreconditionFailed(c, err, current.Version, current)
+				}
+				if err != nil {
+					return err
+				}
+
+				SetETag(c, current.Version)
+				return c.JSON(Response{Data: current})
+			})
+
+			req := httptest.NewRequest(fiber.MethodPatch, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(fiber.HeaderIfMatch, tt.header)
+			}
+			res, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if !tt.wantRecord {
+				return
+			}
+
+			if got := res.Header.Get(fiber.HeaderETag); got != `"4"` {
+				t.Errorf("ETag = %s, want %q", got, `"4"`)
+			}
+
+			var body struct {
+				Data   record  `json:"data"`
+				Errors []Error `json:"errors"`
+			}
+			if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Data != current {
+				t.Errorf("data = %+v, want the current record %+v", body.Data, current)
+			}
+			if len(body.Errors) != 1 || body.Errors[0].Code != externalerrors.VersionConflict {
+				t.Errorf("errors = %+v, want %s", body.Errors, externalerrors.VersionConflict)
+			}
+		})
+	}
+}
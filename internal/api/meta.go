@@ -3,4 +3,9 @@ package api
 type Meta struct {
 	TotalCount    int64 `json:"total_count,omitempty"`
 	FilteredCount int64 `json:"filtered_count,omitempty"`
+	// Counts of the filtered results by property, for lists that support them
+	Facets interface{} `json:"facets,omitempty"`
+	// Cursors of the neighbouring pages, for lists paginated by cursor
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
@@ -6,4 +6,6 @@ type Response struct {
 	Meta     interface{} `json:"meta,omitempty"`
 	Messages []Message   `json:"messages,omitempty"`
 	Error    string      `json:"error,omitempty"`
+	// Codes of the error, see ErrorHandler
+	Errors []Error `json:"errors,omitempty"`
 }
@@ -0,0 +1,369 @@
+// Package apidocs
+//
+// Generates the OpenAPI document of the API from the routes of the app and the
+// param and view types of their endpoints.
+package apidocs
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	"lms-backend/internal/export"
+	"lms-backend/internal/middleware"
+	"lms-backend/internal/session"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/pkg/openapi"
+	"lms-backend/util/sliceutil"
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/csrf"
+)
+
+const (
+	BasePath = "/api/v1"
+	Title    = "Library Management System API"
+	Version  = "1.0"
+
+	sessionScheme = "session"
+	csrfScheme    = "csrf"
+
+	mimeMultipartForm = "multipart/form-data"
+)
+
+// Path parameters that are not IDs
+var stringPathParams = []string{"value", "volume_id"}
+
+// Endpoint documents a route, keyed in Endpoints by its method and OpenAPI path
+// relative to BasePath, e.g. "GET /book/{book_id}".
+type Endpoint struct {
+	Summary     string
+	Description string
+	// Routes outside the session middleware
+	Public bool
+	// Zero value of the JSON body, nil if there is none
+	Body interface{}
+	// Name of the form field of an uploaded file, empty if there is none
+	Upload string
+	// Zero value of the form fields sent with the upload
+	Form interface{}
+	// Query parameters other than those of collections
+	Query []openapi.Parameter
+	// Filters and sorters of a collection query
+	Filters collection.FilterMap
+	Sorters collection.SortMap
+	// Pages by offset or cursor
+	Paginated bool
+	// Can be exported as CSV or XLSX with format
+	Exportable bool
+	// Retries with the same Idempotency-Key header replay the first response
+	Idempotent bool
+	// Changes a versioned record only if If-Match has its ETag
+	Versioned bool
+	// Zero value of the data of the response, nil if there is none
+	Data interface{}
+	// Content types of responses that are files rather than JSON
+	Files []string
+}
+
+// Generate documents the routes under BasePath with their endpoints.
+//
+// Routes without an endpoint are left out of the document and returned as undocumented.
+func Generate(routes []fiber.Route) (doc *openapi.Document, undocumented []string) {
+	schemas := openapi.NewSchemas()
+	doc = &openapi.Document{
+		OpenAPI: openapi.Version,
+		Info: openapi.Info{
+			Title:   Title,
+			Version: Version,
+		},
+		Servers: []openapi.Server{{URL: BasePath}},
+		Paths:   map[string]openapi.PathItem{},
+		Components: openapi.Components{
+			SecuritySchemes: map[string]openapi.SecurityScheme{
+				sessionScheme: {
+					Type:        "apiKey",
+					In:          openapi.InCookie,
+					Name:        session.CookieKey,
+					Description: "Session cookie set when signing in",
+				},
+				csrfScheme: {
+					Type:        "apiKey",
+					In:          openapi.InHeader,
+					Name:        csrf.HeaderName,
+					Description: "CSRF token returned by the health check, required for unsafe methods",
+				},
+			},
+		},
+	}
+
+	tags := []string{}
+	for _, route := range routes {
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, BasePath) {
+			continue
+		}
+
+		path, params := openapi.Path(strings.TrimPrefix(route.Path, BasePath))
+		endpoint, ok := Endpoints[route.Method+" "+path]
+		if !ok {
+			undocumented = append(undocumented, route.Method+" "+route.Path)
+			continue
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = openapi.PathItem{}
+		}
+		method := strings.ToLower(route.Method)
+		if doc.Paths[path][method] != nil {
+			// Registered twice
+			continue
+		}
+
+		tag := strings.Split(strings.TrimPrefix(path, "/"), "/")[0]
+		if !sliceutil.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+
+		doc.Paths[path][method] = endpoint.operation(schemas, route.Method, tag, params)
+	}
+
+	sort.Strings(tags)
+	for _, tag := range tags {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+	doc.Components.Schemas = schemas.Components()
+
+	return doc, undocumented
+}
+
+// Undocumented lists the routes under BasePath that have no endpoint.
+func Undocumented(routes []fiber.Route) []string {
+	_, undocumented := Generate(routes)
+	return undocumented
+}
+
+// Unrouted lists the endpoints that no longer have a route, in order.
+func Unrouted(routes []fiber.Route) []string {
+	doc, _ := Generate(routes)
+
+	unrouted := []string{}
+	for key := range Endpoints {
+		method, path, _ := strings.Cut(key, " ")
+		if doc.Paths[path][strings.ToLower(method)] == nil {
+			unrouted = append(unrouted, key)
+		}
+	}
+	sort.Strings(unrouted)
+
+	return unrouted
+}
+
+func (e *Endpoint) operation(schemas *openapi.Schemas, method, tag string, params []openapi.PathParam) *openapi.Operation {
+	op := &openapi.Operation{
+		Tags:        []string{tag},
+		Summary:     e.Summary,
+		Description: e.Description,
+		Parameters:  []openapi.Parameter{},
+		Responses: map[string]openapi.Response{
+			"default": {
+				Description: "Error",
+				Content: map[string]openapi.MediaType{
+					fiber.MIMEApplicationJSON: {Schema: schemas.Of(api.Response{})},
+				},
+			},
+		},
+		Security: e.security(method),
+	}
+	// Every route is rate limited, see middleware.RateLimit
+	op.Responses[fmt.Sprint(fiber.StatusTooManyRequests)] = openapi.Response{
+		Description: "Too many requests, retry after the seconds in the RateLimit-Reset header",
+		Content: map[string]openapi.MediaType{
+			fiber.MIMEApplicationJSON: {Schema: schemas.Of(api.Response{})},
+		},
+	}
+
+	for _, param := range params {
+		schema := openapi.Integer()
+		if param.Constraint == "" && sliceutil.Contains(stringPathParams, param.Name) {
+			schema = openapi.String()
+		}
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:     param.Name,
+			In:       openapi.InPath,
+			Required: true,
+			Schema:   schema,
+		})
+	}
+
+	op.Parameters = append(op.Parameters, e.Query...)
+	if e.Idempotent {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:        middleware.IdempotencyKeyHeader,
+			In:          openapi.InHeader,
+			Description: "Unique key of the request. Retries with the same key and body replay the first successful response.",
+			Schema:      openapi.String(),
+		})
+	}
+	if e.Versioned {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name:        fiber.HeaderIfMatch,
+			In:          openapi.InHeader,
+			Description: "ETag of the record as last read, or * for any version.",
+			Required:    true,
+			Schema:      openapi.String(),
+		})
+		op.Responses[fmt.Sprint(fiber.StatusPreconditionFailed)] = openapi.Response{
+			Description: "Changed since it was read, with the record as it is now in data and its ETag",
+			Content: map[string]openapi.MediaType{
+				fiber.MIMEApplicationJSON: {Schema: schemas.Of(api.Response{})},
+			},
+		}
+	}
+	op.Parameters = append(op.Parameters, e.collectionParameters()...)
+
+	switch {
+	case e.Upload != "":
+		form := openapi.Object(map[string]*openapi.Schema{})
+		if e.Form != nil {
+			form = schemas.FormOf(e.Form)
+		}
+		form.Properties[e.Upload] = openapi.Binary()
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				mimeMultipartForm: {Schema: form},
+			},
+		}
+	case e.Body != nil:
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{
+				fiber.MIMEApplicationJSON: {Schema: schemas.Of(e.Body)},
+			},
+		}
+	}
+
+	op.Responses[fmt.Sprint(fiber.StatusOK)] = e.response(schemas)
+
+	return op
+}
+
+// security requires a session for private routes and a CSRF token for unsafe methods,
+// as checked by the middleware.
+func (e *Endpoint) security(method string) []openapi.SecurityRequirement {
+	requirement := openapi.SecurityRequirement{}
+	if !e.Public {
+		requirement[sessionScheme] = []string{}
+	}
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+	default:
+		requirement[csrfScheme] = []string{}
+	}
+
+	if len(requirement) == 0 {
+		return nil
+	}
+
+	return []openapi.SecurityRequirement{requirement}
+}
+
+// collectionParameters are the parameters read by collection.GetCollectionQueryFromParam.
+func (e *Endpoint) collectionParameters() []openapi.Parameter {
+	params := []openapi.Parameter{}
+
+	keys := make([]string, 0, len(e.Filters))
+	for key := range e.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		filter := e.Filters[key]
+		description := fmt.Sprintf(
+			"Filters by the %s %s with %s, or with filter[%s][<operator>] for any of %s.",
+			filter.Type, key, filter.Operators[0], key, strings.Join(filter.Operators, ", "),
+		)
+		if len(filter.Values) > 0 {
+			description += " Values are any of " + strings.Join(filter.Values, ", ") + "."
+		}
+		params = append(params, openapi.Parameter{
+			Name:        fmt.Sprintf("filter[%s]", key),
+			In:          openapi.InQuery,
+			Description: description,
+			Schema:      openapi.String(),
+		})
+	}
+
+	if len(e.Sorters) > 0 {
+		sortKeys := make([]string, 0, len(e.Sorters))
+		for key := range e.Sorters {
+			sortKeys = append(sortKeys, key)
+		}
+		sort.Strings(sortKeys)
+
+		params = append(params,
+			openapi.Parameter{Name: "sortBy", In: openapi.InQuery, Schema: openapi.Enum(sortKeys...)},
+			openapi.Parameter{Name: "orderBy", In: openapi.InQuery, Schema: openapi.Enum(collection.ASC, collection.DESC)},
+		)
+	}
+
+	if e.Paginated {
+		params = append(params,
+			openapi.Parameter{
+				Name:        "limit",
+				In:          openapi.InQuery,
+				Description: fmt.Sprintf("Defaults to %d, at most %d", collection.DefaultLimit, collection.MaxLimit),
+				Schema:      openapi.Integer(),
+			},
+			openapi.Parameter{Name: "offset", In: openapi.InQuery, Schema: openapi.Integer()},
+			openapi.Parameter{
+				Name:        "cursor",
+				In:          openapi.InQuery,
+				Description: "Pages by cursor instead of offset. Empty for the first page, then the next or previous cursor of the meta.",
+				Schema:      openapi.String(),
+			},
+		)
+	}
+
+	if e.Exportable {
+		params = append(params, openapi.Parameter{
+			Name:        "format",
+			In:          openapi.InQuery,
+			Description: "Exports every matching row as a file instead. The Accept header may be used instead.",
+			Schema:      openapi.Enum(export.FormatCSV, export.FormatXLSX),
+		})
+	}
+
+	return params
+}
+
+func (e *Endpoint) response(schemas *openapi.Schemas) openapi.Response {
+	if len(e.Files) > 0 {
+		content := map[string]openapi.MediaType{}
+		for _, contentType := range e.Files {
+			content[contentType] = openapi.MediaType{Schema: openapi.Binary()}
+		}
+		return openapi.Response{Description: "File", Content: content}
+	}
+
+	properties := map[string]*openapi.Schema{
+		"messages": schemas.Of([]api.Message{}),
+	}
+	if e.Data != nil {
+		properties["data"] = schemas.Of(e.Data)
+	}
+	if e.Paginated {
+		properties["meta"] = schemas.Of(api.Meta{})
+	}
+
+	content := map[string]openapi.MediaType{
+		fiber.MIMEApplicationJSON: {Schema: openapi.Object(properties)},
+	}
+	if e.Exportable {
+		content[export.MIMECSV] = openapi.MediaType{Schema: openapi.Binary()}
+		content[export.MIMEXLSX] = openapi.MediaType{Schema: openapi.Binary()}
+	}
+
+	return openapi.Response{Description: "Success", Content: content}
+}
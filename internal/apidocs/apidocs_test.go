@@ -0,0 +1,47 @@
+package apidocs_test
+
+import (
+	"lms-backend/internal/apidocs"
+	"lms-backend/internal/router"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func apiRoutes() []fiber.Route {
+	app := fiber.New()
+	router.SetUpAPIRoutes(app)
+
+	return app.GetRoutes(true)
+}
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	for _, route := range apidocs.Undocumented(apiRoutes()) {
+		t.Errorf("%s has no endpoint in apidocs.Endpoints", route)
+	}
+}
+
+func TestEveryEndpointHasARoute(t *testing.T) {
+	for _, key := range apidocs.Unrouted(apiRoutes()) {
+		t.Errorf("%s is documented but has no route", key)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	doc, _ := apidocs.Generate(apiRoutes())
+
+	if len(doc.Paths) == 0 {
+		t.Fatal("document has no paths")
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			if op.Summary == "" {
+				t.Errorf("%s %s has no summary", method, path)
+			}
+			if len(op.Tags) != 1 {
+				t.Errorf("%s %s has tags %v, want one", method, path, op.Tags)
+			}
+		}
+	}
+}
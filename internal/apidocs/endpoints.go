@@ -0,0 +1,527 @@
+package apidocs
+
+import (
+	"lms-backend/internal/dataaccess/auditlog"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/dataaccess/bookimport"
+	"lms-backend/internal/dataaccess/bookmark"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/dataaccess/contributor"
+	"lms-backend/internal/dataaccess/fine"
+	"lms-backend/internal/dataaccess/loan"
+	"lms-backend/internal/dataaccess/publisher"
+	"lms-backend/internal/dataaccess/reservation"
+	"lms-backend/internal/dataaccess/subject"
+	"lms-backend/internal/dataaccess/transfer"
+	"lms-backend/internal/dataaccess/user"
+	"lms-backend/internal/params/auditlogparams"
+	"lms-backend/internal/params/bookcopyparams"
+	"lms-backend/internal/params/bookimportparams"
+	"lms-backend/internal/params/bookparams"
+	"lms-backend/internal/params/branchparams"
+	"lms-backend/internal/params/reservationparams"
+	"lms-backend/internal/params/sharedparams"
+	"lms-backend/internal/params/transferparams"
+	"lms-backend/internal/params/userparams"
+	"lms-backend/internal/view/auditlogview"
+	"lms-backend/internal/view/bookcopyview"
+	"lms-backend/internal/view/bookimportview"
+	"lms-backend/internal/view/bookmarkview"
+	"lms-backend/internal/view/bookview"
+	"lms-backend/internal/view/branchview"
+	"lms-backend/internal/view/contributorview"
+	"lms-backend/internal/view/fineview"
+	"lms-backend/internal/view/loanview"
+	"lms-backend/internal/view/metadataview"
+	"lms-backend/internal/view/publisherview"
+	"lms-backend/internal/view/reservationview"
+	"lms-backend/internal/view/sharedview"
+	"lms-backend/internal/view/subjectview"
+	"lms-backend/internal/view/transferview"
+	"lms-backend/internal/view/userview"
+	"lms-backend/pkg/marc"
+	"lms-backend/pkg/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	mimeMARC    = "application/marc"
+	mimeMARCXML = "application/marcxml+xml"
+	mimePNG     = "image/png"
+
+	uploadField = "file"
+)
+
+var (
+	bookFieldsParam = queryParam("fields[book]", openapi.String(),
+		"Comma-separated fields of the book to show. The id is always shown.")
+	bookIncludeParam = queryParam("include", openapi.String(),
+		"Comma-separated associations to show instead of the default ones, any of copies, thumbnail and bookmarks.")
+	dryRunParam = queryParam("dry_run", openapi.Boolean(), "Validates the file without saving anything.")
+	marcFormat  = openapi.Enum(marc.FormatISO2709, marc.FormatXML)
+)
+
+// Endpoints documents every route of the API. A route missing from here is logged when the
+// app starts and fails the tests of this package.
+var Endpoints = map[string]Endpoint{
+	// Public routes
+	"GET /health": {
+		Summary: "Check that the server is running and get a CSRF token",
+		Public:  true,
+		Data:    "",
+	},
+	"GET /openapi.json": {
+		Summary: "Get this document",
+		Public:  true,
+		Files:   []string{fiber.MIMEApplicationJSON},
+	},
+	"GET /docs": {
+		Summary: "Browse this document",
+		Public:  true,
+		Files:   []string{fiber.MIMETextHTML},
+	},
+	"POST /auth/signin": {
+		Summary: "Sign in",
+		Public:  true,
+		Body:    userparams.SignInParams{},
+		Data:    userview.LoginView{},
+	},
+	"GET /current": {
+		Summary: "Get the signed in user and their abilities, or a guest",
+		Public:  true,
+		Data:    userview.CurrentUserView{},
+	},
+	"POST /user": {
+		Summary: "Sign up",
+		Public:  true,
+		Body:    userparams.CreateParams{},
+		Data:    userview.View{},
+	},
+	"GET /book": {
+		Summary: "List or search books",
+		Description: "With q the books are searched by title, author, genre and publisher, best matches first " +
+			"with their rank and highlights. With facets=true the meta also counts the filtered books by property.",
+		Public: true,
+		Query: []openapi.Parameter{
+			queryParam("q", openapi.String(), "Search query"),
+			queryParam("facets", openapi.Boolean(), "Counts the filtered books by property in the meta."),
+			queryParam("fields[book]", openapi.String(),
+				"Comma-separated fields of the book to show, including availability, rank and highlight. The id is always shown."),
+			bookIncludeParam,
+		},
+		Filters:    book.Filters(),
+		Sorters:    book.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []bookview.SearchResultView{},
+	},
+	"GET /book/popular": {
+		Summary: "List the most loaned books",
+		Public:  true,
+		Data:    []bookview.PopularView{},
+	},
+	"GET /book/{book_id}": {
+		Summary: "Get a book with its copies and thumbnail",
+		Public:  true,
+		Query:   []openapi.Parameter{bookFieldsParam, bookIncludeParam},
+		Data:    bookview.DetailedView{},
+	},
+	"GET /branch": {
+		Summary:   "List branches",
+		Public:    true,
+		Filters:   branch.Filters(),
+		Sorters:   branch.Sorters(),
+		Paginated: true,
+		Data:      []branchview.View{},
+	},
+	"GET /contributor": {
+		Summary:   "List contributors",
+		Public:    true,
+		Filters:   contributor.Filters(),
+		Sorters:   contributor.Sorters(),
+		Paginated: true,
+		Data:      []contributorview.View{},
+	},
+	"GET /subject": {
+		Summary:   "List subjects",
+		Public:    true,
+		Filters:   subject.Filters(),
+		Sorters:   subject.Sorters(),
+		Paginated: true,
+		Data:      []subjectview.View{},
+	},
+	"GET /publisher": {
+		Summary:   "List publishers",
+		Public:    true,
+		Filters:   publisher.Filters(),
+		Sorters:   publisher.Sorters(),
+		Paginated: true,
+		Data:      []publisherview.View{},
+	},
+
+	// Auth
+	"GET /auth/signout": {
+		Summary: "Sign out",
+	},
+
+	// Users
+	"GET /user": {
+		Summary:    "List users",
+		Filters:    user.Filters(),
+		Sorters:    user.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []userview.View{},
+	},
+	"GET /user/{user_id}": {
+		Summary: "Get a user",
+		Data:    userview.View{},
+	},
+	"PATCH /user/{user_id}": {
+		Summary:   "Update a user",
+		Body:      userparams.UpdateParams{},
+		Versioned: true,
+		Data:      userview.View{},
+	},
+	"DELETE /user/{user_id}": {
+		Summary:   "Delete a user",
+		Versioned: true,
+		Data:      userview.View{},
+	},
+	"PATCH /user/{user_id}/role": {
+		Summary: "Change the role of a user",
+		Body:    userparams.UpdateRoleParams{},
+		Data:    userview.View{},
+	},
+	"PATCH /user/{user_id}/branch": {
+		Summary: "Change the branch of a user",
+		Body:    userparams.UpdateBranchParams{},
+		Data:    userview.View{},
+	},
+	"GET /user/autocomplete/{value}": {
+		Summary: "Suggest users by name",
+		Data:    []userview.AutoCompleteView{},
+	},
+
+	// Books
+	"POST /book": {
+		Summary: "Create a book",
+		Body:    bookparams.CreateParams{},
+		Data:    bookview.DetailedView{},
+	},
+	"PATCH /book/{book_id}": {
+		Summary:   "Update a book",
+		Body:      bookparams.UpdateParams{},
+		Versioned: true,
+		Data:      bookview.View{},
+	},
+	"DELETE /book/{book_id}": {
+		Summary:   "Delete a book",
+		Versioned: true,
+		Data:      bookview.View{},
+	},
+	"PATCH /book/{book_id}/thumbnail": {
+		Summary: "Upload the thumbnail of a book",
+		Upload:  uploadField,
+		Data:    bookview.DetailedView{},
+	},
+	"POST /book/{book_id}/merge": {
+		Summary: "Merge duplicates into a book",
+		Body:    bookparams.MergeParams{},
+		Data:    bookview.DetailedView{},
+	},
+	"GET /book/duplicates": {
+		Summary: "List groups of books that look like duplicates",
+		Data:    bookview.DuplicateReportView{},
+	},
+	"GET /book/autocomplete/{value}": {
+		Summary: "Suggest books by title",
+		Data:    []bookview.AutoCompleteView{},
+	},
+	"GET /book/import": {
+		Summary:   "List imports of books",
+		Filters:   bookimport.Filters(),
+		Sorters:   bookimport.Sorters(),
+		Paginated: true,
+		Data:      []bookimportview.View{},
+	},
+	"POST /book/import": {
+		Summary:     "Import books from a CSV or XLSX file",
+		Description: "The rows are imported in the background, follow the progress with the returned import.",
+		Query:       []openapi.Parameter{dryRunParam},
+		Upload:      uploadField,
+		Form:        bookimportparams.ImportParams{},
+		Data:        bookimportview.View{},
+	},
+	"POST /book/import/columns": {
+		Summary: "Read the columns of a CSV or XLSX file and suggest their mapping",
+		Upload:  uploadField,
+		Data:    bookimportview.ColumnsView{},
+	},
+	"GET /book/import/{import_id}": {
+		Summary: "Get the progress and errors of an import",
+		Data:    bookimportview.DetailedView{},
+	},
+	"POST /book/import/marc": {
+		Summary: "Import books from a MARC21 or MARCXML file",
+		Query: []openapi.Parameter{
+			queryParam("format", marcFormat, "Format of the file, detected if left out."),
+			dryRunParam,
+		},
+		Upload: uploadField,
+		Data:   bookview.MARCImportReportView{},
+	},
+	"GET /book/export/marc": {
+		Summary: "Export books as MARC21 or MARCXML",
+		Query: []openapi.Parameter{
+			queryParam("format", marcFormat, "Defaults to "+marc.FormatISO2709),
+		},
+		Filters: book.Filters(),
+		Sorters: book.Sorters(),
+		Files:   []string{mimeMARC, mimeMARCXML},
+	},
+	"POST /book/{book_id}/bookmark": {
+		Summary: "Bookmark a book",
+		Data:    bookmarkview.DetailedView{},
+	},
+	"POST /book/{book_id}/bookcopy": {
+		Summary: "Add copies of a book",
+		Query: []openapi.Parameter{
+			queryParam("count", openapi.Integer(), "Number of copies, defaults to 1."),
+			queryParam("branch_id", openapi.Integer(), "Branch of the copies, defaults to the main branch."),
+		},
+		Data: []sharedview.BookCopyView{},
+	},
+
+	// Book copies
+	"GET /bookcopy/{bookcopy_id}": {
+		Summary: "Get a book copy",
+		Data:    bookcopyview.DetailedView{},
+	},
+	"PATCH /bookcopy/{bookcopy_id}": {
+		Summary:   "Update a book copy",
+		Body:      bookcopyparams.UpdateParams{},
+		Versioned: true,
+		Data:      bookcopyview.DetailedView{},
+	},
+	"DELETE /bookcopy/{bookcopy_id}": {
+		Summary:   "Delete a book copy",
+		Versioned: true,
+		Data:      bookcopyview.DetailedView{},
+	},
+	"GET /bookcopy/{bookcopy_id}/qrcode": {
+		Summary: "Get the QR code of a book copy",
+		Files:   []string{mimePNG},
+	},
+	"POST /bookcopy/{bookcopy_id}/transfer": {
+		Summary: "Send a book copy to another branch",
+		Body:    transferparams.CreateParams{},
+		Data:    transferview.DetailedView{},
+	},
+	"POST /bookcopy/{bookcopy_id}/loan": {
+		Summary:    "Borrow a book copy",
+		Idempotent: true,
+		Data:       loanview.DetailedView{},
+	},
+	"PATCH /bookcopy/{bookcopy_id}/loan/return": {
+		Summary:    "Return the loaned book copy",
+		Idempotent: true,
+		Data:       loanview.DetailedView{},
+	},
+	"POST /bookcopy/{bookcopy_id}/reservation": {
+		Summary:    "Reserve a book copy",
+		Idempotent: true,
+		Query: []openapi.Parameter{
+			queryParam("pickup_branch_id", openapi.Integer(), "Branch to pick the copy up from, defaults to its own."),
+		},
+		Data: reservationview.DetailedView{},
+	},
+	"PATCH /bookcopy/{bookcopy_id}/reservation/cancel": {
+		Summary:    "Cancel the reservation of a book copy",
+		Idempotent: true,
+		Data:       reservationview.DetailedView{},
+	},
+
+	// Branches
+	"POST /branch": {
+		Summary: "Create a branch",
+		Body:    branchparams.CreateParams{},
+		Data:    branchview.View{},
+	},
+	"PATCH /branch/{branch_id}": {
+		Summary: "Update a branch",
+		Body:    branchparams.UpdateParams{},
+		Data:    branchview.View{},
+	},
+	"DELETE /branch/{branch_id}": {
+		Summary: "Delete a branch",
+		Data:    branchview.View{},
+	},
+
+	// Transfers
+	"GET /transfer": {
+		Summary:   "List transfers of book copies between branches",
+		Filters:   transfer.Filters(),
+		Sorters:   transfer.Sorters(),
+		Paginated: true,
+		Data:      []transferview.DetailedView{},
+	},
+	"PATCH /transfer/{transfer_id}/receive": {
+		Summary: "Receive a transferred book copy",
+		Data:    transferview.DetailedView{},
+	},
+
+	// Bookmarks
+	"GET /bookmark": {
+		Summary:   "List bookmarks",
+		Filters:   bookmark.Filters(),
+		Sorters:   bookmark.Sorters(),
+		Paginated: true,
+		Data:      []bookmarkview.DetailedView{},
+	},
+	"DELETE /bookmark/{bookmark_id}": {
+		Summary: "Delete a bookmark",
+		Data:    bookmarkview.DetailedView{},
+	},
+
+	// Loans
+	"GET /loan": {
+		Summary:    "List loans",
+		Filters:    loan.Filters(),
+		Sorters:    loan.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []loanview.DetailedView{},
+	},
+	"POST /loan": {
+		Summary:    "Loan a book copy to a user",
+		Idempotent: true,
+		Body:       sharedparams.UserBookcopyParams{},
+		Data:       loanview.DetailedView{},
+	},
+	"POST /loan/book": {
+		Summary:    "Loan any available copy of a book to a user",
+		Idempotent: true,
+		Body:       sharedparams.UserBookParams{},
+		Data:       loanview.DetailedView{},
+	},
+	"GET /loan/{loan_id}": {
+		Summary: "Get a loan",
+		Data:    loanview.DetailedView{},
+	},
+	"PATCH /loan/{loan_id}/return": {
+		Summary:    "Return a loan",
+		Idempotent: true,
+		Versioned:  true,
+		Data:       loanview.DetailedView{},
+	},
+	"PATCH /loan/{loan_id}/renew": {
+		Summary:    "Renew a loan",
+		Idempotent: true,
+		Versioned:  true,
+		Data:       loanview.DetailedView{},
+	},
+
+	// Reservations
+	"GET /reservation": {
+		Summary:    "List reservations",
+		Filters:    reservation.Filters(),
+		Sorters:    reservation.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []reservationview.DetailedView{},
+	},
+	"POST /reservation": {
+		Summary:    "Reserve a book copy for a user",
+		Idempotent: true,
+		Body:       reservationparams.CreateParams{},
+		Data:       reservationview.DetailedView{},
+	},
+	"POST /reservation/book": {
+		Summary:    "Reserve any copy of a book for a user",
+		Idempotent: true,
+		Body:       reservationparams.CreateByBookParams{},
+		Data:       reservationview.DetailedView{},
+	},
+	"GET /reservation/{reservation_id}": {
+		Summary: "Get a reservation",
+		Data:    reservationview.DetailedView{},
+	},
+	"PATCH /reservation/{reservation_id}/cancel": {
+		Summary:    "Cancel a reservation",
+		Idempotent: true,
+		Data:       reservationview.DetailedView{},
+	},
+
+	// Fines
+	"GET /fine": {
+		Summary:    "List fines",
+		Filters:    fine.Filters(),
+		Sorters:    fine.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []fineview.DetailedView{},
+	},
+	"PATCH /fine/{fine_id}/settle": {
+		Summary:    "Settle a fine",
+		Idempotent: true,
+		Data:       fineview.DetailedView{},
+	},
+	"DELETE /fine/{fine_id}": {
+		Summary: "Delete a fine",
+		Data:    fineview.DetailedView{},
+	},
+
+	// Audit logs
+	"GET /audit_log": {
+		Summary:    "List audit logs",
+		Filters:    auditlog.Filters(),
+		Sorters:    auditlog.Sorters(),
+		Paginated:  true,
+		Exportable: true,
+		Data:       []auditlogview.DetailedView{},
+	},
+	"GET /audit_log/verify": {
+		Summary:     "Verify the audit log",
+		Description: "Checks that no entry of the hash chain was changed or removed, and reports the first that was.",
+		Data:        auditlogview.VerificationView{},
+	},
+	"POST /audit_log": {
+		Summary: "Create an audit log",
+		Body:    auditlogparams.BaseParams{},
+		Data:    auditlogview.DetailedView{},
+	},
+
+	// External metadata
+	"GET /external": {
+		Summary: "Search the metadata providers for books",
+		Query: []openapi.Parameter{
+			queryParam("title", openapi.String(), ""),
+			queryParam("author", openapi.String(), ""),
+			queryParam("publisher", openapi.String(), ""),
+			queryParam("isbn", openapi.String(), ""),
+		},
+		Data: []metadataview.ResultView{},
+	},
+	"POST /external/volume/{volume_id}/import": {
+		Summary: "Create a book with one copy from a Google Books volume",
+		Data:    bookview.DetailedView{},
+	},
+
+	// Files
+	"POST /file/image": {
+		Summary: "Upload an image",
+		Upload:  uploadField,
+		Data:    sharedview.FileUploadView{},
+	},
+}
+
+func queryParam(name string, schema *openapi.Schema, description string) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          openapi.InQuery,
+		Description: description,
+		Schema:      schema,
+	}
+}
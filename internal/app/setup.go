@@ -6,6 +6,7 @@ package app
 import (
 	migratedb "lms-backend/cmd/migratedb/migrate"
 	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/config"
 	"lms-backend/internal/cron"
 	"lms-backend/internal/database"
@@ -38,10 +39,19 @@ func SetupAndRunApp() error {
 		return err
 	}
 
+	err = audit.RegisterCallbacks(database.DB)
+	if err != nil {
+		return err
+	}
+
 	// create app
 	app := fiber.New(fiber.Config{
 		AppName:      cfg.AppName,
 		ErrorHandler: api.ErrorHandler,
+		// The IP of the client is read from the header only if the request is from a trusted proxy
+		ProxyHeader:             cfg.ProxyHeader,
+		EnableTrustedProxyCheck: len(cfg.TrustedProxies) > 0,
+		TrustedProxies:          cfg.TrustedProxies,
 	})
 
 	// setup routes
@@ -66,5 +76,5 @@ func LoadEnvAndConnectToDB() error {
 		return err
 	}
 
-	return nil
+	return audit.RegisterCallbacks(database.DB)
 }
@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	rowsBeforeKey = "audit:rows_before"
+)
+
+// entityRow is the row of an entity before it is updated or deleted.
+type entityRow struct {
+	id  uint
+	row map[string]interface{}
+}
+
+// RegisterCallbacks records the entities created, updated and deleted in transactions
+// started by Begin, so that an audit log entry is created for each of them.
+//
+// Only entities written by their primary key are recorded, e.g. db.Updates(&book),
+// and not those written by conditions, e.g. db.Model(&model.Book{}).Where(...).Updates(...).
+func RegisterCallbacks(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("audit:before_create", readRowsBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("audit:after_create", afterCreate); err != nil {
+		return err
+	}
+
+	if err := callbacks.Update().Before("gorm:update").Register("audit:before_update", readRowsBefore); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("audit:after_update", afterUpdate); err != nil {
+		return err
+	}
+
+	if err := callbacks.Delete().Before("gorm:delete").Register("audit:before_delete", readRowsBefore); err != nil {
+		return err
+	}
+
+	return callbacks.Delete().After("gorm:delete").Register("audit:after_delete", afterDelete)
+}
+
+func afterCreate(db *gorm.DB) {
+	r := recorderFrom(db.Statement.Context)
+	if r == nil || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	// Associations are saved with ON CONFLICT, and those that already existed are not created
+	existing := map[uint]bool{}
+	for _, before := range rowsBefore(db) {
+		existing[before.id] = true
+	}
+
+	for _, id := range writtenIDs(db) {
+		if existing[id] {
+			continue
+		}
+
+		after, err := readRow(db, id)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		r.record(model.AuditLogActionCreate, db.Statement.Table, id, nil, after)
+	}
+}
+
+// readRowsBefore reads the rows about to be written, to be compared after.
+func readRowsBefore(db *gorm.DB) {
+	if recorderFrom(db.Statement.Context) == nil || db.Error != nil {
+		return
+	}
+
+	rows := []entityRow{}
+	for _, id := range writtenIDs(db) {
+		before, err := readRow(db, id)
+		if orm.IsRecordNotFound(err) {
+			continue
+		}
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		rows = append(rows, entityRow{id: id, row: before})
+	}
+
+	db.InstanceSet(rowsBeforeKey, rows)
+}
+
+func afterUpdate(db *gorm.DB) {
+	r := recorderFrom(db.Statement.Context)
+	if r == nil || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	for _, before := range rowsBefore(db) {
+		after, err := readRow(db, before.id)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+
+		r.record(model.AuditLogActionUpdate, db.Statement.Table, before.id, before.row, after)
+	}
+}
+
+func afterDelete(db *gorm.DB) {
+	r := recorderFrom(db.Statement.Context)
+	if r == nil || db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	for _, before := range rowsBefore(db) {
+		r.record(model.AuditLogActionDelete, db.Statement.Table, before.id, before.row, nil)
+	}
+}
+
+func rowsBefore(db *gorm.DB) []entityRow {
+	rows, ok := db.InstanceGet(rowsBeforeKey)
+	if !ok {
+		return nil
+	}
+
+	//nolint:errcheck // only set by readRowsBefore
+	before, _ := rows.([]entityRow)
+	return before
+}
+
+// writtenIDs returns the IDs of the records of the statement that have an integer primary
+// key, which excludes join tables and the audit log itself.
+func writtenIDs(db *gorm.DB) []uint {
+	stmt := db.Statement
+	if stmt.Schema == nil || stmt.Table == model.AuditLogTableName || len(stmt.Schema.PrimaryFields) != 1 {
+		return nil
+	}
+
+	field := stmt.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return nil
+	}
+
+	values := []reflect.Value{}
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			values = append(values, reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		values = append(values, stmt.ReflectValue)
+	}
+
+	ids := []uint{}
+	for _, value := range values {
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+
+		id, isZero := field.ValueOf(stmt.Context, value)
+		if isZero {
+			continue
+		}
+
+		switch v := reflect.ValueOf(id); {
+		case v.CanUint():
+			ids = append(ids, uint(v.Uint()))
+		case v.CanInt():
+			ids = append(ids, uint(v.Int()))
+		}
+	}
+
+	return ids
+}
+
+// readRow reads the columns of the entity in the transaction of the statement,
+// including soft deleted rows.
+func readRow(db *gorm.DB, id uint) (map[string]interface{}, error) {
+	row := map[string]interface{}{}
+
+	result := orm.NewSession(db).
+		Table(db.Statement.Table).
+		Where(clause.Eq{Column: clause.Column{Name: db.Statement.Schema.PrioritizedPrimaryField.DBName}, Value: id}).
+		Take(&row)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return row, nil
+}
@@ -1,11 +1,14 @@
 package audit
 
 import (
+	"context"
 	"lms-backend/internal/database"
+	"lms-backend/internal/middleware"
 	"lms-backend/internal/model"
 	"lms-backend/internal/session"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"gorm.io/gorm"
 )
 
@@ -13,18 +16,34 @@ import (
 //
 // The function will commit the transaction if no panic occurs, otherwise it will rollback.
 //
-// The function will also create an audit log entry with the provided action message.
+// The function will also create an audit log entry with the provided action message for each
+// entity created, updated or deleted in the transaction, with the changes to its columns.
+// If no entity is changed, a single entry is created.
 func Begin(c *fiber.Ctx, action string) (*gorm.DB, func(error)) {
-	var userID int64 = 1 // Default to 1 (admin)
+	entry := model.AuditLog{
+		UserID: 1, // Default to 1 (admin)
+		Action: action,
+	}
+
+	ctx := context.Background()
 	if c != nil {
 		usrID, err := session.GetLoginSession(c)
 		if err == nil {
-			userID = usrID
+			entry.UserID = uint(usrID)
 		}
+
+		ctx = c.UserContext()
+		entry.RequestID = middleware.GetRequestID(c)
+		entry.IPAddress = c.IP()
+	}
+	if entry.RequestID == "" {
+		// Jobs have no request, but their entries are still grouped
+		entry.RequestID = utils.UUIDv4()
 	}
 
+	rec := &recorder{}
 	db := database.GetDB()
-	tx := db.Begin()
+	tx := db.WithContext(withRecorder(ctx, rec)).Begin()
 
 	var deferedRollBackOrCommit = func(err error) {
 		//nolint
@@ -33,14 +52,12 @@ func Begin(c *fiber.Ctx, action string) (*gorm.DB, func(error)) {
 			return
 		}
 
-		auditLog := model.AuditLog{
-			UserID: uint(userID),
-			Action: action,
-		}
-
-		if err := auditLog.Create(tx); err != nil {
-			tx.Rollback()
-			return
+		for _, auditLog := range rec.auditLogs(entry) {
+			//nolint:gosec // loop does not modify struct
+			if err := auditLog.Create(tx); err != nil {
+				tx.Rollback()
+				return
+			}
 		}
 
 		tx.Commit()
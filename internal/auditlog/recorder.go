@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"context"
+	"lms-backend/internal/model"
+	"lms-backend/util/sliceutil"
+	"reflect"
+	"sync"
+)
+
+const (
+	filteredValue = "[FILTERED]"
+)
+
+var (
+	// Columns that change on every write, and are left out of the changes
+	ignoredColumns = []string{"created_at", "updated_at"}
+	// Columns of which only the change, and not the value, is recorded
+	filteredColumns = []string{"encrypted_password"}
+)
+
+type recorderKey struct{}
+
+// entityChange is the change to an entity over a transaction.
+type entityChange struct {
+	actionType model.AuditLogActionType
+	entityType string
+	entityID   uint
+	changes    model.AuditLogChanges
+}
+
+// recorder collects the changes to the entities written in a transaction, merging the
+// writes to the same entity into one change.
+type recorder struct {
+	mu      sync.Mutex
+	changes []*entityChange
+}
+
+func withRecorder(ctx context.Context, r *recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, r)
+}
+
+func recorderFrom(ctx context.Context) *recorder {
+	if ctx == nil {
+		return nil
+	}
+
+	//nolint:errcheck // nil outside of audited transactions
+	r, _ := ctx.Value(recorderKey{}).(*recorder)
+	return r
+}
+
+// record adds a write to the entity, given its row before and after the write.
+// before is nil for created entities and after is nil for deleted ones.
+func (r *recorder) record(actionType model.AuditLogActionType, entityType string, entityID uint, before, after map[string]interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	diff := diffRows(before, after)
+
+	for i, change := range r.changes {
+		if change.entityType != entityType || change.entityID != entityID {
+			continue
+		}
+
+		// Created entities stay created when updated later on, and are left out if deleted
+		switch {
+		case change.actionType == model.AuditLogActionCreate && actionType == model.AuditLogActionDelete:
+			r.changes = append(r.changes[:i], r.changes[i+1:]...)
+			return
+		case change.actionType != model.AuditLogActionCreate:
+			change.actionType = actionType
+		}
+
+		for column, c := range diff {
+			if previous, ok := change.changes[column]; ok {
+				c.Old = previous.Old
+			}
+			change.changes[column] = c
+		}
+
+		if change.actionType == model.AuditLogActionUpdate {
+			for column, c := range change.changes {
+				if !sliceutil.Contains(filteredColumns, column) && reflect.DeepEqual(c.Old, c.New) {
+					delete(change.changes, column)
+				}
+			}
+		}
+		return
+	}
+
+	if actionType == model.AuditLogActionUpdate && len(diff) == 0 {
+		return
+	}
+
+	r.changes = append(r.changes, &entityChange{
+		actionType: actionType,
+		entityType: entityType,
+		entityID:   entityID,
+		changes:    diff,
+	})
+}
+
+// auditLogs returns an entry for each entity changed, copied from entry, or entry itself
+// if no entity was changed.
+func (r *recorder) auditLogs(entry model.AuditLog) []model.AuditLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	logs := []model.AuditLog{}
+	for _, change := range r.changes {
+		if change.actionType == model.AuditLogActionUpdate && len(change.changes) == 0 {
+			continue
+		}
+
+		log := entry
+		log.ActionType = change.actionType
+		log.EntityType = change.entityType
+		log.EntityID = change.entityID
+		log.Changes = change.changes
+		logs = append(logs, log)
+	}
+
+	if len(logs) == 0 {
+		entry.ActionType = model.AuditLogActionOther
+		logs = append(logs, entry)
+	}
+
+	return logs
+}
+
+// diffRows returns the columns of which the value differs between the rows.
+func diffRows(before, after map[string]interface{}) model.AuditLogChanges {
+	changes := model.AuditLogChanges{}
+
+	columns := map[string]bool{}
+	for column := range before {
+		columns[column] = true
+	}
+	for column := range after {
+		columns[column] = true
+	}
+
+	for column := range columns {
+		if sliceutil.Contains(ignoredColumns, column) {
+			continue
+		}
+
+		oldValue, newValue := normalizeValue(before[column]), normalizeValue(after[column])
+		if before != nil && after != nil && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+
+		// Old and new stay null for created and deleted entities
+		if sliceutil.Contains(filteredColumns, column) {
+			if before != nil {
+				oldValue = filteredValue
+			}
+			if after != nil {
+				newValue = filteredValue
+			}
+		}
+		changes[column] = model.AuditLogChange{Old: oldValue, New: newValue}
+	}
+
+	return changes
+}
+
+// normalizeValue converts bytes, e.g. of text or json columns, to strings so that they
+// are not encoded in base64.
+func normalizeValue(value interface{}) interface{} {
+	if b, ok := value.([]byte); ok {
+		return string(b)
+	}
+
+	return value
+}
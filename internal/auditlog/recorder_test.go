@@ -0,0 +1,264 @@
+package audit
+
+import (
+	"lms-backend/internal/model"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffRows(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		before map[string]interface{}
+		after  map[string]interface{}
+		want   model.AuditLogChanges
+	}{
+		{
+			name:   "changed columns",
+			before: map[string]interface{}{"id": int64(1), "title": "Dune", "copies": int64(1)},
+			after:  map[string]interface{}{"id": int64(1), "title": "Dune Messiah", "copies": int64(1)},
+			want:   model.AuditLogChanges{"title": {Old: "Dune", New: "Dune Messiah"}},
+		},
+		{
+			name:   "no changes",
+			before: map[string]interface{}{"id": int64(1), "title": "Dune"},
+			after:  map[string]interface{}{"id": int64(1), "title": "Dune"},
+			want:   model.AuditLogChanges{},
+		},
+		{
+			name:   "ignored columns",
+			before: map[string]interface{}{"id": int64(1), "created_at": created, "updated_at": created},
+			after:  map[string]interface{}{"id": int64(1), "created_at": created, "updated_at": created.Add(time.Hour)},
+			want:   model.AuditLogChanges{},
+		},
+		{
+			name:   "bytes are compared and recorded as text",
+			before: map[string]interface{}{"notes": []byte(`{"a":1}`), "isbn": []byte("9780441172719")},
+			after:  map[string]interface{}{"notes": []byte(`{"a":2}`), "isbn": []byte("9780441172719")},
+			want:   model.AuditLogChanges{"notes": {Old: `{"a":1}`, New: `{"a":2}`}},
+		},
+		{
+			name:   "column set to null",
+			before: map[string]interface{}{"deleted_at": nil, "due_date": created},
+			after:  map[string]interface{}{"deleted_at": created, "due_date": nil},
+			want: model.AuditLogChanges{
+				"deleted_at": {Old: nil, New: created},
+				"due_date":   {Old: created, New: nil},
+			},
+		},
+		{
+			name:   "filtered column",
+			before: map[string]interface{}{"username": "alice", "encrypted_password": "old hash"},
+			after:  map[string]interface{}{"username": "alice", "encrypted_password": "new hash"},
+			want:   model.AuditLogChanges{"encrypted_password": {Old: filteredValue, New: filteredValue}},
+		},
+		{
+			name:   "unchanged filtered column",
+			before: map[string]interface{}{"username": "alice", "encrypted_password": "hash"},
+			after:  map[string]interface{}{"username": "bob", "encrypted_password": "hash"},
+			want:   model.AuditLogChanges{"username": {Old: "alice", New: "bob"}},
+		},
+		{
+			name:  "created",
+			after: map[string]interface{}{"id": int64(1), "title": "Dune", "notes": nil, "encrypted_password": "hash", "created_at": created},
+			want: model.AuditLogChanges{
+				"id":                 {Old: nil, New: int64(1)},
+				"title":              {Old: nil, New: "Dune"},
+				"notes":              {Old: nil, New: nil},
+				"encrypted_password": {Old: nil, New: filteredValue},
+			},
+		},
+		{
+			name:   "deleted",
+			before: map[string]interface{}{"id": int64(1), "title": "Dune", "encrypted_password": "hash", "updated_at": created},
+			want: model.AuditLogChanges{
+				"id":                 {Old: int64(1), New: nil},
+				"title":              {Old: "Dune", New: nil},
+				"encrypted_password": {Old: filteredValue, New: nil},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffRows(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffRows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// write is a write recorded in a transaction.
+type write struct {
+	actionType model.AuditLogActionType
+	entityType string
+	entityID   uint
+	before     map[string]interface{}
+	after      map[string]interface{}
+}
+
+func TestRecorderAuditLogs(t *testing.T) {
+	row := func(status string) map[string]interface{} {
+		return map[string]interface{}{"id": int64(1), "status": status}
+	}
+
+	tests := []struct {
+		name   string
+		writes []write
+		want   []model.AuditLog
+	}{
+		{
+			name: "no writes",
+			want: []model.AuditLog{{ActionType: model.AuditLogActionOther}},
+		},
+		{
+			name: "update without changes",
+			writes: []write{
+				{model.AuditLogActionUpdate, "loans", 1, row("borrowed"), row("borrowed")},
+			},
+			want: []model.AuditLog{{ActionType: model.AuditLogActionOther}},
+		},
+		{
+			name: "entities in order",
+			writes: []write{
+				{model.AuditLogActionUpdate, "book_copies", 1, row("available"), row("on_loan")},
+				{model.AuditLogActionCreate, "loans", 1, nil, row("borrowed")},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionUpdate, EntityType: "book_copies", EntityID: 1,
+					Changes: model.AuditLogChanges{"status": {Old: "available", New: "on_loan"}},
+				},
+				{
+					ActionType: model.AuditLogActionCreate, EntityType: "loans", EntityID: 1,
+					Changes: model.AuditLogChanges{
+						"id":     {Old: nil, New: int64(1)},
+						"status": {Old: nil, New: "borrowed"},
+					},
+				},
+			},
+		},
+		{
+			name: "updates are merged from the first old value to the last new value",
+			writes: []write{
+				{model.AuditLogActionUpdate, "book_copies", 1, row("available"), row("on_reserve")},
+				{model.AuditLogActionUpdate, "book_copies", 1, row("on_reserve"), row("on_loan")},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionUpdate, EntityType: "book_copies", EntityID: 1,
+					Changes: model.AuditLogChanges{"status": {Old: "available", New: "on_loan"}},
+				},
+			},
+		},
+		{
+			name: "updates back to the old value",
+			writes: []write{
+				{model.AuditLogActionUpdate, "book_copies", 1, row("available"), row("on_loan")},
+				{model.AuditLogActionUpdate, "book_copies", 1, row("on_loan"), row("available")},
+			},
+			want: []model.AuditLog{{ActionType: model.AuditLogActionOther}},
+		},
+		{
+			name: "same ID of another entity type",
+			writes: []write{
+				{model.AuditLogActionUpdate, "book_copies", 1, row("available"), row("on_loan")},
+				{model.AuditLogActionUpdate, "loans", 1, row("borrowed"), row("returned")},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionUpdate, EntityType: "book_copies", EntityID: 1,
+					Changes: model.AuditLogChanges{"status": {Old: "available", New: "on_loan"}},
+				},
+				{
+					ActionType: model.AuditLogActionUpdate, EntityType: "loans", EntityID: 1,
+					Changes: model.AuditLogChanges{"status": {Old: "borrowed", New: "returned"}},
+				},
+			},
+		},
+		{
+			name: "created and updated",
+			writes: []write{
+				{model.AuditLogActionCreate, "loans", 1, nil, row("borrowed")},
+				{model.AuditLogActionUpdate, "loans", 1, row("borrowed"), row("returned")},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionCreate, EntityType: "loans", EntityID: 1,
+					Changes: model.AuditLogChanges{
+						"id":     {Old: nil, New: int64(1)},
+						"status": {Old: nil, New: "returned"},
+					},
+				},
+			},
+		},
+		{
+			name: "created and deleted",
+			writes: []write{
+				{model.AuditLogActionCreate, "loans", 1, nil, row("borrowed")},
+				{model.AuditLogActionDelete, "loans", 1, row("borrowed"), nil},
+			},
+			want: []model.AuditLog{{ActionType: model.AuditLogActionOther}},
+		},
+		{
+			name: "updated and deleted",
+			writes: []write{
+				{model.AuditLogActionUpdate, "loans", 1, row("borrowed"), row("returned")},
+				{model.AuditLogActionDelete, "loans", 1, row("returned"), nil},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionDelete, EntityType: "loans", EntityID: 1,
+					Changes: model.AuditLogChanges{
+						"id":     {Old: int64(1), New: nil},
+						"status": {Old: "borrowed", New: nil},
+					},
+				},
+			},
+		},
+		{
+			name: "filtered column changed and changed back",
+			writes: []write{
+				{
+					model.AuditLogActionUpdate, "users", 1,
+					map[string]interface{}{"encrypted_password": "a"},
+					map[string]interface{}{"encrypted_password": "b"},
+				},
+				{
+					model.AuditLogActionUpdate, "users", 1,
+					map[string]interface{}{"encrypted_password": "b"},
+					map[string]interface{}{"encrypted_password": "a"},
+				},
+			},
+			want: []model.AuditLog{
+				{
+					ActionType: model.AuditLogActionUpdate, EntityType: "users", EntityID: 1,
+					Changes: model.AuditLogChanges{"encrypted_password": {Old: filteredValue, New: filteredValue}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &recorder{}
+			for _, w := range tt.writes {
+				r.record(w.actionType, w.entityType, w.entityID, w.before, w.after)
+			}
+
+			entry := model.AuditLog{UserID: 1, Action: "alice loaning Dune", RequestID: "request"}
+			for i := range tt.want {
+				tt.want[i].UserID = entry.UserID
+				tt.want[i].Action = entry.Action
+				tt.want[i].RequestID = entry.RequestID
+			}
+
+			if got := r.auditLogs(entry); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("auditLogs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
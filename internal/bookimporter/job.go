@@ -0,0 +1,232 @@
+package bookimporter
+
+import (
+	"errors"
+	"fmt"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/isbn"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// BatchSize is the number of rows committed together.
+const BatchSize = 100
+
+// bookFields maps the fields of the errors of Book.Validate to the fields of the spreadsheet.
+var bookFields = map[string]string{
+	"title":            FieldTitle,
+	"author":           FieldAuthors,
+	"isbn":             FieldISBN,
+	"publisher":        FieldPublishers,
+	"publication_date": FieldPublicationDate,
+	"genre":            FieldSubjects,
+	"language":         FieldLanguage,
+}
+
+// Job is a spreadsheet that has been read and mapped, ready to be imported.
+type Job struct {
+	DryRun          bool
+	DefaultBranchID uint // Branch of the copies of rows that do not name one
+	Rows            []Row
+
+	mapping Mapping
+}
+
+// NewJob maps the data rows below the header row. Blank rows are left out.
+func NewJob(rows [][]string, mapping Mapping, defaultBranchID uint, dryRun bool) (*Job, error) {
+	if len(rows) < 2 {
+		return nil, externalerrors.BadRequest("the file has no rows below the header row")
+	}
+
+	columns, err := mapping.columns(rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		DryRun:          dryRun,
+		DefaultBranchID: defaultBranchID,
+		mapping:         mapping,
+	}
+	for i, values := range rows[1:] {
+		row := Row{Number: i + 2, Cells: map[string]string{}}
+		blank := true
+		for field, index := range columns {
+			if index < len(values) {
+				row.Cells[field] = strings.TrimSpace(values[index])
+			}
+			blank = blank && row.Cells[field] == ""
+		}
+
+		if !blank {
+			job.Rows = append(job.Rows, row)
+		}
+	}
+
+	if len(job.Rows) == 0 {
+		return nil, externalerrors.BadRequest("the file has no rows below the header row")
+	}
+
+	return job, nil
+}
+
+// Run imports the rows in batches and records the progress and row errors on bookImport.
+// It is meant to run in its own goroutine after bookImport has been committed.
+func (j *Job) Run(bookImport *model.BookImport) {
+	err := j.run(bookImport)
+	if err != nil {
+		log.Printf("book import %d failed: %s\n", bookImport.ID, err)
+		bookImport.Status = model.BookImportStatusFailed
+		bookImport.FailureReason = err.Error()
+	} else {
+		bookImport.Status = model.BookImportStatusCompleted
+	}
+
+	bookImport.FinishedAt.Time = time.Now()
+	bookImport.FinishedAt.Valid = true
+	if err := bookImport.UpdateProgress(database.GetDB()); err != nil {
+		log.Printf("could not finish book import %d: %s\n", bookImport.ID, err)
+	}
+}
+
+func (j *Job) run(bookImport *model.BookImport) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unexpected error: %v", r)
+		}
+	}()
+
+	branchIDs, err := branch.IDsByName(database.GetDB())
+	if err != nil {
+		return err
+	}
+
+	isbns := map[string]int{} // normalized isbn -> row using it
+	for start := 0; start < len(j.Rows); start += BatchSize {
+		end := start + BatchSize
+		if end > len(j.Rows) {
+			end = len(j.Rows)
+		}
+
+		if err := j.runBatch(bookImport, j.Rows[start:end], branchIDs, isbns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runBatch imports the rows in one transaction. Each row is created in a savepoint so that
+// a row failing on insert does not abort the rest of the batch. A dry run only validates.
+func (j *Job) runBatch(bookImport *model.BookImport, rows []Row, branchIDs map[string]uint, isbns map[string]int) (err error) {
+	tx := database.GetDB()
+	if !j.DryRun {
+		var rollBackOrCommit func(error)
+		tx, rollBackOrCommit = audit.Begin(nil, fmt.Sprintf(
+			"Importing rows %d to %d of %s", rows[0].Number, rows[len(rows)-1].Number, bookImport.FileName,
+		))
+		defer func() { rollBackOrCommit(err) }()
+	}
+
+	for _, row := range rows {
+		e := j.toEntry(row)
+		branchID, err := j.check(tx, e, branchIDs, isbns)
+		if err != nil {
+			return err
+		}
+
+		if !e.hasErrors() && !j.DryRun {
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return importEntry(tx, e, branchID)
+			})
+			if err != nil {
+				e.fail("", errorMessage(err))
+			}
+		}
+
+		bookImport.ProcessedRows++
+		if e.hasErrors() {
+			bookImport.RejectedRows++
+		} else {
+			bookImport.AcceptedRows++
+		}
+
+		for i := range e.errors {
+			e.errors[i].BookImportID = bookImport.ID
+			if err := e.errors[i].Create(tx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bookImport.UpdateProgress(tx)
+}
+
+// check validates the book of the entry and resolves its branch.
+func (j *Job) check(db *gorm.DB, e *entry, branchIDs map[string]uint, isbns map[string]int) (uint, error) {
+	branchID := j.DefaultBranchID
+	if e.branchName != "" {
+		id, ok := branchIDs[e.branchName]
+		if !ok {
+			e.fail(j.mapping[FieldBranch], fmt.Sprintf("branch %q does not exist", e.branchName))
+		}
+		branchID = id
+	}
+
+	if err := e.book.Validate(db); err != nil {
+		var validationErr *externalerrors.Error
+		if !errors.As(err, &validationErr) {
+			return 0, err
+		}
+
+		if len(validationErr.Fields) == 0 {
+			e.fail("", validationErr.Message)
+		}
+		for _, fieldErr := range validationErr.Fields {
+			e.fail(j.mapping[bookFields[fieldErr.Field]], fieldErr.Message)
+		}
+	}
+
+	if normalized, err := isbn.Normalize(e.book.ISBN); err == nil {
+		if other, ok := isbns[normalized]; ok {
+			e.fail(j.mapping[FieldISBN], fmt.Sprintf("isbn %s is also used by row %d", normalized, other))
+		} else {
+			isbns[normalized] = e.row.Number
+		}
+	}
+
+	return branchID, nil
+}
+
+func errorMessage(err error) string {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Message
+	}
+
+	return err.Error()
+}
+
+func importEntry(db *gorm.DB, e *entry, branchID uint) error {
+	created, err := book.Create(db, e.book)
+	if err != nil {
+		return err
+	}
+
+	if e.copies == 0 {
+		return nil
+	}
+
+	_, err = bookcopy.CreateMultiple(db, int64(created.ID), int64(branchID), e.copies)
+	return err
+}
@@ -0,0 +1,44 @@
+package bookimporter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckReportsValidationErrorsByColumn(t *testing.T) {
+	job := &Job{mapping: Mapping{
+		FieldTitle:           "Book Title",
+		FieldAuthors:         "Written By",
+		FieldISBN:            "ISBN-13",
+		FieldPublishers:      "Publisher",
+		FieldPublicationDate: "Year",
+		FieldSubjects:        "Genre",
+		FieldLanguage:        "Lang",
+	}}
+
+	e := job.toEntry(Row{Number: 7, Cells: map[string]string{
+		FieldTitle:      "",
+		FieldAuthors:    "Abdullah, Siti",
+		FieldISBN:       "9780306406158",
+		FieldPublishers: "Penerbit",
+		FieldSubjects:   "History",
+	}})
+
+	// The book is invalid before its ISBN is looked up, so no database is needed
+	if _, err := job.check(nil, e, map[string]uint{}, map[string]int{}); err != nil {
+		t.Fatalf("check() error = %v", err)
+	}
+
+	got := map[string]int{}
+	for _, importErr := range e.errors {
+		if importErr.Row != 7 {
+			t.Errorf("error %q is on row %d, want 7", importErr.Message, importErr.Row)
+		}
+		got[importErr.ColumnName]++
+	}
+
+	want := map[string]int{"Book Title": 1, "ISBN-13": 1, "Year": 1, "Lang": 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errors by column = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package bookimporter
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+	"regexp"
+	"strings"
+)
+
+type Field struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`
+	aliases  []string // Normalized headers the field is suggested for
+}
+
+const (
+	FieldTitle           = "title"
+	FieldAuthors         = "authors"
+	FieldEditors         = "editors"
+	FieldTranslators     = "translators"
+	FieldIllustrators    = "illustrators"
+	FieldISBN            = "isbn"
+	FieldPublishers      = "publishers"
+	FieldPublicationDate = "publication_date"
+	FieldSubjects        = "subjects"
+	FieldLanguage        = "language"
+	FieldCopies          = "copies"
+	FieldBranch          = "branch"
+)
+
+// Fields lists the book fields a column can be mapped to.
+// Contributors, publishers and subjects are separated by semicolons within a cell.
+var Fields = []Field{
+	{Name: FieldTitle, Required: true, aliases: []string{"title", "booktitle"}},
+	{Name: FieldAuthors, Required: true, aliases: []string{"author", "authors"}},
+	{Name: FieldEditors, aliases: []string{"editor", "editors"}},
+	{Name: FieldTranslators, aliases: []string{"translator", "translators"}},
+	{Name: FieldIllustrators, aliases: []string{"illustrator", "illustrators"}},
+	{Name: FieldISBN, Required: true, aliases: []string{"isbn", "isbn13", "isbn10"}},
+	{Name: FieldPublishers, Required: true, aliases: []string{"publisher", "publishers"}},
+	{Name: FieldPublicationDate, Required: true, aliases: []string{"publicationdate", "published", "date", "year"}},
+	{Name: FieldSubjects, Required: true, aliases: []string{"subject", "subjects", "genre", "genres"}},
+	{Name: FieldLanguage, Required: true, aliases: []string{"language", "lang"}},
+	{Name: FieldCopies, aliases: []string{"copies", "count", "quantity", "qty"}},
+	{Name: FieldBranch, aliases: []string{"branch", "library"}},
+}
+
+var nonAlphanumericReg = regexp.MustCompile(`[^a-z0-9]`)
+
+// Mapping maps field names to column headers.
+type Mapping map[string]string
+
+func normalizeHeader(header string) string {
+	return nonAlphanumericReg.ReplaceAllString(strings.ToLower(header), "")
+}
+
+// SuggestMapping maps fields to the headers that look like them.
+func SuggestMapping(headers []string) Mapping {
+	mapping := Mapping{}
+	for _, header := range headers {
+		normalized := normalizeHeader(header)
+		for _, field := range Fields {
+			if _, ok := mapping[field.Name]; ok {
+				continue
+			}
+
+			for _, alias := range field.aliases {
+				if normalized == alias {
+					mapping[field.Name] = header
+					break
+				}
+			}
+		}
+	}
+
+	return mapping
+}
+
+// columns resolves the mapping into the column index of each mapped field.
+func (m Mapping) columns(headers []string) (map[string]int, error) {
+	indexes := map[string]int{}
+	for i, header := range headers {
+		indexes[strings.TrimSpace(header)] = i
+	}
+
+	known := map[string]bool{}
+	columns := map[string]int{}
+	for _, field := range Fields {
+		known[field.Name] = true
+
+		header, ok := m[field.Name]
+		if !ok || header == "" {
+			if field.Required {
+				return nil, externalerrors.BadRequest(fmt.Sprintf("%s must be mapped to a column", field.Name))
+			}
+			continue
+		}
+
+		index, ok := indexes[strings.TrimSpace(header)]
+		if !ok {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("column %q mapped to %s does not exist", header, field.Name))
+		}
+		columns[field.Name] = index
+	}
+
+	for name := range m {
+		if !known[name] {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("%s is not a book field", name))
+		}
+	}
+
+	return columns, nil
+}
@@ -0,0 +1,131 @@
+package bookimporter
+
+import (
+	"fmt"
+	"lms-backend/internal/model"
+	"lms-backend/util/sliceutil"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// valueSeparator separates multiple contributors, publishers or subjects within a cell.
+	valueSeparator = ";"
+	// maxCopiesPerRow guards against a typo creating thousands of copies.
+	maxCopiesPerRow = 100
+)
+
+// excelEpoch is day 0 of the serial dates used by spreadsheet applications.
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+
+// Row is a data row of the spreadsheet with its cells keyed by field name.
+type Row struct {
+	Number int // Row number in the spreadsheet, the header row is 1
+	Cells  map[string]string
+}
+
+// entry is a row mapped onto a book and the copies to create for it.
+type entry struct {
+	row        Row
+	book       *model.Book
+	copies     int64
+	branchName string
+	errors     []model.BookImportError
+}
+
+func (e *entry) fail(column, message string) {
+	e.errors = append(e.errors, model.BookImportError{
+		Row:        e.row.Number,
+		ColumnName: column,
+		Message:    message,
+	})
+}
+
+func (e *entry) hasErrors() bool {
+	return len(e.errors) > 0
+}
+
+func splitValues(cell string) []string {
+	values := sliceutil.Map(strings.Split(cell, valueSeparator), strings.TrimSpace)
+	return sliceutil.Filter(values, func(v string) bool {
+		return v != ""
+	})
+}
+
+// parseDate accepts RFC3339 timestamps, dates, years and spreadsheet serial dates.
+func parseDate(cell string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "2006"} {
+		if t, err := time.Parse(layout, cell); err == nil {
+			return t, nil
+		}
+	}
+
+	if serial, err := strconv.ParseFloat(cell, 64); err == nil && serial > 0 {
+		days := math.Floor(serial)
+		return excelEpoch.AddDate(0, 0, int(days)), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not a date, use YYYY-MM-DD or YYYY", cell)
+}
+
+// toEntry maps the cells of the row onto a book. Problems with individual cells are
+// collected instead of stopping at the first one.
+func (j *Job) toEntry(row Row) *entry {
+	e := &entry{row: row, copies: 1}
+	book := &model.Book{
+		Title:    row.Cells[FieldTitle],
+		ISBN:     row.Cells[FieldISBN],
+		Language: row.Cells[FieldLanguage],
+	}
+
+	roles := []struct {
+		field string
+		role  string
+	}{
+		{FieldAuthors, model.ContributorRoleAuthor},
+		{FieldEditors, model.ContributorRoleEditor},
+		{FieldTranslators, model.ContributorRoleTranslator},
+		{FieldIllustrators, model.ContributorRoleIllustrator},
+	}
+	for _, r := range roles {
+		for _, name := range splitValues(row.Cells[r.field]) {
+			book.BookContributors = append(book.BookContributors, model.BookContributor{
+				Contributor: &model.Contributor{Name: name},
+				Role:        r.role,
+			})
+		}
+	}
+
+	book.Publishers = sliceutil.Map(splitValues(row.Cells[FieldPublishers]), func(name string) model.Publisher {
+		return model.Publisher{Name: name}
+	})
+	book.Subjects = sliceutil.Map(splitValues(row.Cells[FieldSubjects]), func(name string) model.Subject {
+		return model.Subject{Name: name}
+	})
+
+	if cell := row.Cells[FieldPublicationDate]; cell != "" {
+		publicationDate, err := parseDate(cell)
+		if err != nil {
+			e.fail(j.mapping[FieldPublicationDate], err.Error())
+		}
+		book.PublicationDate = publicationDate
+	}
+
+	if cell := row.Cells[FieldCopies]; cell != "" {
+		copies, err := strconv.ParseInt(cell, 10, 64)
+		switch {
+		case err != nil:
+			e.fail(j.mapping[FieldCopies], fmt.Sprintf("%q is not a whole number", cell))
+		case copies < 0 || copies > maxCopiesPerRow:
+			e.fail(j.mapping[FieldCopies], fmt.Sprintf("copies must be between 0 and %d", maxCopiesPerRow))
+		}
+		e.copies = copies
+	}
+
+	e.branchName = row.Cells[FieldBranch]
+	e.book = book
+
+	return e
+}
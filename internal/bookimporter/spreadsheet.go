@@ -0,0 +1,63 @@
+// Package bookimporter imports books from CSV and XLSX spreadsheets.
+//
+// The first row holds the column headers. A column mapping assigns headers to book fields.
+// Rows are validated and created in batches in the background while the progress is
+// recorded on a model.BookImport.
+package bookimporter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/xlsx"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+)
+
+// ReadSpreadsheet reads every row of an uploaded CSV or XLSX file.
+// The format is taken from the file extension.
+func ReadSpreadsheet(fileHeader *multipart.FileHeader) ([][]string, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rows [][]string
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		rows, err = readCSV(file)
+	case ".xlsx":
+		rows, err = xlsx.ReadRows(file, fileHeader.Size)
+	default:
+		return nil, externalerrors.BadRequest("only .csv and .xlsx files can be imported")
+	}
+	if err != nil {
+		return nil, externalerrors.BadRequest(fmt.Sprintf("could not read %s: %s", fileHeader.Filename, err))
+	}
+
+	if len(rows) == 0 {
+		return nil, externalerrors.BadRequest(fmt.Sprintf("%s is empty", fileHeader.Filename))
+	}
+
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Spreadsheet applications often prepend a byte order mark
+	data = bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1 // Rows may leave trailing columns out
+	reader.TrimLeadingSpace = true
+
+	return reader.ReadAll()
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 var (
@@ -16,6 +17,10 @@ var (
 
 	GoogleAPIKey string
 	BackendURL   string
+
+	// Accession numbers are generated as the prefix followed by a zero-padded sequence number.
+	AccessionNumberPrefix = "LMS"
+	AccessionNumberDigits = 8
 )
 
 type Config struct {
@@ -35,6 +40,9 @@ type Config struct {
 	REDISURL    string
 	Port        string
 	FrontendURL string
+	// Header with the IP of the client, as set by trusted proxies, e.g. X-Forwarded-For
+	ProxyHeader    string
+	TrustedProxies []string
 }
 
 // Returns a Config struct with the values from the environment variables
@@ -126,22 +134,48 @@ func GetConfig() (*Config, error) {
 		BackendURL = "http://localhost:3000"
 	}
 
+	if prefix, ok := os.LookupEnv("ACCESSION_NUMBER_PREFIX"); ok {
+		AccessionNumberPrefix = prefix
+	}
+
+	if digits := os.Getenv("ACCESSION_NUMBER_DIGITS"); digits != "" {
+		d, err := strconv.Atoi(digits)
+		if err != nil || d <= 0 {
+			return nil, internalerror.InternalServerError("Bad accession number digits: " + digits)
+		}
+		AccessionNumberDigits = d
+	}
+
+	if err := loadRateLimits(); err != nil {
+		return nil, err
+	}
+
+	proxyHeader := os.Getenv("PROXY_HEADER")
+	var trustedProxies []string
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		for _, proxy := range strings.Split(proxies, ",") {
+			trustedProxies = append(trustedProxies, strings.TrimSpace(proxy))
+		}
+	}
+
 	return &Config{
-		Mode:          mode,
-		AppName:       appName,
-		PGHost:        pgHost,
-		PGPort:        pgPort,
-		PGUsername:    pgUsername,
-		PGPassword:    pgPassword,
-		PGDatabase:    pgDatabase,
-		SSLMode:       sslMode,
-		REDISHost:     redisHost,
-		REDISPort:     redisPort,
-		REDISUser:     redisUser,
-		REDISPassword: redisPassword,
-		REDISURL:      redisURL,
-		Port:          port,
-		FrontendURL:   frontendURL,
+		Mode:           mode,
+		AppName:        appName,
+		PGHost:         pgHost,
+		PGPort:         pgPort,
+		PGUsername:     pgUsername,
+		PGPassword:     pgPassword,
+		PGDatabase:     pgDatabase,
+		SSLMode:        sslMode,
+		REDISHost:      redisHost,
+		REDISPort:      redisPort,
+		REDISUser:      redisUser,
+		REDISPassword:  redisPassword,
+		REDISURL:       redisURL,
+		Port:           port,
+		FrontendURL:    frontendURL,
+		ProxyHeader:    proxyHeader,
+		TrustedProxies: trustedProxies,
 	}, nil
 }
 
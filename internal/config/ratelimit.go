@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/internalerror"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit allows a number of requests in any window of time. A limit of 0 requests is
+// no limit.
+type RateLimit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimits are the budgets of a class of routes for each kind of caller.
+type RateLimits struct {
+	Anonymous     RateLimit
+	Authenticated RateLimit
+	Staff         RateLimit
+}
+
+// Classes of routes with their own budget. Requests to auth or external routes are also
+// counted in the budget of the api.
+const (
+	APIRateLimitClass      = "api"
+	AuthRateLimitClass     = "auth"
+	ExternalRateLimitClass = "external"
+)
+
+// Each limit may be set as <requests>/<window>, e.g. RATE_LIMIT_AUTH_ANONYMOUS=10/1m.
+var RateLimitClasses = map[string]*RateLimits{
+	APIRateLimitClass: {
+		Anonymous:     RateLimit{Requests: 300, Window: time.Minute},
+		Authenticated: RateLimit{Requests: 600, Window: time.Minute},
+		Staff:         RateLimit{Requests: 3000, Window: time.Minute},
+	},
+	AuthRateLimitClass: {
+		Anonymous:     RateLimit{Requests: 10, Window: time.Minute},
+		Authenticated: RateLimit{Requests: 10, Window: time.Minute},
+		Staff:         RateLimit{Requests: 30, Window: time.Minute},
+	},
+	ExternalRateLimitClass: {
+		Anonymous:     RateLimit{Requests: 10, Window: time.Minute},
+		Authenticated: RateLimit{Requests: 30, Window: time.Minute},
+		Staff:         RateLimit{Requests: 120, Window: time.Minute},
+	},
+}
+
+func loadRateLimits() error {
+	for class, limits := range RateLimitClasses {
+		for tier, limit := range map[string]*RateLimit{
+			"ANONYMOUS":     &limits.Anonymous,
+			"AUTHENTICATED": &limits.Authenticated,
+			"STAFF":         &limits.Staff,
+		} {
+			key := fmt.Sprintf("RATE_LIMIT_%s_%s", strings.ToUpper(class), tier)
+			value := os.Getenv(key)
+			if value == "" {
+				continue
+			}
+
+			parsed, err := parseRateLimit(value)
+			if err != nil {
+				return internalerror.InternalServerError(fmt.Sprintf("Bad %s: %s", key, value))
+			}
+			*limit = parsed
+		}
+	}
+
+	return nil
+}
+
+func parseRateLimit(value string) (RateLimit, error) {
+	requests, window, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("%s is not <requests>/<window>", value)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil || n < 0 {
+		return RateLimit{}, fmt.Errorf("%s is not a number of requests", requests)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(window))
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("%s is not a window of time", window)
+	}
+
+	return RateLimit{Requests: n, Window: d}, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    RateLimit
+		wantErr bool
+	}{
+		{value: "10/1m", want: RateLimit{Requests: 10, Window: time.Minute}},
+		{value: " 300 / 30s ", want: RateLimit{Requests: 300, Window: 30 * time.Second}},
+		{value: "0/1h", want: RateLimit{Requests: 0, Window: time.Hour}},
+		{value: "10", wantErr: true},
+		{value: "ten/1m", wantErr: true},
+		{value: "-1/1m", wantErr: true},
+		{value: "10/minute", wantErr: true},
+		{value: "10/0s", wantErr: true},
+		{value: "10/-1m", wantErr: true},
+		{value: "/1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseRateLimit(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRateLimit(%q) error = %v, want error %t", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRateLimit(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRateLimits(t *testing.T) {
+	defaults := map[string]RateLimits{}
+	for class, limits := range RateLimitClasses {
+		defaults[class] = *limits
+	}
+	restore := func() {
+		for class, limits := range defaults {
+			*RateLimitClasses[class] = limits
+		}
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    map[string]RateLimits
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			want: defaults,
+		},
+		{
+			name: "overridden tiers",
+			env: map[string]string{
+				"RATE_LIMIT_AUTH_ANONYMOUS": "5/30s",
+				"RATE_LIMIT_API_STAFF":      "0/1m",
+			},
+			want: map[string]RateLimits{
+				APIRateLimitClass: {
+					Anonymous:     defaults[APIRateLimitClass].Anonymous,
+					Authenticated: defaults[APIRateLimitClass].Authenticated,
+					Staff:         RateLimit{Requests: 0, Window: time.Minute},
+				},
+				AuthRateLimitClass: {
+					Anonymous:     RateLimit{Requests: 5, Window: 30 * time.Second},
+					Authenticated: defaults[AuthRateLimitClass].Authenticated,
+					Staff:         defaults[AuthRateLimitClass].Staff,
+				},
+				ExternalRateLimitClass: defaults[ExternalRateLimitClass],
+			},
+		},
+		{
+			name:    "bad limit",
+			env:     map[string]string{"RATE_LIMIT_EXTERNAL_AUTHENTICATED": "30 per minute"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(restore)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			err := loadRateLimits()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadRateLimits() error = %v, want error %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for class, want := range tt.want {
+				if got := *RateLimitClasses[class]; got != want {
+					t.Errorf("RateLimitClasses[%s] = %+v, want %+v", class, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,14 +1,23 @@
 package auditlog
 
 import (
+	"lms-backend/internal/model"
 	collection "lms-backend/pkg/collectionquery"
 )
 
+// Filters of the audit log, e.g. filter[entity_type]=book_copies&filter[entity_id]=42 for
+// everything that happened to a copy.
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"username": collection.StringLikeFilter("users.username", JoinUser),
-		"action":   collection.StringLikeFilter("action"),
-		"value":    collection.MultipleColumnStringLikeFilter([]string{"action", "users.username"}, JoinUser),
+		"username":    collection.StringFilter("users.username", JoinUser),
+		"action":      collection.StringFilter("action"),
+		"action_type": collection.EnumFilter("audit_logs.action_type", model.AuditLogActionTypes),
+		"entity_type": collection.StringFilter("audit_logs.entity_type").WithOperators(collection.Eq, collection.In, collection.Ne),
+		"entity_id":   collection.IntFilter("audit_logs.entity_id"),
+		"request_id":  collection.StringFilter("audit_logs.request_id").WithOperators(collection.Eq, collection.In),
+		"ip_address":  collection.StringFilter("audit_logs.ip_address").WithOperators(collection.Eq, collection.In),
+		"date":        collection.DateFilter("audit_logs.date"),
+		"value":       collection.AnyColumnLikeFilter([]string{"action", "users.username"}, JoinUser),
 	}
 }
 
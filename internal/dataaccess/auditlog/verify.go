@@ -0,0 +1,59 @@
+package auditlog
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
+
+	"gorm.io/gorm"
+)
+
+const (
+	verifyBatchSize = 1000
+)
+
+// Verify walks the audit log in order and stops at the first entry that does not follow
+// the one before it, or of which the contents were changed.
+func Verify(db *gorm.DB) (*viewmodel.AuditLogVerificationViewModel, error) {
+	verification := &viewmodel.AuditLogVerificationViewModel{}
+
+	var lastID uint
+	for {
+		var logs []model.AuditLog
+		result := db.Model(&model.AuditLog{}).
+			Where("id > ?", lastID).
+			Order("id").
+			Limit(verifyBatchSize).
+			Find(&logs)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if len(logs) == 0 {
+			return verification, nil
+		}
+
+		for _, log := range logs {
+			lastID = log.ID
+
+			// The chain starts after the entries from before it
+			if verification.Checked == 0 && log.Hash == "" {
+				verification.Unchained++
+				continue
+			}
+
+			//nolint:gosec // loop does not modify struct
+			reason, err := log.VerifyChain(verification.LastHash)
+			if err != nil {
+				return nil, err
+			}
+			if reason != "" {
+				verification.BrokenID = log.ID
+				verification.Reason = reason
+				return verification, nil
+			}
+
+			verification.Checked++
+			verification.LastID = log.ID
+			verification.LastHash = log.Hash
+		}
+	}
+}
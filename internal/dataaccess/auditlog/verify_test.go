@@ -0,0 +1,239 @@
+package auditlog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"lms-backend/internal/model"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var auditLogColumns = []string{
+	"id", "created_at", "user_id", "action", "action_type", "entity_type", "entity_id",
+	"request_id", "ip_address", "changes", "date", "previous_hash", "hash",
+}
+
+// fakeLogs is a database in which every query returns the entries after the ID in its
+// first argument, as the audit log is read in batches.
+type fakeLogs struct {
+	logs []model.AuditLog
+}
+
+func (db *fakeLogs) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeLogs) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeLogs
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *fakeConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	lastID, ok := args[0].Value.(int64)
+	if !ok {
+		return nil, fmt.Errorf("unexpected ID %v", args[0].Value)
+	}
+
+	rows := &fakeRows{}
+	for _, log := range c.db.logs {
+		if int64(log.ID) <= lastID {
+			continue
+		}
+
+		changes, err := log.Changes.Value()
+		if err != nil {
+			return nil, err
+		}
+		rows.rows = append(rows.rows, []driver.Value{
+			int64(log.ID), log.CreatedAt, int64(log.UserID), log.Action, log.ActionType,
+			log.EntityType, int64(log.EntityID), log.RequestID, log.IPAddress,
+			[]byte(changes.(string)), log.Date, log.PreviousHash, log.Hash,
+		})
+	}
+
+	return rows, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fakeRows) Columns() []string { return auditLogColumns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func openFakeLogs(t *testing.T, logs []model.AuditLog) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{Conn: sql.OpenDB(&fakeLogs{logs: logs})}),
+		&gorm.Config{Logger: logger.Discard},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// chainLogs sets the hashes of the entries after the unchained ones, as they are appended
+// to the log. Dates are stored to the microsecond.
+func chainLogs(t *testing.T, logs []model.AuditLog) []model.AuditLog {
+	t.Helper()
+
+	previousHash := ""
+	for i := range logs {
+		logs[i].Date = logs[i].Date.Truncate(time.Microsecond)
+		if logs[i].Hash == "unchained" {
+			logs[i].Hash = ""
+			continue
+		}
+
+		logs[i].PreviousHash = previousHash
+		hash, err := logs[i].ComputeHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		logs[i].Hash = hash
+		previousHash = hash
+	}
+
+	return logs
+}
+
+func newLog(id uint, hash string) model.AuditLog {
+	return model.AuditLog{
+		ID:         id,
+		UserID:     1,
+		Action:     "alice loaning Dune",
+		ActionType: model.AuditLogActionUpdate,
+		EntityType: "book_copies",
+		EntityID:   id,
+		RequestID:  fmt.Sprintf("request-%d", id),
+		IPAddress:  "127.0.0.1",
+		Changes: model.AuditLogChanges{
+			"status": {Old: "available", New: "on_loan"},
+		},
+		Date: time.Date(2024, 1, 15, 10, 30, 0, int(id)*1001, time.UTC),
+		Hash: hash,
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name          string
+		logs          []model.AuditLog
+		modify        func(logs []model.AuditLog) []model.AuditLog
+		wantChecked   int64
+		wantUnchained int64
+		wantLastID    uint
+		wantBrokenID  uint
+	}{
+		{
+			name:        "empty log",
+			wantChecked: 0,
+		},
+		{
+			name:        "valid chain",
+			logs:        []model.AuditLog{newLog(1, ""), newLog(2, ""), newLog(3, "")},
+			wantChecked: 3,
+			wantLastID:  3,
+		},
+		{
+			name: "entries from before the chain",
+			logs: []model.AuditLog{
+				newLog(1, "unchained"), newLog(2, "unchained"), newLog(3, ""), newLog(4, ""),
+			},
+			wantChecked:   2,
+			wantUnchained: 2,
+			wantLastID:    4,
+		},
+		{
+			name:         "unchained entry within the chain",
+			logs:         []model.AuditLog{newLog(1, ""), newLog(2, "unchained"), newLog(3, "")},
+			wantChecked:  1,
+			wantLastID:   1,
+			wantBrokenID: 2,
+		},
+		{
+			name: "changed contents",
+			logs: []model.AuditLog{newLog(1, ""), newLog(2, ""), newLog(3, "")},
+			modify: func(logs []model.AuditLog) []model.AuditLog {
+				logs[1].UserID = 2
+				return logs
+			},
+			wantChecked:  1,
+			wantLastID:   1,
+			wantBrokenID: 2,
+		},
+		{
+			name: "removed entry",
+			logs: []model.AuditLog{newLog(1, ""), newLog(2, ""), newLog(3, "")},
+			modify: func(logs []model.AuditLog) []model.AuditLog {
+				return append(logs[:1], logs[2:]...)
+			},
+			wantChecked:  1,
+			wantLastID:   1,
+			wantBrokenID: 3,
+		},
+		{
+			name: "reordered entries",
+			logs: []model.AuditLog{newLog(1, ""), newLog(2, ""), newLog(3, "")},
+			modify: func(logs []model.AuditLog) []model.AuditLog {
+				logs[1].ID, logs[2].ID = logs[2].ID, logs[1].ID
+				logs[1], logs[2] = logs[2], logs[1]
+				return logs
+			},
+			wantChecked:  1,
+			wantLastID:   1,
+			wantBrokenID: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := chainLogs(t, tt.logs)
+			if tt.modify != nil {
+				logs = tt.modify(logs)
+			}
+
+			got, err := Verify(openFakeLogs(t, logs))
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			if got.Checked != tt.wantChecked || got.Unchained != tt.wantUnchained ||
+				got.LastID != tt.wantLastID || got.BrokenID != tt.wantBrokenID {
+				t.Errorf("Verify() = checked %d, unchained %d, last %d, broken %d, "+
+					"want checked %d, unchained %d, last %d, broken %d",
+					got.Checked, got.Unchained, got.LastID, got.BrokenID,
+					tt.wantChecked, tt.wantUnchained, tt.wantLastID, tt.wantBrokenID)
+			}
+			if (got.Reason == "") != (tt.wantBrokenID == 0) {
+				t.Errorf("Verify() reason = %q, want a reason: %t", got.Reason, tt.wantBrokenID != 0)
+			}
+			if tt.wantBrokenID == 0 && len(logs) > 0 && got.LastHash != logs[len(logs)-1].Hash {
+				t.Errorf("Verify() last hash = %s, want %s", got.LastHash, logs[len(logs)-1].Hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package book
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
+
+	"gorm.io/gorm"
+)
+
+// ListAvailability counts the copies of the given books by status in one query.
+// Books without copies are left out.
+func ListAvailability(db *gorm.DB, bookIDs []uint) (map[uint]viewmodel.BookAvailabilityViewModel, error) {
+	var availabilities []viewmodel.BookAvailabilityViewModel
+
+	result := db.Model(&model.BookCopy{}).
+		Select(
+			"book_copies.book_id AS book_id, "+
+				"COUNT(*) AS total_copies, "+
+				"COUNT(*) FILTER (WHERE book_copies.status = ?) AS available_copies, "+
+				"COUNT(*) FILTER (WHERE book_copies.status = ?) AS loaned_copies, "+
+				"COUNT(*) FILTER (WHERE book_copies.status = ?) AS reserved_copies, "+
+				"MIN(loans.due_date) AS next_due_date",
+			model.BookStatusAvailable, model.BookStatusOnLoan, model.BookStatusOnReserve,
+		).
+		Joins(
+			"LEFT JOIN loans ON loans.book_copy_id = book_copies.id AND loans.status = ? AND loans.deleted_at IS NULL",
+			model.LoanStatusBorrowed,
+		).
+		Where("book_copies.book_id IN ?", bookIDs).
+		Group("book_copies.book_id").
+		Find(&availabilities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	availabilitiesByBookID := map[uint]viewmodel.BookAvailabilityViewModel{}
+	for _, a := range availabilities {
+		availabilitiesByBookID[a.BookID] = a
+	}
+
+	return availabilitiesByBookID, nil
+}
@@ -8,6 +8,8 @@ import (
 	"lms-backend/internal/orm"
 	"lms-backend/internal/viewmodel"
 	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
@@ -17,13 +19,28 @@ func preloadCopies(db *gorm.DB) *gorm.DB {
 	return db.Preload("BookCopies")
 }
 
-func preloadAssociations(db *gorm.DB) *gorm.DB {
-	return db.Scopes(preloadCopies).
-		Preload("Bookmarks").
-		Preload("Thumbnail").
+func preloadContributors(db *gorm.DB) *gorm.DB {
+	return db.Preload("BookContributors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).
+		Preload("BookContributors.Contributor")
+}
+
+func preloadEntities(db *gorm.DB) *gorm.DB {
+	return db.Scopes(preloadContributors).
+		Preload("Subjects").
+		Preload("Publishers")
+}
+
+func preloadThumbnail(db *gorm.DB) *gorm.DB {
+	return db.Preload("Thumbnail").
 		Preload("Thumbnail.FileUpload")
 }
 
+func preloadAssociations(db *gorm.DB) *gorm.DB {
+	return db.Scopes(preloadCopies, preloadEntities, preloadThumbnail)
+}
+
 func Read(db *gorm.DB, bookID int64) (*model.Book, error) {
 	var book model.Book
 	result := db.Model(&model.Book{}).
@@ -88,12 +105,35 @@ func GetBookTitle(db *gorm.DB, bookID int64) (string, error) {
 	return title, nil
 }
 
+// saveEntities links the contributors, subjects and publishers set on the book.
+func saveEntities(db *gorm.DB, book *model.Book) error {
+	if err := book.UpdateContributors(db, book.BookContributors); err != nil {
+		return err
+	}
+
+	subjects := sliceutil.Map(book.Subjects, func(s model.Subject) string {
+		return s.Name
+	})
+	if err := book.UpdateSubjects(db, subjects); err != nil {
+		return err
+	}
+
+	publishers := sliceutil.Map(book.Publishers, func(p model.Publisher) string {
+		return p.Name
+	})
+	return book.UpdatePublishers(db, publishers)
+}
+
 func Create(db *gorm.DB, book *model.Book) (*model.Book, error) {
 	if err := book.Create(db); err != nil {
 		return nil, err
 	}
 
-	return Read(db, int64(book.ID))
+	if err := saveEntities(db, book); err != nil {
+		return nil, err
+	}
+
+	return ReadDetailed(db, int64(book.ID))
 }
 
 func CreateWithCopy(db *gorm.DB, book *model.Book) (*model.Book, error) {
@@ -106,15 +146,24 @@ func Update(db *gorm.DB, book *model.Book) (*model.Book, error) {
 		return nil, err
 	}
 
+	if err := saveEntities(db, book); err != nil {
+		return nil, err
+	}
+
 	return book, nil
 }
 
-func Delete(db *gorm.DB, bookID int64) (*model.Book, error) {
-	book, err := ReadDetailed(db, bookID)
+// Delete deletes the book if it is at the version, or any version if it is 0.
+func Delete(db *gorm.DB, bookID int64, version uint) (*model.Book, error) {
+	book, err := ReadDetailed(db.Preload("Bookmarks"), bookID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.BookModelName, book.Version, version); err != nil {
+		return nil, err
+	}
+
 	for _, copy := range book.BookCopies {
 		if err := copy.Delete(db); err != nil {
 			return nil, err
@@ -160,64 +209,37 @@ func List(db *gorm.DB) ([]model.Book, error) {
 }
 
 func ListDetailed(db *gorm.DB) ([]model.Book, error) {
-	books, err := List(db)
-	if err != nil {
-		return nil, err
-	}
-
-	db = orm.NewSession(db)
-
-	for i, b := range books {
-		var copies []model.BookCopy
-
-		result := db.Model(&model.BookCopy{}).
-			Where("book_id = ?", b.ID).
-			Find(&copies)
-		if result.Error != nil {
-			return nil, result.Error
-		}
-
-		books[i].BookCopies = copies
-
-		var thumbnail *model.FileUploadReference
-
-		result = db.Model(&model.FileUploadReference{}).
-			Preload("FileUpload").
-			Where("attachable_id = ?", b.ID).
-			Where("attachable_type = ?", model.BookThumbnailFileUploadReferenceAttachableType).
-			First(&thumbnail)
-		if result.Error != nil {
-			if orm.IsRecordNotFound(result.Error) {
-				continue
-			}
-			return nil, result.Error
-		}
-
-		books[i].Thumbnail = thumbnail
-	}
+	return List(db.Scopes(preloadCopies, preloadEntities, preloadThumbnail))
+}
 
-	return books, nil
+// ListWithEntities lists books with their contributors, subjects, publishers and thumbnail
+// but not their copies. Use ListAvailability to count the copies.
+func ListWithEntities(db *gorm.DB) ([]model.Book, error) {
+	return List(db.Scopes(preloadEntities, preloadThumbnail))
 }
 
-func AutoComplete(db *gorm.DB, value string) ([]model.Book, error) {
+// AutoComplete suggests books whose title, author or isbn resembles value, best match first.
+// Typos and accents are tolerated.
+func AutoComplete(db *gorm.DB, value string) ([]viewmodel.BookAutoCompleteViewModel, error) {
 	if len(value) == 0 {
-		return []model.Book{}, nil
+		return []viewmodel.BookAutoCompleteViewModel{}, nil
 	}
 
-	var books []model.Book
+	var suggestions []viewmodel.BookAutoCompleteViewModel
 
-	result := db.Model(&model.Book{}).
-		Where("title ILIKE ?", "%%"+value+"%%").
-		Or("author ILIKE ?", "%%"+value+"%%").
-		Or("publisher ILIKE ?", "%%"+value+"%%").
-		Or("isbn ILIKE ?", "%%"+value+"%%").
-		Limit(5).
-		Find(&books)
+	matches := orm.FuzzyMatches(db.Model(&model.Book{}), "books.id", value, autoCompleteFields,
+		"books.id AS id", "books.title AS title",
+	)
+	result := db.Table("(?) AS matches", matches).
+		Order("score DESC").
+		Order("title ASC").
+		Limit(orm.FuzzyMatchLimit).
+		Find(&suggestions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return books, nil
+	return suggestions, nil
 }
 
 func ListPopularBooks(db *gorm.DB) ([]viewmodel.PopularBookViewModel, error) {
@@ -278,12 +300,17 @@ func CountNumberOfCopiesReservedByUser(db *gorm.DB, userID, bookID int64) (int64
 }
 
 func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
+	if err := user.Lock(db, userID); err != nil {
+		return nil, err
+	}
+
 	hasExceededMaxLoan, err := user.HasExceededMaxLoan(db, userID)
 	if err != nil {
 		return nil, err
 	}
 	if hasExceededMaxLoan {
-		return nil, externalerrors.BadRequest("You have reached the maximum number of loans")
+		return nil, externalerrors.BadRequest("You have reached the maximum number of loans").
+			WithCode(externalerrors.LoanLimitReached)
 	}
 
 	loanCount, err := CountNumberOfCopiesLoanedByUser(db, userID, bookID)
@@ -291,7 +318,8 @@ func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
 		return nil, err
 	}
 	if loanCount > 0 {
-		return nil, externalerrors.BadRequest("You have already loaned a copy of this book")
+		return nil, externalerrors.BadRequest("You have already loaned a copy of this book").
+			WithCode(externalerrors.BookAlreadyLoaned)
 	}
 
 	resCount, err := CountNumberOfCopiesReservedByUser(db, userID, bookID)
@@ -299,7 +327,8 @@ func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
 		return nil, err
 	}
 	if resCount > 0 {
-		return nil, externalerrors.BadRequest("You have already reserved a copy of this book")
+		return nil, externalerrors.BadRequest("You have already reserved a copy of this book").
+			WithCode(externalerrors.BookAlreadyReserved)
 	}
 
 	book, err := ReadWithCopies(db, bookID)
@@ -307,6 +336,10 @@ func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
 		return nil, err
 	}
 
+	if err := lockCopies(db, book); err != nil {
+		return nil, err
+	}
+
 	for _, copy := range book.BookCopies {
 		if copy.Status == model.BookStatusOnLoan {
 			continue
@@ -316,6 +349,10 @@ func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
 			continue
 		}
 
+		if copy.Status == model.BookStatusInTransit {
+			continue
+		}
+
 		ln, err := loan.Loan(db, userID, int64(copy.ID))
 		if err != nil {
 			return nil, err
@@ -330,16 +367,37 @@ func Loan(db *gorm.DB, userID, bookID int64) (*model.Loan, error) {
 		return ln, nil
 	}
 
-	return nil, externalerrors.BadRequest("No copies are available for loan")
+	return nil, externalerrors.BadRequest("No copies are available for loan").
+		WithCode(externalerrors.CopyNotAvailable)
+}
+
+// lockCopies reads the copies of the book again and locks them until the end of the
+// transaction, in order of their IDs so that concurrent loans and reservations do not
+// deadlock. Copies locked are not loaned or reserved by another request meanwhile.
+func lockCopies(db *gorm.DB, book *model.Book) error {
+	return db.Model(&model.BookCopy{}).
+		Scopes(orm.ForUpdate).
+		Where("book_id = ?", book.ID).
+		Order("id").
+		Find(&book.BookCopies).Error
 }
 
-func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
+// Reserve reserves any available copy of the book for collection at the pickup branch.
+//
+// Copies already at the pickup branch are preferred. If pickupBranchID is 0,
+// the branch the reserved copy is currently at is used.
+func Reserve(db *gorm.DB, userID, bookID, pickupBranchID int64) (*model.Reservation, error) {
+	if err := user.Lock(db, userID); err != nil {
+		return nil, err
+	}
+
 	hasExceededMaxReservation, err := user.HasExceededMaxReservation(db, userID)
 	if err != nil {
 		return nil, err
 	}
 	if hasExceededMaxReservation {
-		return nil, externalerrors.BadRequest("You have reached the maximum number of reservations")
+		return nil, externalerrors.BadRequest("You have reached the maximum number of reservations").
+			WithCode(externalerrors.ReservationLimitReached)
 	}
 
 	loanCount, err := CountNumberOfCopiesLoanedByUser(db, userID, bookID)
@@ -347,7 +405,8 @@ func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
 		return nil, err
 	}
 	if loanCount > 0 {
-		return nil, externalerrors.BadRequest("You have already loaned a copy of this book")
+		return nil, externalerrors.BadRequest("You have already loaned a copy of this book").
+			WithCode(externalerrors.BookAlreadyLoaned)
 	}
 
 	resCount, err := CountNumberOfCopiesReservedByUser(db, userID, bookID)
@@ -355,7 +414,8 @@ func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
 		return nil, err
 	}
 	if resCount > 0 {
-		return nil, externalerrors.BadRequest("You have already reserved a copy of this book")
+		return nil, externalerrors.BadRequest("You have already reserved a copy of this book").
+			WithCode(externalerrors.BookAlreadyReserved)
 	}
 
 	book, err := ReadWithCopies(db, bookID)
@@ -363,6 +423,16 @@ func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
 		return nil, err
 	}
 
+	if err := lockCopies(db, book); err != nil {
+		return nil, err
+	}
+
+	// Copies at the pickup branch come first so that no transfer is needed
+	sort.SliceStable(book.BookCopies, func(i, j int) bool {
+		return int64(book.BookCopies[i].CurrentBranchID) == pickupBranchID &&
+			int64(book.BookCopies[j].CurrentBranchID) != pickupBranchID
+	})
+
 	for _, copy := range book.BookCopies {
 		if copy.Status == model.BookStatusOnLoan {
 			continue
@@ -372,7 +442,16 @@ func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
 			continue
 		}
 
-		res, err := reservation.ReserveBook(db, userID, int64(copy.ID))
+		if copy.Status == model.BookStatusInTransit {
+			continue
+		}
+
+		pickup := pickupBranchID
+		if pickup == 0 {
+			pickup = int64(copy.CurrentBranchID)
+		}
+
+		res, err := reservation.ReserveBook(db, userID, int64(copy.ID), pickup)
 		if err != nil {
 			return nil, err
 		}
@@ -386,5 +465,6 @@ func Reserve(db *gorm.DB, userID, bookID int64) (*model.Reservation, error) {
 		return res, nil
 	}
 
-	return nil, externalerrors.BadRequest("No copies are available for reservation")
+	return nil, externalerrors.BadRequest("No copies are available for reservation").
+		WithCode(externalerrors.CopyNotAvailable)
 }
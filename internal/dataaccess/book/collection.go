@@ -1,26 +1,63 @@
 package book
 
 import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
 	collection "lms-backend/pkg/collectionquery"
 )
 
+const (
+	// Name of the first listed author of the book, used for sorting
+	firstAuthorNameQuery = `(
+		SELECT contributors.name FROM book_contributors
+		INNER JOIN contributors ON contributors.id = book_contributors.contributor_id
+		WHERE book_contributors.book_id = books.id AND book_contributors.role = 'author'
+		ORDER BY book_contributors.position LIMIT 1
+	)`
+	// Alphabetically first publisher of the book, used for sorting
+	firstPublisherNameQuery = `(
+		SELECT MIN(publishers.name) FROM book_publishers
+		INNER JOIN publishers ON publishers.id = book_publishers.publisher_id
+		WHERE book_publishers.book_id = books.id
+	)`
+	// First year of the decade the book was published in
+	publicationDecadeQuery = "(EXTRACT(YEAR FROM books.publication_date)::INT / 10 * 10)"
+)
+
+var autoCompleteFields = []orm.FuzzyField{
+	{Name: "title", Column: "books.title"},
+	{Name: "author", Column: "books.author"},
+	{Name: "isbn", Column: "books.isbn"},
+}
+
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"title":     collection.StringLikeFilter("title"),
-		"author":    collection.StringLikeFilter("author"),
-		"isbn":      collection.StringLikeFilter("isbn"),
-		"publisher": collection.StringLikeFilter("publisher"),
-		"value":     collection.MultipleColumnStringLikeFilter([]string{"title", "author", "isbn", "publisher"}),
+		"title":               collection.StringFilter("title"),
+		"author":              contributorNameFilter(model.ContributorRoleAuthor),
+		"contributor":         contributorNameFilter(),
+		"contributor_id":      contributorIDFilter,
+		"isbn":                collection.StringFilter("isbn"),
+		"publisher":           publisherNameFilter,
+		"publisher_id":        publisherIDFilter,
+		"subject":             subjectNameFilter,
+		"subject_id":          subjectIDFilter,
+		"value":               valueFilter,
+		"branch_id":           copyAtBranchFilter(),
+		"available_branch_id": copyAtBranchFilter(model.BookStatusAvailable),
+		"language":            collection.StringFilter("books.language").WithOperators(collection.In, collection.Eq, collection.Ne),
+		"publication_decade":  collection.IntFilter(publicationDecadeQuery),
+		"available":           availableFilter,
 	}
 }
 
 func Sorters() collection.SortMap {
 	return map[string]collection.Sorter{
 		"title":            collection.SortBy("title"),
-		"author":           collection.SortBy("author"),
+		"author":           collection.SortBy(firstAuthorNameQuery),
 		"isbn":             collection.SortBy("isbn"),
-		"publisher":        collection.SortBy("publisher"),
+		"publisher":        collection.SortBy(firstPublisherNameQuery),
 		"publication_date": collection.SortBy("publication_date"),
 		"created_at":       collection.SortBy("created_at"),
+		"available":        collection.SortBy("availability.available_copies", JoinAvailability),
 	}
 }
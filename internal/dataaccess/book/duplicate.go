@@ -0,0 +1,177 @@
+package book
+
+import (
+	"lms-backend/internal/dataaccess/fileupload"
+	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/isbn"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// ListDuplicates groups books that share the same ISBN once normalized to ISBN-13,
+// and lists books whose ISBN cannot be normalized at all.
+func ListDuplicates(db *gorm.DB) (*viewmodel.BookDuplicateReportViewModel, error) {
+	var books []model.Book
+	result := db.Model(&model.Book{}).
+		Order("id ASC").
+		Find(&books)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	report := &viewmodel.BookDuplicateReportViewModel{
+		Duplicates:   []viewmodel.BookDuplicateViewModel{},
+		InvalidBooks: []model.Book{},
+	}
+
+	booksByISBN := map[string][]model.Book{}
+	for _, book := range books {
+		normalized, err := isbn.Normalize(book.ISBN)
+		if err != nil {
+			report.InvalidBooks = append(report.InvalidBooks, book)
+			continue
+		}
+		booksByISBN[normalized] = append(booksByISBN[normalized], book)
+	}
+
+	for normalized, group := range booksByISBN {
+		if len(group) < 2 {
+			continue
+		}
+		report.Duplicates = append(report.Duplicates, viewmodel.BookDuplicateViewModel{
+			ISBN:  normalized,
+			Books: group,
+		})
+	}
+
+	sort.Slice(report.Duplicates, func(i, j int) bool {
+		return report.Duplicates[i].ISBN < report.Duplicates[j].ISBN
+	})
+
+	return report, nil
+}
+
+// Merge moves the copies, bookmarks and thumbnail of the source books onto the target book
+// and deletes the source books.
+//
+// Bookmarks that would duplicate an existing bookmark of the same user are dropped.
+// The target keeps its own thumbnail if it has one.
+func Merge(db *gorm.DB, targetID int64, sourceIDs []int64) (*model.Book, error) {
+	target, err := ReadDetailed(db, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]*model.Book, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return nil, externalerrors.BadRequest("a book cannot be merged into itself")
+		}
+
+		source, err := ReadDetailed(db, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+
+	if err := mergeCopies(db, target, sourceIDs); err != nil {
+		return nil, err
+	}
+
+	if err := mergeBookmarks(db, target, sourceIDs); err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		if err := mergeThumbnail(db, target, source); err != nil {
+			return nil, err
+		}
+
+		if err := source.Delete(db); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := clearISBNDuplicate(db, target); err != nil {
+		return nil, err
+	}
+
+	return ReadDetailed(db, targetID)
+}
+
+// clearISBNDuplicate unmarks the target as sharing its ISBN with an earlier book once no
+// other book has the ISBN, so that the database checks that it stays unique.
+func clearISBNDuplicate(db *gorm.DB, target *model.Book) error {
+	return db.Model(&model.Book{}).
+		Where("id = ?", target.ID).
+		Where("isbn_duplicate").
+		Where("NOT EXISTS (?)", db.Model(&model.Book{}).
+			Select("1").
+			Where("isbn = ?", target.ISBN).
+			Where("id <> ?", target.ID),
+		).
+		UpdateColumn("isbn_duplicate", false).
+		Error
+}
+
+func mergeCopies(db *gorm.DB, target *model.Book, sourceIDs []int64) error {
+	return db.Model(&model.BookCopy{}).
+		Where("book_id IN ?", sourceIDs).
+		UpdateColumn("book_id", target.ID).
+		Error
+}
+
+func mergeBookmarks(db *gorm.DB, target *model.Book, sourceIDs []int64) error {
+	// Users who already bookmarked the target keep only that bookmark
+	result := db.Where("book_id IN ?", sourceIDs).
+		Where("user_id IN (?)", db.Model(&model.Bookmark{}).
+			Select("user_id").
+			Where("book_id = ?", target.ID),
+		).
+		Delete(&model.Bookmark{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Users who bookmarked several sources keep only their earliest bookmark
+	result = db.Where("book_id IN ?", sourceIDs).
+		Where("id NOT IN (?)", db.Model(&model.Bookmark{}).
+			Select("MIN(id)").
+			Where("book_id IN ?", sourceIDs).
+			Group("user_id"),
+		).
+		Delete(&model.Bookmark{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return db.Model(&model.Bookmark{}).
+		Where("book_id IN ?", sourceIDs).
+		UpdateColumn("book_id", target.ID).
+		Error
+}
+
+func mergeThumbnail(db *gorm.DB, target, source *model.Book) error {
+	if source.Thumbnail == nil {
+		return nil
+	}
+
+	if target.Thumbnail != nil {
+		_, err := fileupload.Delete(db, int64(source.Thumbnail.FileUploadID))
+		return err
+	}
+
+	result := db.Model(&model.FileUploadReference{}).
+		Where("id = ?", source.Thumbnail.ID).
+		UpdateColumn("attachable_id", target.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	target.Thumbnail = source.Thumbnail
+	return nil
+}
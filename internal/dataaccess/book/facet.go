@@ -0,0 +1,122 @@
+package book
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+	"lms-backend/internal/viewmodel"
+
+	"gorm.io/gorm"
+)
+
+// facetBucketLimit caps facets with many values, such as genres, to their most common values.
+const facetBucketLimit = 20
+
+type facet struct {
+	name      string
+	filterKey string
+	query     func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB
+	labels    map[string]string // Replaces the labels of known values
+}
+
+var facets = []facet{
+	{
+		name:      "genre",
+		filterKey: "subject_id",
+		query: func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB {
+			return db.Table("book_subjects").
+				Select("subjects.id::TEXT AS value, subjects.name AS label, COUNT(DISTINCT book_subjects.book_id) AS count").
+				Joins("INNER JOIN subjects ON subjects.id = book_subjects.subject_id").
+				Where("book_subjects.book_id IN (?)", bookIDs).
+				Group("subjects.id, subjects.name").
+				Order("count DESC, label ASC")
+		},
+	},
+	{
+		name:      "language",
+		filterKey: "language",
+		query: func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB {
+			return db.Table("books").
+				Select("books.language AS value, books.language AS label, COUNT(*) AS count").
+				Where("books.id IN (?)", bookIDs).
+				Group("books.language").
+				Order("count DESC, label ASC")
+		},
+	},
+	{
+		name:      "publication_decade",
+		filterKey: "publication_decade",
+		query: func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB {
+			return db.Table("books").
+				Select(publicationDecadeQuery+"::TEXT AS value, "+publicationDecadeQuery+"::TEXT || 's' AS label, COUNT(*) AS count").
+				Where("books.id IN (?)", bookIDs).
+				Group(publicationDecadeQuery).
+				Order(publicationDecadeQuery + " DESC")
+		},
+	},
+	{
+		name:      "branch",
+		filterKey: "branch_id",
+		query: func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB {
+			return db.Model(&model.BookCopy{}).
+				Select("branches.id::TEXT AS value, branches.name AS label, COUNT(DISTINCT book_copies.book_id) AS count").
+				Joins("INNER JOIN branches ON branches.id = book_copies.current_branch_id").
+				Where("book_copies.book_id IN (?)", bookIDs).
+				Group("branches.id, branches.name").
+				Order("label ASC")
+		},
+	},
+	{
+		name:      "availability",
+		filterKey: "available",
+		query: func(db *gorm.DB, bookIDs *gorm.DB) *gorm.DB {
+			available := db.Session(&gorm.Session{NewDB: true}).
+				Model(&model.BookCopy{}).
+				Select("1").
+				Where("book_copies.book_id = books.id").
+				Where("book_copies.status = ?", model.BookStatusAvailable)
+
+			return db.Table("books").
+				Select("(EXISTS (?))::TEXT AS value, '' AS label, COUNT(*) AS count", available).
+				Where("books.id IN (?)", bookIDs).
+				Group("value").
+				Order("value DESC")
+		},
+		labels: map[string]string{
+			"true":  "Available",
+			"false": "Unavailable",
+		},
+	},
+}
+
+// ListFacets counts the books matched by db by genre, language, publication decade,
+// branch and availability.
+func ListFacets(db *gorm.DB) ([]viewmodel.FacetViewModel, error) {
+	bookIDs := orm.CloneSession(db).
+		Model(&model.Book{}).
+		Select("books.id")
+
+	results := []viewmodel.FacetViewModel{}
+	for _, f := range facets {
+		var buckets []viewmodel.FacetBucketViewModel
+
+		query := f.query(db.Session(&gorm.Session{NewDB: true}), bookIDs)
+		result := query.Limit(facetBucketLimit).Find(&buckets)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		for i, bucket := range buckets {
+			if label, ok := f.labels[bucket.Value]; ok {
+				buckets[i].Label = label
+			}
+		}
+
+		results = append(results, viewmodel.FacetViewModel{
+			Name:      f.name,
+			FilterKey: f.filterKey,
+			Buckets:   buckets,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,130 @@
+package book
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+	collection "lms-backend/pkg/collectionquery"
+
+	"gorm.io/gorm"
+)
+
+// copyAtBranchFilter filters books that have at least one copy currently at
+// any of the given branches.
+//
+// If statuses are given, only copies with one of the statuses are considered.
+func copyAtBranchFilter(statuses ...model.BookStatus) collection.Filter {
+	return collection.NewFilter(collection.IntField, func(c collection.Condition) func(*gorm.DB) *gorm.DB {
+		return func(db *gorm.DB) *gorm.DB {
+			subQuery := db.Session(&gorm.Session{NewDB: true}).
+				Model(&model.BookCopy{}).
+				Select("1").
+				Where("book_copies.book_id = books.id").
+				Where("book_copies.current_branch_id IN ?", c.Values)
+			if len(statuses) > 0 {
+				subQuery = subQuery.Where("book_copies.status IN ?", statuses)
+			}
+
+			return db.Where("EXISTS (?)", subQuery)
+		}
+	}, collection.In)
+}
+
+// contributorSubQuery selects the contributors of the book in the outer query.
+// If roles are given, only contributors in one of the roles are considered.
+func contributorSubQuery(db *gorm.DB, roles ...model.ContributorRole) *gorm.DB {
+	subQuery := db.Session(&gorm.Session{NewDB: true}).
+		Table("book_contributors").
+		Select("1").
+		Joins("INNER JOIN contributors ON contributors.id = book_contributors.contributor_id").
+		Where("book_contributors.book_id = books.id")
+	if len(roles) > 0 {
+		subQuery = subQuery.Where("book_contributors.role IN ?", roles)
+	}
+
+	return subQuery
+}
+
+func subjectSubQuery(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Table("book_subjects").
+		Select("1").
+		Joins("INNER JOIN subjects ON subjects.id = book_subjects.subject_id").
+		Where("book_subjects.book_id = books.id")
+}
+
+func publisherSubQuery(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Table("book_publishers").
+		Select("1").
+		Joins("INNER JOIN publishers ON publishers.id = book_publishers.publisher_id").
+		Where("book_publishers.book_id = books.id")
+}
+
+// existsFilter filters books for which the subquery has a row whose column matches the condition.
+func existsFilter(
+	fieldType collection.FieldType,
+	columnName string,
+	subQuery func(*gorm.DB) *gorm.DB,
+	operators ...collection.Operator,
+) collection.Filter {
+	return collection.NewFilter(fieldType, func(c collection.Condition) func(*gorm.DB) *gorm.DB {
+		return func(db *gorm.DB) *gorm.DB {
+			return db.Where("EXISTS (?)", subQuery(db).Scopes(collection.ColumnCondition(columnName, c)))
+		}
+	}, operators...)
+}
+
+// contributorNameFilter filters books with a contributor whose name is similar to the value.
+func contributorNameFilter(roles ...model.ContributorRole) collection.Filter {
+	return existsFilter(collection.StringField, "contributors.name", func(db *gorm.DB) *gorm.DB {
+		return contributorSubQuery(db, roles...)
+	}, collection.Like, collection.Eq)
+}
+
+// contributorIDFilter filters books with any of the contributors.
+var contributorIDFilter = existsFilter(collection.IntField, "contributors.id", func(db *gorm.DB) *gorm.DB {
+	return contributorSubQuery(db)
+}, collection.In)
+
+var subjectNameFilter = existsFilter(collection.StringField, "subjects.name", subjectSubQuery,
+	collection.Like, collection.Eq)
+
+// subjectIDFilter filters books with any of the subjects.
+var subjectIDFilter = existsFilter(collection.IntField, "subjects.id", subjectSubQuery, collection.In)
+
+var publisherNameFilter = existsFilter(collection.StringField, "publishers.name", publisherSubQuery,
+	collection.Like, collection.Eq)
+
+// publisherIDFilter filters books with any of the publishers.
+var publisherIDFilter = existsFilter(collection.IntField, "publishers.id", publisherSubQuery, collection.In)
+
+// valueFilter filters books whose title, isbn, contributors, publishers or subjects are similar to the value.
+var valueFilter = collection.NewFilter(collection.StringField, func(c collection.Condition) func(*gorm.DB) *gorm.DB {
+	like := "%" + orm.EscapeLike(c.StringValue()) + "%"
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(
+			"(books.title ILIKE ? OR books.isbn ILIKE ? OR EXISTS (?) OR EXISTS (?) OR EXISTS (?))",
+			like,
+			like,
+			contributorSubQuery(db).Where("contributors.name ILIKE ?", like),
+			publisherSubQuery(db).Where("publishers.name ILIKE ?", like),
+			subjectSubQuery(db).Where("subjects.name ILIKE ?", like),
+		)
+	}
+}, collection.Like)
+
+// availableFilter filters books that have a copy available for loan, or none with false.
+var availableFilter = collection.NewFilter(collection.BoolField, func(c collection.Condition) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		subQuery := db.Session(&gorm.Session{NewDB: true}).
+			Model(&model.BookCopy{}).
+			Select("1").
+			Where("book_copies.book_id = books.id").
+			Where("book_copies.status = ?", model.BookStatusAvailable)
+		if c.BoolValue() {
+			return db.Where("EXISTS (?)", subQuery)
+		}
+
+		return db.Where("NOT EXISTS (?)", subQuery)
+	}
+}, collection.Eq)
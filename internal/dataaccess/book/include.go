@@ -0,0 +1,49 @@
+package book
+
+import (
+	"lms-backend/internal/fieldset"
+
+	"gorm.io/gorm"
+)
+
+// Associations a client can ask for with include
+const (
+	IncludeCopies    = "copies"
+	IncludeThumbnail = "thumbnail"
+	IncludeBookmarks = "bookmarks"
+)
+
+// Fields of the book views backed by associations
+const (
+	FieldContributors = "contributors"
+	FieldSubjects     = "subjects"
+	FieldPublishers   = "publishers"
+)
+
+// PreloadSelection preloads only the associations needed for the selected fields and includes.
+//
+// Bookmarks are limited to those of the user.
+func PreloadSelection(selection *fieldset.Selection, userID int64) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if selection.HasField(FieldContributors) {
+			db = db.Scopes(preloadContributors)
+		}
+		if selection.HasField(FieldSubjects) {
+			db = db.Preload("Subjects")
+		}
+		if selection.HasField(FieldPublishers) {
+			db = db.Preload("Publishers")
+		}
+		if selection.HasInclude(IncludeCopies) {
+			db = db.Scopes(preloadCopies)
+		}
+		if selection.HasInclude(IncludeThumbnail) {
+			db = db.Scopes(preloadThumbnail)
+		}
+		if selection.HasInclude(IncludeBookmarks) {
+			db = db.Preload("Bookmarks", "user_id = ?", userID)
+		}
+
+		return db
+	}
+}
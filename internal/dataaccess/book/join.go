@@ -0,0 +1,10 @@
+package book
+
+const (
+	// Number of available copies of each book, for sorting by availability
+	JoinAvailability = `LEFT JOIN LATERAL (
+		SELECT COUNT(*) FILTER (WHERE book_copies.status = 'available') AS available_copies
+		FROM book_copies
+		WHERE book_copies.book_id = books.id AND book_copies.deleted_at IS NULL
+	) AS availability ON TRUE`
+)
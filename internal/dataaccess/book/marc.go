@@ -0,0 +1,166 @@
+package book
+
+import (
+	"fmt"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/marcmapping"
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/pkg/marc"
+
+	"gorm.io/gorm"
+)
+
+func ReadByISBN(db *gorm.DB, isbn string) (*model.Book, error) {
+	var book model.Book
+	result := db.Model(&model.Book{}).
+		Where("isbn = ?", isbn).
+		First(&book)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.BookModelName)
+		}
+		return nil, err
+	}
+
+	return &book, nil
+}
+
+// ImportMARC maps the records onto books and copies and creates them.
+//
+// Records with fatal problems are skipped and reported. A record whose ISBN is already
+// catalogued adds its copies to the existing book. With dryRun nothing is written.
+func ImportMARC(db *gorm.DB, records []*marc.Record, dryRun bool) (*viewmodel.MARCImportReportViewModel, error) {
+	branchIDs, err := branch.IDsByName(db)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &viewmodel.MARCImportReportViewModel{
+		DryRun:  dryRun,
+		Records: []viewmodel.MARCImportRecordViewModel{},
+	}
+	accessionNumbers := map[string]int{} // accession number -> index of the record using it
+
+	for i, record := range records {
+		entry := marcmapping.ToEntry(record)
+		result := viewmodel.MARCImportRecordViewModel{
+			Index:         i + 1,
+			ControlNumber: entry.ControlNumber,
+			Title:         entry.Book.Title,
+			ISBN:          entry.Book.ISBN,
+			CopyCount:     len(entry.Copies),
+		}
+
+		existing, err := checkMARCEntry(db, entry, i+1, branchIDs, accessionNumbers)
+		if err != nil {
+			return nil, err
+		}
+
+		if !dryRun && !entry.HasFatalProblems() {
+			err := db.Transaction(func(tx *gorm.DB) error {
+				book, err := importMARCEntry(tx, entry, existing)
+				if err != nil {
+					return err
+				}
+				result.BookID = book.ID
+				return nil
+			})
+			if err != nil {
+				entry.Fail("", err.Error())
+			}
+		}
+
+		result.Accepted = !entry.HasFatalProblems()
+		if result.Accepted {
+			report.Accepted++
+		} else {
+			report.Skipped++
+		}
+
+		result.Problems = entry.Problems
+		report.Records = append(report.Records, result)
+	}
+
+	return report, nil
+}
+
+// checkMARCEntry reports problems that need the database: unknown branches, accession numbers
+// already in use and books that are already catalogued, which is returned.
+func checkMARCEntry(
+	db *gorm.DB, entry *marcmapping.Entry, index int, branchIDs map[string]uint, accessionNumbers map[string]int,
+) (*model.Book, error) {
+	for i, copy := range entry.Copies {
+		if copy.BranchName != "" {
+			branchID, ok := branchIDs[copy.BranchName]
+			if !ok {
+				entry.Fail("852", fmt.Sprintf("branch %q does not exist", copy.BranchName))
+			}
+			entry.Copies[i].HomeBranchID = branchID
+		}
+
+		if copy.AccessionNumber == "" {
+			continue
+		}
+
+		if other, ok := accessionNumbers[copy.AccessionNumber]; ok {
+			entry.Fail("852", fmt.Sprintf("accession number %s is also used by record %d", copy.AccessionNumber, other))
+			continue
+		}
+		accessionNumbers[copy.AccessionNumber] = index
+
+		if err := copy.ValidateAccessionNumber(db); err != nil {
+			entry.Fail("852", err.Error())
+		}
+	}
+
+	if entry.Book.ISBN == "" {
+		return nil, nil
+	}
+
+	existing, err := ReadByISBN(db, entry.Book.ISBN)
+	if err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	entry.Warn("020", fmt.Sprintf(
+		"isbn is already catalogued as \"%s\" (book id %d), copies are added to it", existing.Title, existing.ID,
+	))
+
+	return existing, nil
+}
+
+func importMARCEntry(db *gorm.DB, entry *marcmapping.Entry, existing *model.Book) (*model.Book, error) {
+	book := existing
+	if book == nil {
+		if err := entry.Book.Create(db); err != nil {
+			return nil, err
+		}
+
+		if err := saveEntities(db, entry.Book); err != nil {
+			return nil, err
+		}
+		book = entry.Book
+	}
+
+	for _, copy := range entry.Copies {
+		copy.BookID = book.ID
+		if err := copy.BookCopy.Create(db); err != nil {
+			return nil, err
+		}
+	}
+
+	return book, nil
+}
+
+// ListForMARCExport lists books with everything needed to map them onto MARC records.
+func ListForMARCExport(db *gorm.DB) ([]model.Book, error) {
+	return List(db.Scopes(preloadEntities).
+		Preload("BookCopies").
+		Preload("BookCopies.HomeBranch"),
+	)
+}
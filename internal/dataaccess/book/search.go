@@ -0,0 +1,59 @@
+package book
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Parses user input the way web search engines do: quoted phrases, OR and -excluded words
+	searchQuery = "websearch_to_tsquery('english', ?)"
+	// Wraps matches in <mark> tags and shows the whole field, since catalogue fields are short
+	headlineOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+)
+
+// Search keeps the books matching the full-text search query.
+func Search(query string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Joins("CROSS JOIN "+searchQuery+" AS search_query", query).
+			Where("books.search_vector @@ search_query")
+	}
+}
+
+// OrderByRank orders the books by how well they match the full-text search query, best first.
+// It must be applied after Search.
+func OrderByRank(db *gorm.DB) *gorm.DB {
+	return db.Order("ts_rank(books.search_vector, search_query) DESC")
+}
+
+func headline(column string) string {
+	return "ts_headline('english', " + column + ", " + searchQuery + ", '" + headlineOptions + "') AS " + column
+}
+
+// ListSearchHighlights ranks and highlights the given books against the full-text search query.
+// Only the books of one page should be passed, ts_headline reparses every field.
+func ListSearchHighlights(db *gorm.DB, query string, bookIDs []uint) (map[uint]viewmodel.BookSearchHighlightViewModel, error) {
+	var highlights []viewmodel.BookSearchHighlightViewModel
+
+	result := db.Model(&model.Book{}).
+		Select(
+			"books.id AS book_id, "+
+				"ts_rank(books.search_vector, "+searchQuery+") AS rank, "+
+				headline("title")+", "+headline("author")+", "+headline("publisher")+", "+headline("genre"),
+			query, query, query, query, query,
+		).
+		Where("books.id IN ?", bookIDs).
+		Find(&highlights)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	highlightsByBookID := map[uint]viewmodel.BookSearchHighlightViewModel{}
+	for _, h := range highlights {
+		highlightsByBookID[h.BookID] = h
+	}
+
+	return highlightsByBookID, nil
+}
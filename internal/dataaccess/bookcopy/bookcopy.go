@@ -4,6 +4,7 @@ import (
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/dataaccess/loan"
 	"lms-backend/internal/dataaccess/reservation"
+	"lms-backend/internal/dataaccess/transfer"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/model"
 	"lms-backend/internal/orm"
@@ -21,7 +22,8 @@ func preloadAssociations(db *gorm.DB) *gorm.DB {
 		Preload("Reservations").
 		Preload("Loans").
 		Preload("Loans.LoanHistories").
-		Preload("Loans.Fines")
+		Preload("Loans.Fines").
+		Preload("Transfers")
 }
 
 func Read(db *gorm.DB, id int64) (*model.BookCopy, error) {
@@ -39,6 +41,28 @@ func Read(db *gorm.DB, id int64) (*model.BookCopy, error) {
 	return &b, nil
 }
 
+// ReadForUpdate reads the copy and locks it until the end of the transaction, so that
+// it is loaned, reserved, returned or transferred by one request at a time.
+func ReadForUpdate(db *gorm.DB, id int64) (*model.BookCopy, error) {
+	return Read(db.Scopes(orm.ForUpdate), id)
+}
+
+// ReadByAccessionNumber looks up a copy by the accession number on its barcode label.
+func ReadByAccessionNumber(db *gorm.DB, accessionNumber string) (*model.BookCopy, error) {
+	var b model.BookCopy
+	result := db.Model(&model.BookCopy{}).
+		Where("accession_number = ?", accessionNumber).
+		First(&b)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.BookCopyModelName)
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
 func ReadWithBook(db *gorm.DB, id int64) (*model.BookCopy, error) {
 	var b model.BookCopy
 	result := db.Model(&model.BookCopy{}).
@@ -84,16 +108,18 @@ func Create(db *gorm.DB, bookID int64) (*model.BookCopy, error) {
 	return ReadWithBook(db, int64(b.ID))
 }
 
-// CreateMultiple creates multiple book copies with the same book ID.
+// CreateMultiple creates multiple book copies with the same book ID at the given branch.
 //
 // This function will not preload Book
-func CreateMultiple(db *gorm.DB, bookID, count int64) ([]model.BookCopy, error) {
+func CreateMultiple(db *gorm.DB, bookID, branchID, count int64) ([]model.BookCopy, error) {
 	var bookCopies []model.BookCopy
 
 	for i := int64(0); i < count; i++ {
 		bookCopy := model.BookCopy{
-			BookID: uint(bookID),
-			Status: model.BookStatusAvailable,
+			BookID:          uint(bookID),
+			Status:          model.BookStatusAvailable,
+			HomeBranchID:    uint(branchID),
+			CurrentBranchID: uint(branchID),
 		}
 		bookCopies = append(bookCopies, bookCopy)
 	}
@@ -106,12 +132,40 @@ func CreateMultiple(db *gorm.DB, bookID, count int64) ([]model.BookCopy, error)
 	return bookCopies, nil
 }
 
-func Delete(db *gorm.DB, id int64) (*model.BookCopy, error) {
+// UpdateDetails updates the accession number, shelving and acquisition of the copy at the
+// version of details, leaving its book, branch and status as they are.
+func UpdateDetails(db *gorm.DB, details *model.BookCopy) (*model.BookCopy, error) {
+	b, err := ReadForUpdate(db, int64(details.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	b.AccessionNumber = details.AccessionNumber
+	b.CallNumber = details.CallNumber
+	b.ShelfLocation = details.ShelfLocation
+	b.AcquisitionDate = details.AcquisitionDate
+	b.AcquisitionSource = details.AcquisitionSource
+	b.AcquisitionPrice = details.AcquisitionPrice
+	b.Version = details.Version
+
+	if err := b.UpdateDetails(db); err != nil {
+		return nil, err
+	}
+
+	return ReadWithBook(db, int64(b.ID))
+}
+
+// Delete deletes the copy if it is at the version, or any version if it is 0.
+func Delete(db *gorm.DB, id int64, version uint) (*model.BookCopy, error) {
 	b, err := ReadWithBook(db, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.BookCopyModelName, b.Version, version); err != nil {
+		return nil, err
+	}
+
 	if err := b.Delete(db); err != nil {
 		return nil, err
 	}
@@ -120,7 +174,11 @@ func Delete(db *gorm.DB, id int64) (*model.BookCopy, error) {
 }
 
 func LoanCopy(db *gorm.DB, userID, id int64) (*model.Loan, error) {
-	b, err := Read(db, id)
+	if err := user.Lock(db, userID); err != nil {
+		return nil, err
+	}
+
+	b, err := ReadForUpdate(db, id)
 	if err != nil {
 		return nil, err
 	}
@@ -131,7 +189,8 @@ func LoanCopy(db *gorm.DB, userID, id int64) (*model.Loan, error) {
 		return nil, err
 	}
 	if hasExceededMaxLoan {
-		return nil, externalerrors.BadRequest("You have reached the maximum number of loans")
+		return nil, externalerrors.BadRequest("You have reached the maximum number of loans").
+			WithCode(externalerrors.LoanLimitReached)
 	}
 
 	// Check if user has loaned or reserved this book
@@ -140,12 +199,18 @@ func LoanCopy(db *gorm.DB, userID, id int64) (*model.Loan, error) {
 		return nil, err
 	}
 	if loanCount > 0 {
-		return nil, externalerrors.BadRequest("You have already loaned a copy of this book")
+		return nil, externalerrors.BadRequest("You have already loaned a copy of this book").
+			WithCode(externalerrors.BookAlreadyLoaned)
 	}
 
 	// Check if book is on loan
 	if b.Status == model.BookStatusOnLoan {
-		return nil, externalerrors.BadRequest("Book is already on loan")
+		return nil, externalerrors.BadRequest("Book is already on loan").WithCode(externalerrors.CopyOnLoan)
+	}
+
+	// Check if book is being transferred between branches
+	if b.Status == model.BookStatusInTransit {
+		return nil, externalerrors.BadRequest("Book is currently in transit").WithCode(externalerrors.CopyInTransit)
 	}
 
 	// Check if book is on reserve
@@ -157,7 +222,8 @@ func LoanCopy(db *gorm.DB, userID, id int64) (*model.Loan, error) {
 			First(&r)
 		if err := result.Error; err != nil {
 			if orm.IsRecordNotFound(err) {
-				return nil, externalerrors.BadRequest("Book is currently on reserve by another user")
+				return nil, externalerrors.BadRequest("Book is currently on reserve by another user").
+					WithCode(externalerrors.CopyOnReserve)
 			}
 			return nil, err
 		}
@@ -186,22 +252,27 @@ func LoanCopy(db *gorm.DB, userID, id int64) (*model.Loan, error) {
 	return ln, nil
 }
 
-func ReturnCopy(db *gorm.DB, loanID int64) (*model.Loan, error) {
+// ReturnCopy fails if the loan is no longer at the version, unless it is 0.
+func ReturnCopy(db *gorm.DB, loanID int64, version uint) (*model.Loan, error) {
 	ln, err := loan.Read(db, loanID)
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := Read(db, int64(ln.BookCopyID))
+	if err := orm.EnsureVersion(model.LoanModelName, ln.Version, version); err != nil {
+		return nil, err
+	}
+
+	b, err := ReadForUpdate(db, int64(ln.BookCopyID))
 	if err != nil {
 		return nil, err
 	}
 
 	if b.Status != model.BookStatusOnLoan {
-		return nil, externalerrors.BadRequest("Book is not on loan")
+		return nil, externalerrors.BadRequest("Book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 	}
 
-	ln, err = loan.ReturnLoan(db, loanID)
+	ln, err = loan.ReturnLoan(db, loanID, version)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +287,11 @@ func ReturnCopy(db *gorm.DB, loanID int64) (*model.Loan, error) {
 }
 
 func ReturnByBookCopyID(db *gorm.DB, bookCopyID int64) (*model.Loan, error) {
+	b, err := ReadForUpdate(db, bookCopyID)
+	if err != nil {
+		return nil, err
+	}
+
 	var ln model.Loan
 
 	result := db.Model(&model.Loan{}).
@@ -225,21 +301,16 @@ func ReturnByBookCopyID(db *gorm.DB, bookCopyID int64) (*model.Loan, error) {
 
 	if err := result.Error; err != nil {
 		if orm.IsRecordNotFound(err) {
-			return nil, externalerrors.BadRequest("Book is not on loan")
+			return nil, externalerrors.BadRequest("Book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 		}
 		return nil, result.Error
 	}
 
-	b, err := Read(db, int64(ln.BookCopyID))
-	if err != nil {
-		return nil, err
-	}
-
 	if b.Status != model.BookStatusOnLoan {
-		return nil, externalerrors.BadRequest("Book is not on loan")
+		return nil, externalerrors.BadRequest("Book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 	}
 
-	returnedLn, err := loan.ReturnLoan(db, int64(ln.ID))
+	returnedLn, err := loan.ReturnLoan(db, int64(ln.ID), ln.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -253,22 +324,27 @@ func ReturnByBookCopyID(db *gorm.DB, bookCopyID int64) (*model.Loan, error) {
 	return returnedLn, nil
 }
 
-func RenewCopy(db *gorm.DB, loanID int64) (*model.Loan, error) {
+// RenewCopy fails if the loan is no longer at the version, unless it is 0.
+func RenewCopy(db *gorm.DB, loanID int64, version uint) (*model.Loan, error) {
 	ln, err := loan.Read(db, loanID)
 	if err != nil {
 		return nil, err
 	}
 
-	b, err := Read(db, int64(ln.BookCopyID))
+	if err := orm.EnsureVersion(model.LoanModelName, ln.Version, version); err != nil {
+		return nil, err
+	}
+
+	b, err := ReadForUpdate(db, int64(ln.BookCopyID))
 	if err != nil {
 		return nil, err
 	}
 
 	if b.Status != model.BookStatusOnLoan {
-		return nil, externalerrors.BadRequest("Book is not on loan")
+		return nil, externalerrors.BadRequest("Book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 	}
 
-	renewedLn, err := loan.RenewLoan(db, loanID)
+	renewedLn, err := loan.RenewLoan(db, loanID, version)
 	if err != nil {
 		return nil, err
 	}
@@ -278,18 +354,33 @@ func RenewCopy(db *gorm.DB, loanID int64) (*model.Loan, error) {
 	return renewedLn, nil
 }
 
-func ReserveCopy(db *gorm.DB, userID, id int64) (*model.Reservation, error) {
-	b, err := Read(db, id)
+// ReserveCopy reserves the copy for collection at the pickup branch.
+//
+// If pickupBranchID is 0, the branch the copy is currently at is used.
+func ReserveCopy(db *gorm.DB, userID, id, pickupBranchID int64) (*model.Reservation, error) {
+	if err := user.Lock(db, userID); err != nil {
+		return nil, err
+	}
+
+	b, err := ReadForUpdate(db, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if b.Status == model.BookStatusOnLoan {
-		return nil, externalerrors.BadRequest("Book is currently on loan")
+		return nil, externalerrors.BadRequest("Book is currently on loan").WithCode(externalerrors.CopyOnLoan)
 	}
 
 	if b.Status == model.BookStatusOnReserve {
-		return nil, externalerrors.BadRequest("Book is currently on reserve")
+		return nil, externalerrors.BadRequest("Book is currently on reserve").WithCode(externalerrors.CopyOnReserve)
+	}
+
+	if b.Status == model.BookStatusInTransit {
+		return nil, externalerrors.BadRequest("Book is currently in transit").WithCode(externalerrors.CopyInTransit)
+	}
+
+	if pickupBranchID == 0 {
+		pickupBranchID = int64(b.CurrentBranchID)
 	}
 
 	hasExceededMaxReservation, err := user.HasExceededMaxReservation(db, userID)
@@ -297,7 +388,8 @@ func ReserveCopy(db *gorm.DB, userID, id int64) (*model.Reservation, error) {
 		return nil, err
 	}
 	if hasExceededMaxReservation {
-		return nil, externalerrors.BadRequest("You have reached the maximum number of reservations")
+		return nil, externalerrors.BadRequest("You have reached the maximum number of reservations").
+			WithCode(externalerrors.ReservationLimitReached)
 	}
 
 	loanCount, err := book.CountNumberOfCopiesLoanedByUser(db, userID, int64(b.BookID))
@@ -305,7 +397,8 @@ func ReserveCopy(db *gorm.DB, userID, id int64) (*model.Reservation, error) {
 		return nil, err
 	}
 	if loanCount > 0 {
-		return nil, externalerrors.BadRequest("You have already loaned a copy of this book")
+		return nil, externalerrors.BadRequest("You have already loaned a copy of this book").
+			WithCode(externalerrors.BookAlreadyLoaned)
 	}
 
 	resCount, err := book.CountNumberOfCopiesReservedByUser(db, userID, int64(b.BookID))
@@ -313,10 +406,11 @@ func ReserveCopy(db *gorm.DB, userID, id int64) (*model.Reservation, error) {
 		return nil, err
 	}
 	if resCount > 0 {
-		return nil, externalerrors.BadRequest("You have already reserved a copy of this book")
+		return nil, externalerrors.BadRequest("You have already reserved a copy of this book").
+			WithCode(externalerrors.BookAlreadyReserved)
 	}
 
-	res, err := reservation.ReserveBook(db, userID, id)
+	res, err := reservation.ReserveBook(db, userID, id, pickupBranchID)
 	if err != nil {
 		return nil, err
 	}
@@ -337,13 +431,14 @@ func CancelReservationCopy(db *gorm.DB, resID int64) (*model.Reservation, error)
 		return nil, err
 	}
 
-	b, err := Read(db, int64(res.BookCopyID))
+	b, err := ReadForUpdate(db, int64(res.BookCopyID))
 	if err != nil {
 		return nil, err
 	}
 
-	if b.Status != model.BookStatusOnReserve {
-		return nil, externalerrors.BadRequest("Book is not on reserve")
+	// Copies on their way to the pickup branch stay in transit until received
+	if b.Status != model.BookStatusOnReserve && b.Status != model.BookStatusInTransit {
+		return nil, externalerrors.BadRequest("Book is not on reserve").WithCode(externalerrors.CopyNotOnReserve)
 	}
 
 	// Fulfill the reservation
@@ -352,6 +447,10 @@ func CancelReservationCopy(db *gorm.DB, resID int64) (*model.Reservation, error)
 		return nil, err
 	}
 
+	if b.Status == model.BookStatusInTransit {
+		return res, nil
+	}
+
 	// Update book status
 	b.Status = model.BookStatusAvailable
 	if err := b.Update(db); err != nil {
@@ -361,6 +460,87 @@ func CancelReservationCopy(db *gorm.DB, resID int64) (*model.Reservation, error)
 	return res, nil
 }
 
+// TransferCopy sends the copy from the branch it is currently at to another branch.
+//
+// The copy is in transit until the transfer is received. Reserved copies can only be
+// sent to the pickup branch of their reservation.
+func TransferCopy(db *gorm.DB, userID, id, toBranchID int64) (*model.Transfer, error) {
+	b, err := ReadForUpdate(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.Status {
+	case model.BookStatusAvailable:
+	case model.BookStatusOnReserve:
+		res, err := reservation.ReadReservedByBookCopyID(db, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(res.PickupBranchID) != toBranchID {
+			return nil, externalerrors.BadRequest("Reserved copies can only be transferred to the pickup branch").
+				WithCode(externalerrors.CopyOnReserve)
+		}
+	default:
+		return nil, externalerrors.BadRequest("Only available or reserved copies can be transferred").
+			WithCode(externalerrors.CopyNotAvailable)
+	}
+
+	t, err := transfer.Send(db, userID, id, int64(b.CurrentBranchID), toBranchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Update book status
+	b.Status = model.BookStatusInTransit
+	if err := b.Update(db); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ReceiveCopy marks the transfer as received and places the copy at the destination branch.
+func ReceiveCopy(db *gorm.DB, transferID int64) (*model.Transfer, error) {
+	t, err := transfer.Read(db, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ReadForUpdate(db, int64(t.BookCopyID))
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Status != model.BookStatusInTransit {
+		return nil, externalerrors.BadRequest("Book is not in transit").WithCode(externalerrors.CopyNotInTransit)
+	}
+
+	t, err = transfer.Receive(db, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copies sent for a reservation are held for it at the pickup branch
+	isReserved, err := reservation.IsCopyReserved(db, int64(b.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	// Update book status and location
+	b.Status = model.BookStatusAvailable
+	if isReserved {
+		b.Status = model.BookStatusOnReserve
+	}
+	b.CurrentBranchID = t.ToBranchID
+	if err := b.Update(db); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
 func Count(db *gorm.DB) (int64, error) {
 	var count int64
 
@@ -0,0 +1,200 @@
+package bookcopy_test
+
+import (
+	"errors"
+	"fmt"
+	"lms-backend/internal/config"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/database"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Name of a migrated database the tests may write to. The other connection
+	// settings are the POSTGRES_* variables the app uses.
+	testDatabaseEnv = "TEST_POSTGRES_DB"
+)
+
+// connectTestDB connects to the test database, and skips the test if none is set.
+func connectTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	name := os.Getenv(testDatabaseEnv)
+	if name == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.PGDatabase = name
+
+	if err := database.SetupPostgres(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	return database.GetDB()
+}
+
+// testISBN returns an ISBN-13 that is unlikely to be used by another book.
+func testISBN() string {
+	digits := fmt.Sprintf("979%09d", time.Now().UnixNano()%1e9)
+
+	sum := 0
+	for i, d := range digits {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(d-'0') * weight
+	}
+
+	return fmt.Sprintf("%s%d", digits, (10-sum%10)%10)
+}
+
+// createLoanFixtures creates a book with one available copy and users to loan it,
+// which are deleted with their loans, reservations and transfers at the end of the test.
+func createLoanFixtures(t *testing.T, db *gorm.DB, users int) (*model.BookCopy, []model.User) {
+	t.Helper()
+
+	b := model.Book{
+		Title:           "Concurrent Loans",
+		Author:          "Test Author",
+		ISBN:            testISBN(),
+		Publisher:       "Test Publisher",
+		PublicationDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Genre:           "Test",
+		Language:        "en",
+	}
+	if err := b.Create(db); err != nil {
+		t.Fatal(err)
+	}
+
+	copy := model.BookCopy{BookID: b.ID}
+	if err := copy.Create(db); err != nil {
+		t.Fatal(err)
+	}
+
+	suffix := time.Now().UnixNano() % 1e6
+	us := make([]model.User, users)
+	for i := range us {
+		us[i] = model.User{
+			Username:          fmt.Sprintf("loanrace%06d%02d", suffix, i),
+			EncryptedPassword: "P4ssw0rd!",
+			Person:            &model.Person{FullName: fmt.Sprintf("Borrower %d", i)},
+		}
+	}
+	if err := db.Create(&us).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		loans := db.Model(&model.Loan{}).Select("id").Where("book_copy_id = ?", copy.ID)
+		userIDs := make([]uint, len(us))
+		personIDs := make([]uint, len(us))
+		for i, u := range us {
+			userIDs[i] = u.ID
+			personIDs[i] = u.PersonID
+		}
+
+		for _, result := range []*gorm.DB{
+			db.Unscoped().Where("loan_id IN (?)", loans).Delete(&model.LoanHistory{}),
+			db.Unscoped().Where("book_copy_id = ?", copy.ID).Delete(&model.Loan{}),
+			db.Unscoped().Where("book_copy_id = ?", copy.ID).Delete(&model.Reservation{}),
+			db.Unscoped().Where("book_copy_id = ?", copy.ID).Delete(&model.Transfer{}),
+			db.Unscoped().Delete(&copy),
+			db.Unscoped().Delete(&b),
+			db.Unscoped().Delete(&model.User{}, userIDs),
+			db.Unscoped().Delete(&model.Person{}, personIDs),
+		} {
+			if result.Error != nil {
+				t.Error(result.Error)
+			}
+		}
+	})
+
+	return &copy, us
+}
+
+// TestConcurrentLoansOfOneCopy loans the only copy of a book to many users at once, half
+// by the copy and half by the book, each in its own transaction as the loan endpoints do.
+func TestConcurrentLoansOfOneCopy(t *testing.T) {
+	db := connectTestDB(t)
+	copy, users := createLoanFixtures(t, db, 10)
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, len(users))
+	)
+	for i, u := range users {
+		wg.Add(1)
+		go func(i int, userID int64) {
+			defer wg.Done()
+			<-start
+
+			errs[i] = db.Transaction(func(tx *gorm.DB) error {
+				var err error
+				if i%2 == 0 {
+					_, err = bookcopy.LoanCopy(tx, userID, int64(copy.ID))
+				} else {
+					_, err = book.Loan(tx, userID, int64(copy.BookID))
+				}
+				return err
+			})
+		}(i, int64(u.ID))
+	}
+	close(start)
+	wg.Wait()
+
+	loaned := 0
+	for i, err := range errs {
+		if err == nil {
+			loaned++
+			continue
+		}
+
+		// Users that lost the race are told the copy is not available, and not a database error
+		var extErr *externalerrors.Error
+		if !errors.As(err, &extErr) {
+			t.Errorf("user %d: loan error = %v, want an external error", i, err)
+			continue
+		}
+		if extErr.Code != externalerrors.CopyOnLoan && extErr.Code != externalerrors.CopyNotAvailable {
+			t.Errorf("user %d: loan error code = %s, want %s or %s",
+				i, extErr.Code, externalerrors.CopyOnLoan, externalerrors.CopyNotAvailable)
+		}
+	}
+	if loaned != 1 {
+		t.Errorf("%d users loaned the copy, want 1", loaned)
+	}
+
+	var active int64
+	result := db.Model(&model.Loan{}).
+		Where("book_copy_id = ?", copy.ID).
+		Where("status = ?", model.LoanStatusBorrowed).
+		Count(&active)
+	if result.Error != nil {
+		t.Fatal(result.Error)
+	}
+	if active != 1 {
+		t.Errorf("copy is on %d active loans, want 1", active)
+	}
+
+	var loanedCopy model.BookCopy
+	if err := db.First(&loanedCopy, copy.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if loanedCopy.Status != model.BookStatusOnLoan {
+		t.Errorf("copy status = %s, want %s", loanedCopy.Status, model.BookStatusOnLoan)
+	}
+}
@@ -0,0 +1,84 @@
+package bookcopy_test
+
+import (
+	"errors"
+	"fmt"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// createTestBranch creates a branch, which is deleted at the end of the test.
+//
+// Create branches before the copies that refer to them, so that they are deleted after.
+func createTestBranch(t *testing.T, db *gorm.DB) *model.Branch {
+	t.Helper()
+
+	branch := model.Branch{
+		Name:    fmt.Sprintf("Test Branch %d", time.Now().UnixNano()),
+		Address: "1 Test Street",
+	}
+	if err := branch.Create(db); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := db.Unscoped().Delete(&branch).Error; err != nil {
+			t.Error(err)
+		}
+	})
+
+	return &branch
+}
+
+// TestTransferReservedCopyToPickupBranch reserves a copy for pickup at another branch, and
+// sends it there to be collected.
+func TestTransferReservedCopyToPickupBranch(t *testing.T) {
+	db := connectTestDB(t)
+	pickupBranch := createTestBranch(t, db)
+	otherBranch := createTestBranch(t, db)
+	copy, users := createLoanFixtures(t, db, 2)
+	patronID, staffID := int64(users[0].ID), int64(users[1].ID)
+
+	if _, err := bookcopy.ReserveCopy(db, patronID, int64(copy.ID), int64(pickupBranch.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := bookcopy.TransferCopy(db, staffID, int64(copy.ID), int64(otherBranch.ID))
+	var extErr *externalerrors.Error
+	if !errors.As(err, &extErr) || extErr.Code != externalerrors.CopyOnReserve {
+		t.Errorf("TransferCopy() to another branch error = %v, want %s", err, externalerrors.CopyOnReserve)
+	}
+
+	transfer, err := bookcopy.TransferCopy(db, staffID, int64(copy.ID), int64(pickupBranch.ID))
+	if err != nil {
+		t.Fatalf("TransferCopy() to the pickup branch error = %v", err)
+	}
+
+	if _, err := bookcopy.ReceiveCopy(db, int64(transfer.ID)); err != nil {
+		t.Fatalf("ReceiveCopy() error = %v", err)
+	}
+
+	received, err := bookcopy.Read(db, int64(copy.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if received.Status != model.BookStatusOnReserve {
+		t.Errorf("copy status = %s, want %s", received.Status, model.BookStatusOnReserve)
+	}
+	if received.CurrentBranchID != pickupBranch.ID {
+		t.Errorf("copy branch = %d, want %d", received.CurrentBranchID, pickupBranch.ID)
+	}
+
+	// The copy is held for the patron who reserved it
+	if _, err := bookcopy.LoanCopy(db, staffID, int64(copy.ID)); err == nil {
+		t.Error("LoanCopy() by another user error = nil, want an error")
+	}
+	if _, err := bookcopy.LoanCopy(db, patronID, int64(copy.ID)); err != nil {
+		t.Errorf("LoanCopy() by the patron error = %v", err)
+	}
+}
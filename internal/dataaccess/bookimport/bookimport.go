@@ -0,0 +1,80 @@
+package bookimport
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+
+	"gorm.io/gorm"
+)
+
+func preloadErrors(db *gorm.DB) *gorm.DB {
+	return db.Preload("Errors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("row ASC").Order("id ASC")
+	})
+}
+
+func Read(db *gorm.DB, id int64) (*model.BookImport, error) {
+	var b model.BookImport
+	result := db.Model(&model.BookImport{}).
+		Where("id = ?", id).
+		First(&b)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.BookImportModelName)
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// ReadDetailed reads the import with its row errors in row order.
+func ReadDetailed(db *gorm.DB, id int64) (*model.BookImport, error) {
+	var b model.BookImport
+	result := db.Model(&model.BookImport{}).
+		Scopes(preloadErrors).
+		Preload("User").
+		Where("id = ?", id).
+		First(&b)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.BookImportModelName)
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func Create(db *gorm.DB, b *model.BookImport) (*model.BookImport, error) {
+	if err := b.Create(db); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.BookImport{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.BookImport, error) {
+	var bs []model.BookImport
+
+	result := db.Model(&model.BookImport{}).
+		Find(&bs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return bs, nil
+}
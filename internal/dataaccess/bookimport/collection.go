@@ -0,0 +1,22 @@
+package bookimport
+
+import (
+	"lms-backend/internal/model"
+	collection "lms-backend/pkg/collectionquery"
+)
+
+func Filters() collection.FilterMap {
+	return map[string]collection.Filter{
+		"status":     collection.EnumFilter("status", model.BookImportStatuses),
+		"user_id":    collection.IntFilter("user_id"),
+		"file_name":  collection.StringFilter("file_name"),
+		"created_at": collection.DateFilter("created_at"),
+	}
+}
+
+func Sorters() collection.SortMap {
+	return map[string]collection.Sorter{
+		"file_name":  collection.SortBy("file_name"),
+		"created_at": collection.SortBy("created_at"),
+	}
+}
@@ -6,8 +6,8 @@ import (
 
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"book_id": collection.IntEqualFilter("book_id"),
-		"user_id": collection.IntEqualFilter("user_id"),
+		"book_id": collection.IntFilter("book_id"),
+		"user_id": collection.IntFilter("user_id"),
 	}
 }
 
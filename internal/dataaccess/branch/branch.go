@@ -0,0 +1,130 @@
+package branch
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+
+	"gorm.io/gorm"
+)
+
+func Read(db *gorm.DB, id int64) (*model.Branch, error) {
+	var b model.Branch
+	result := db.Model(&model.Branch{}).
+		Where("id = ?", id).
+		First(&b)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.BranchModelName)
+		}
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func GetBranchName(db *gorm.DB, id int64) (string, error) {
+	var name string
+
+	result := db.Model(&model.Branch{}).
+		Select("name").
+		Where("id = ?", id).
+		First(&name)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return "", orm.ErrRecordNotFound(model.BranchModelName)
+		}
+		return "", err
+	}
+
+	return name, nil
+}
+
+func Create(db *gorm.DB, b *model.Branch) (*model.Branch, error) {
+	if err := b.Create(db); err != nil {
+		return nil, err
+	}
+
+	return Read(db, int64(b.ID))
+}
+
+func Update(db *gorm.DB, b *model.Branch) (*model.Branch, error) {
+	if err := b.Update(db); err != nil {
+		return nil, err
+	}
+
+	return Read(db, int64(b.ID))
+}
+
+func Delete(db *gorm.DB, id int64) (*model.Branch, error) {
+	b, err := Read(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Delete(db); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.Branch{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.Branch, error) {
+	var bs []model.Branch
+
+	result := db.Model(&model.Branch{}).
+		Find(&bs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return bs, nil
+}
+
+// ListByUserID returns the branches a staff member is scoped to.
+//
+// An empty slice means the user is not restricted to any branch.
+func ListByUserID(db *gorm.DB, userID int64) ([]model.Branch, error) {
+	var bs []model.Branch
+
+	result := db.Model(&model.Branch{}).
+		Joins("JOIN user_branches ON user_branches.branch_id = branches.id").
+		Where("user_branches.user_id = ?", userID).
+		Order("branches.id ASC").
+		Find(&bs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return bs, nil
+}
+
+// IDsByName maps the name of every branch to its ID.
+func IDsByName(db *gorm.DB) (map[string]uint, error) {
+	var branches []model.Branch
+	result := db.Model(&model.Branch{}).
+		Select("id", "name").
+		Find(&branches)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	ids := map[string]uint{}
+	for _, b := range branches {
+		ids[b.Name] = b.ID
+	}
+
+	return ids, nil
+}
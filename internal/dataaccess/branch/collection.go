@@ -0,0 +1,19 @@
+package branch
+
+import (
+	collection "lms-backend/pkg/collectionquery"
+)
+
+func Filters() collection.FilterMap {
+	return map[string]collection.Filter{
+		"name":  collection.StringFilter("name"),
+		"value": collection.AnyColumnLikeFilter([]string{"name", "address"}),
+	}
+}
+
+func Sorters() collection.SortMap {
+	return map[string]collection.Sorter{
+		"name":       collection.SortBy("name"),
+		"created_at": collection.SortBy("created_at"),
+	}
+}
@@ -0,0 +1,48 @@
+package contributor
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+
+	"gorm.io/gorm"
+)
+
+func Read(db *gorm.DB, id int64) (*model.Contributor, error) {
+	var contributor model.Contributor
+	result := db.Model(&model.Contributor{}).
+		Where("id = ?", id).
+		First(&contributor)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.ContributorModelName)
+		}
+		return nil, err
+	}
+
+	return &contributor, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.Contributor{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.Contributor, error) {
+	var contributors []model.Contributor
+
+	result := db.Model(&model.Contributor{}).
+		Find(&contributors)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return contributors, nil
+}
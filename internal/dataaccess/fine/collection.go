@@ -1,17 +1,18 @@
 package fine
 
 import (
+	"lms-backend/internal/model"
 	collection "lms-backend/pkg/collectionquery"
 )
 
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"status":         collection.StringEqualFilter("fines.status"),
-		"user_id":        collection.MultipleIntEqualFilter("fines.user_id"),
-		"loan_id":        collection.MultipleIntEqualFilter("loan_id"),
-		"users.username": collection.StringLikeFilter("users.username", JoinUser),
-		"books.value":    collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinLoan, JoinBookCopy, JoinBook),
-		"value":          collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinLoan, JoinBookCopy, JoinBook, JoinUser),
+		"status":         collection.EnumFilter("fines.status", model.FineStatuses),
+		"user_id":        collection.IntFilter("fines.user_id"),
+		"loan_id":        collection.IntFilter("loan_id"),
+		"users.username": collection.StringFilter("users.username", JoinUser),
+		"books.value":    collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinLoan, JoinBookCopy, JoinBook),
+		"value":          collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinLoan, JoinBookCopy, JoinBook, JoinUser),
 	}
 }
 
@@ -1,17 +1,22 @@
 package loan
 
 import (
+	"lms-backend/internal/model"
 	collection "lms-backend/pkg/collectionquery"
 )
 
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"status":         collection.StringEqualFilter("loans.status"),
-		"user_id":        collection.MultipleIntEqualFilter("loans.user_id"),
-		"book_id":        collection.MultipleIntEqualFilter("loans.book_id"),
-		"users.username": collection.StringLikeFilter("users.username", JoinUser),
-		"books.value":    collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinBookCopy, JoinBook),
-		"value":          collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinBookCopy, JoinBook, JoinUser),
+		"status":         collection.EnumFilter("loans.status", model.LoanStatuses),
+		"user_id":        collection.IntFilter("loans.user_id"),
+		"book_id":        collection.IntFilter("loans.book_id"),
+		"borrow_date":    collection.DateFilter("loans.borrow_date"),
+		"due_date":       collection.DateFilter("loans.due_date"),
+		"return_date":    collection.DateFilter("loans.return_date"),
+		"users.username": collection.StringFilter("users.username", JoinUser),
+		"barcode":        collection.StringFilter("book_copies.accession_number", JoinBookCopy).WithOperators(collection.Eq, collection.In, collection.Like),
+		"books.value":    collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinBookCopy, JoinBook),
+		"value":          collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinBookCopy, JoinBook, JoinUser),
 	}
 }
 
@@ -82,12 +82,17 @@ func ListBorrowedLoanByUserID(db *gorm.DB, userID int64) ([]model.Loan, error) {
 	return loans, nil
 }
 
-func Delete(db *gorm.DB, loanID int64) (*model.Loan, error) {
+// Delete deletes the loan if it is at the version, or any version if it is 0.
+func Delete(db *gorm.DB, loanID int64, version uint) (*model.Loan, error) {
 	ln, err := ReadDetailed(db, loanID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.LoanModelName, ln.Version, version); err != nil {
+		return nil, err
+	}
+
 	if err := ln.Delete(db); err != nil {
 		return nil, err
 	}
@@ -265,14 +270,18 @@ func Loan(db *gorm.DB, userID, copyID int64) (*model.Loan, error) {
 	return ReadDetailed(db, int64(ln.ID))
 }
 
-func ReturnLoan(db *gorm.DB, loanID int64) (*model.Loan, error) {
+func ReturnLoan(db *gorm.DB, loanID int64, version uint) (*model.Loan, error) {
 	ln, err := ReadDetailed(db, loanID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.LoanModelName, ln.Version, version); err != nil {
+		return nil, err
+	}
+
 	if ln.Status != model.LoanStatusBorrowed {
-		return nil, externalerrors.BadRequest("book is not on loan")
+		return nil, externalerrors.BadRequest("book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 	}
 
 	ln.ReturnDate = sql.NullTime{
@@ -292,20 +301,25 @@ func ReturnLoan(db *gorm.DB, loanID int64) (*model.Loan, error) {
 	return ln, nil
 }
 
-func RenewLoan(db *gorm.DB, loanID int64) (*model.Loan, error) {
+func RenewLoan(db *gorm.DB, loanID int64, version uint) (*model.Loan, error) {
 	ln, err := ReadDetailed(db, loanID)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.LoanModelName, ln.Version, version); err != nil {
+		return nil, err
+	}
+
 	if ln.Status != model.LoanStatusBorrowed {
-		return nil, externalerrors.BadRequest("book is not on loan")
+		return nil, externalerrors.BadRequest("book is not on loan").WithCode(externalerrors.CopyNotOnLoan)
 	}
 
 	ln.DueDate = ln.DueDate.Add(model.LoanDuration)
 	// check if the loaned duration exceeds maximum
 	if ln.DueDate.Sub(ln.BorrowDate) > model.MaximumLoanDuration {
-		return nil, externalerrors.BadRequest("loan duration exceeds maximum")
+		return nil, externalerrors.BadRequest("loan duration exceeds maximum").
+			WithCode(externalerrors.LoanDurationExceeded)
 	}
 
 	ln.LoanHistories = append(ln.LoanHistories, model.LoanHistory{
@@ -0,0 +1,48 @@
+package publisher
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+
+	"gorm.io/gorm"
+)
+
+func Read(db *gorm.DB, id int64) (*model.Publisher, error) {
+	var publisher model.Publisher
+	result := db.Model(&model.Publisher{}).
+		Where("id = ?", id).
+		First(&publisher)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.PublisherModelName)
+		}
+		return nil, err
+	}
+
+	return &publisher, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.Publisher{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.Publisher, error) {
+	var publishers []model.Publisher
+
+	result := db.Model(&model.Publisher{}).
+		Find(&publishers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return publishers, nil
+}
@@ -1,17 +1,20 @@
 package reservation
 
 import (
+	"lms-backend/internal/model"
 	collection "lms-backend/pkg/collectionquery"
 )
 
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"status":         collection.StringEqualFilter("reservations.status"),
-		"user_id":        collection.MultipleIntEqualFilter("reservations.user_id"),
-		"book_id":        collection.MultipleIntEqualFilter("reservations.book_id"),
-		"users.username": collection.StringLikeFilter("users.username", JoinUser),
-		"books.value":    collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinBookCopy, JoinBook),
-		"value":          collection.MultipleColumnStringLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinBookCopy, JoinBook, JoinUser),
+		"status":           collection.EnumFilter("reservations.status", model.ReservationStatuses),
+		"user_id":          collection.IntFilter("reservations.user_id"),
+		"book_id":          collection.IntFilter("reservations.book_id"),
+		"pickup_branch_id": collection.IntFilter("reservations.pickup_branch_id"),
+		"reservation_date": collection.DateFilter("reservations.reservation_date"),
+		"users.username":   collection.StringFilter("users.username", JoinUser),
+		"books.value":      collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinBookCopy, JoinBook),
+		"value":            collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher", "users.username"}, JoinBookCopy, JoinBook, JoinUser),
 	}
 }
 
@@ -138,13 +138,28 @@ func ReadReservedByBookCopyID(db *gorm.DB, bookCopyID int64) (*model.Reservation
 
 	if err := result.Error; err != nil {
 		if orm.IsRecordNotFound(err) {
-			return nil, externalerrors.BadRequest("Book is not reserved")
+			return nil, externalerrors.BadRequest("Book is not reserved").WithCode(externalerrors.CopyNotOnReserve)
 		}
 		return nil, result.Error
 	}
 	return &res, nil
 }
 
+// IsCopyReserved returns whether the copy is held for a pending reservation.
+func IsCopyReserved(db *gorm.DB, bookCopyID int64) (bool, error) {
+	var count int64
+
+	result := db.Model(&model.Reservation{}).
+		Where("book_copy_id = ?", bookCopyID).
+		Where("status = ?", model.ReservationStatusPending).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
 func ListWithBookUser(db *gorm.DB) ([]model.Reservation, error) {
 	var res []model.Reservation
 
@@ -213,12 +228,13 @@ func CountOutstandingReservationsByUserID(db *gorm.DB, userID int64) (int64, err
 // User should not have more than maximum reservations and loans.
 //
 // Book should be neither on loan nor on reserve.
-func ReserveBook(db *gorm.DB, userID, copyID int64) (*model.Reservation, error) {
+func ReserveBook(db *gorm.DB, userID, copyID, pickupBranchID int64) (*model.Reservation, error) {
 	reservation := &model.Reservation{
 		UserID:          uint(userID),
 		BookCopyID:      uint(copyID),
 		Status:          model.ReservationStatusPending,
 		ReservationDate: time.Now().Add(model.ReservationDuration),
+		PickupBranchID:  uint(pickupBranchID),
 	}
 
 	if err := reservation.Create(db); err != nil {
@@ -238,7 +254,8 @@ func FullfilReservation(db *gorm.DB, reservationID int64) (*model.Reservation, e
 	}
 
 	if reservation.Status != model.ReservationStatusPending {
-		return nil, externalerrors.BadRequest("reservation is not pending")
+		return nil, externalerrors.BadRequest("reservation is not pending").
+			WithCode(externalerrors.ReservationNotPending)
 	}
 
 	reservation.Status = model.ReservationStatusFulfilled
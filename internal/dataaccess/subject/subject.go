@@ -0,0 +1,48 @@
+package subject
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+
+	"gorm.io/gorm"
+)
+
+func Read(db *gorm.DB, id int64) (*model.Subject, error) {
+	var subject model.Subject
+	result := db.Model(&model.Subject{}).
+		Where("id = ?", id).
+		First(&subject)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.SubjectModelName)
+		}
+		return nil, err
+	}
+
+	return &subject, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.Subject{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.Subject, error) {
+	var subjects []model.Subject
+
+	result := db.Model(&model.Subject{}).
+		Find(&subjects)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return subjects, nil
+}
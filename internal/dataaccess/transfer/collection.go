@@ -0,0 +1,26 @@
+package transfer
+
+import (
+	"lms-backend/internal/model"
+	collection "lms-backend/pkg/collectionquery"
+)
+
+func Filters() collection.FilterMap {
+	return map[string]collection.Filter{
+		"status":         collection.EnumFilter("transfers.status", model.TransferStatuses),
+		"book_copy_id":   collection.IntFilter("transfers.book_copy_id"),
+		"from_branch_id": collection.IntFilter("transfers.from_branch_id"),
+		"to_branch_id":   collection.IntFilter("transfers.to_branch_id"),
+		"sent_at":        collection.DateFilter("transfers.sent_at"),
+		"received_at":    collection.DateFilter("transfers.received_at"),
+		"value":          collection.AnyColumnLikeFilter([]string{"books.title", "books.author", "books.isbn", "books.publisher"}, JoinBookCopy, JoinBook),
+	}
+}
+
+func Sorters() collection.SortMap {
+	return map[string]collection.Sorter{
+		"sent_at":     collection.SortBy("transfers.sent_at"),
+		"received_at": collection.SortBy("transfers.received_at"),
+		"created_at":  collection.SortBy("transfers.created_at"),
+	}
+}
@@ -0,0 +1,6 @@
+package transfer
+
+const (
+	JoinBookCopy = "JOIN book_copies ON transfers.book_copy_id = book_copies.id"
+	JoinBook     = "JOIN books ON book_copies.book_id = books.id"
+)
@@ -0,0 +1,132 @@
+package transfer
+
+import (
+	"database/sql"
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
+	"lms-backend/pkg/error/externalerrors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func preloadAssociations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("BookCopy").
+		Preload("BookCopy.Book").
+		Preload("FromBranch").
+		Preload("ToBranch").
+		Preload("User")
+}
+
+func Read(db *gorm.DB, id int64) (*model.Transfer, error) {
+	var t model.Transfer
+	result := db.Model(&model.Transfer{}).
+		Where("id = ?", id).
+		First(&t)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.TransferModelName)
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func ReadDetailed(db *gorm.DB, id int64) (*model.Transfer, error) {
+	var t model.Transfer
+	result := db.Model(&model.Transfer{}).
+		Scopes(preloadAssociations).
+		Where("id = ?", id).
+		First(&t)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return nil, orm.ErrRecordNotFound(model.TransferModelName)
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func Count(db *gorm.DB) (int64, error) {
+	var count int64
+
+	result := orm.CloneSession(db).
+		Model(&model.Transfer{}).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return count, nil
+}
+
+func List(db *gorm.DB) ([]model.Transfer, error) {
+	var ts []model.Transfer
+
+	result := db.Model(&model.Transfer{}).
+		Find(&ts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ts, nil
+}
+
+func ListDetailed(db *gorm.DB) ([]model.Transfer, error) {
+	var ts []model.Transfer
+
+	result := db.Model(&model.Transfer{}).
+		Scopes(preloadAssociations).
+		Find(&ts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ts, nil
+}
+
+// Assumes that the book copy is available at the origin branch, or reserved for pickup at
+// the destination branch.
+//
+// Relevant checks should be done before calling this function.
+func Send(db *gorm.DB, userID, copyID, fromBranchID, toBranchID int64) (*model.Transfer, error) {
+	t := model.Transfer{
+		BookCopyID:   uint(copyID),
+		FromBranchID: uint(fromBranchID),
+		ToBranchID:   uint(toBranchID),
+		UserID:       uint(userID),
+		Status:       model.TransferStatusInTransit,
+		SentAt:       time.Now(),
+	}
+	if err := t.Create(db); err != nil {
+		return nil, err
+	}
+
+	return ReadDetailed(db, int64(t.ID))
+}
+
+func Receive(db *gorm.DB, id int64) (*model.Transfer, error) {
+	t, err := ReadDetailed(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status != model.TransferStatusInTransit {
+		return nil, externalerrors.BadRequest("transfer is not in transit").
+			WithCode(externalerrors.TransferNotInTransit)
+	}
+
+	t.Status = model.TransferStatusReceived
+	t.ReceivedAt = sql.NullTime{
+		Time:  time.Now(),
+		Valid: true,
+	}
+	if err := t.Update(db); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -1,16 +1,23 @@
 package user
 
 import (
+	"lms-backend/internal/orm"
 	collection "lms-backend/pkg/collectionquery"
 )
 
+var autoCompleteFields = []orm.FuzzyField{
+	{Name: "username", Column: "users.username"},
+	{Name: "full_name", Column: "people.full_name"},
+	{Name: "preferred_name", Column: "people.preferred_name"},
+}
+
 func Filters() collection.FilterMap {
 	return map[string]collection.Filter{
-		"username":        collection.StringLikeFilter("username"),
-		"email":           collection.StringLikeFilter("email"),
-		"full_name":       collection.StringLikeFilter("people.full_name", JoinPerson),
-		"Preferred_named": collection.StringLikeFilter("people.preferred_name", JoinPerson),
-		"value":           collection.MultipleColumnStringLikeFilter([]string{"username", "email", "people.full_name", "people.preferred_name"}, JoinPerson),
+		"username":       collection.StringFilter("username"),
+		"email":          collection.StringFilter("email"),
+		"full_name":      collection.StringFilter("people.full_name", JoinPerson),
+		"preferred_name": collection.StringFilter("people.preferred_name", JoinPerson),
+		"value":          collection.AnyColumnLikeFilter([]string{"username", "email", "people.full_name", "people.preferred_name"}, JoinPerson),
 	}
 }
 
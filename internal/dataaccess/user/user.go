@@ -5,7 +5,9 @@ import (
 	"lms-backend/internal/dataaccess/reservation"
 	"lms-backend/internal/model"
 	"lms-backend/internal/orm"
+	"lms-backend/internal/viewmodel"
 	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -42,6 +44,25 @@ func Read(db *gorm.DB, id int64) (*model.User, error) {
 	return &user, nil
 }
 
+// Lock locks the user until the end of the transaction, so that the loans and reservations
+// of the user are counted and made by one request at a time.
+func Lock(db *gorm.DB, id int64) error {
+	var user model.User
+	result := db.Model(&model.User{}).
+		Scopes(orm.ForUpdate).
+		Select("id").
+		Where("id = ?", id).
+		First(&user)
+	if err := result.Error; err != nil {
+		if orm.IsRecordNotFound(err) {
+			return orm.ErrRecordNotFound(model.UserModelName)
+		}
+		return err
+	}
+
+	return nil
+}
+
 func ReadDetailed(db *gorm.DB, id int64) (*model.User, error) {
 	var user model.User
 	result := db.Model(&model.User{}).
@@ -127,12 +148,17 @@ func UpdateParticulars(db *gorm.DB, user *model.User) (*model.User, error) {
 	return Update(db, user)
 }
 
-func Delete(db *gorm.DB, id int64) (*model.User, error) {
+// Delete deletes the user if it is at the version, or any version if it is 0.
+func Delete(db *gorm.DB, id int64, version uint) (*model.User, error) {
 	usr, err := ReadDetailed(db, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := orm.EnsureVersion(model.UserModelName, usr.Version, version); err != nil {
+		return nil, err
+	}
+
 	if err := usr.Person.Delete(db); err != nil {
 		return nil, err
 	}
@@ -209,7 +235,8 @@ func Login(db *gorm.DB, user *model.User) (*model.User, error) {
 
 	err := bcrypt.CompareHashAndPassword([]byte(userInDB.EncryptedPassword), []byte(user.EncryptedPassword))
 	if err != nil {
-		return nil, externalerrors.Unauthorized("user not found or invalid password")
+		return nil, externalerrors.Unauthorized("user not found or invalid password").
+			WithCode(externalerrors.InvalidCredentials)
 	}
 
 	userInDB.LastSignInAt = userInDB.CurrentSignInAt
@@ -248,6 +275,19 @@ func UpdateRoles(db *gorm.DB, userID int64, roleIDs ...int64) (*model.User, erro
 	return usr, nil
 }
 
+func UpdateBranches(db *gorm.DB, userID int64, branchIDs ...int64) (*model.User, error) {
+	usr, err := Read(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usr.UpdateBranches(db, branchIDs); err != nil {
+		return nil, err
+	}
+
+	return usr, nil
+}
+
 func GetAbilities(db *gorm.DB, userID int64) ([]model.Ability, error) {
 	var abilities []model.Ability
 
@@ -283,6 +323,16 @@ func GetRoles(db *gorm.DB, userID int64) ([]model.Role, error) {
 	return roles, nil
 }
 
+// IsStaff is whether the user has a role of staff or above.
+func IsStaff(db *gorm.DB, userID int64) (bool, error) {
+	roles, err := GetRoles(db, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return sliceutil.Find(roles, func(r model.Role) bool { return r.ID <= model.StaffRole }) != nil, nil
+}
+
 func HasExceededMaxLoan(db *gorm.DB, userID int64) (bool, error) {
 	count, err := loan.CountOutstandingLoansByUserID(db, userID)
 	if err != nil {
@@ -301,23 +351,26 @@ func HasExceededMaxReservation(db *gorm.DB, userID int64) (bool, error) {
 	return count > model.MaximumReservations, nil
 }
 
-func AutoComplete(db *gorm.DB, value string) ([]model.User, error) {
+// AutoComplete suggests users whose username or name resembles value, best match first.
+// Typos and accents are tolerated.
+func AutoComplete(db *gorm.DB, value string) ([]viewmodel.UserAutoCompleteViewModel, error) {
 	if len(value) == 0 {
-		return []model.User{}, nil
+		return []viewmodel.UserAutoCompleteViewModel{}, nil
 	}
 
-	var users []model.User
+	var suggestions []viewmodel.UserAutoCompleteViewModel
 
-	result := db.Model(&model.User{}).
-		Joins(JoinPerson).
-		Where("username ILIKE ?", "%%"+value+"%%").
-		Or("people.full_name ILIKE ?", "%%"+value+"%%").
-		Or("people.preferred_name ILIKE ?", "%%"+value+"%%").
-		Limit(5).
-		Find(&users)
+	matches := orm.FuzzyMatches(db.Model(&model.User{}).Joins(JoinPerson), "users.id", value, autoCompleteFields,
+		"users.id AS id", "users.username AS username",
+	)
+	result := db.Table("(?) AS matches", matches).
+		Order("score DESC").
+		Order("username ASC").
+		Limit(orm.FuzzyMatchLimit).
+		Find(&suggestions)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return users, nil
+	return suggestions, nil
 }
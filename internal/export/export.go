@@ -0,0 +1,218 @@
+// Package export streams the rows of a collection as a CSV or XLSX file.
+package export
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/xlsx"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Format = string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+
+	formatQueryKey = "format"
+
+	MIMECSV  = "text/csv"
+	MIMEXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// Rows fetched per query while streaming
+	BatchSize = 500
+)
+
+// Column is a column of the exported file.
+type Column[T any] struct {
+	Header string
+	Value  func(*T) string
+}
+
+// RequestedFormat is the format asked for with format=csv|xlsx or the Accept header.
+// It is empty if the client wants the usual JSON list.
+func RequestedFormat(c *fiber.Ctx) (Format, error) {
+	if format := c.Query(formatQueryKey); format != "" {
+		switch format {
+		case FormatCSV, FormatXLSX:
+			return format, nil
+		default:
+			return "", externalerrors.BadRequest(fmt.Sprintf(
+				"%s is not a supported export format, use %s or %s", format, FormatCSV, FormatXLSX,
+			))
+		}
+	}
+
+	// JSON is offered first so that clients accepting anything get the usual list
+	switch c.Accepts(fiber.MIMEApplicationJSON, MIMECSV, MIMEXLSX) {
+	case MIMECSV:
+		return FormatCSV, nil
+	case MIMEXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", nil
+	}
+}
+
+// rowWriter is the common part of the CSV and XLSX writers.
+type rowWriter interface {
+	Write(cells []string) error
+	Close() error
+}
+
+type csvWriter struct {
+	*csv.Writer
+}
+
+func (w csvWriter) Write(cells []string) error {
+	return w.Writer.Write(escapeFormulas(cells))
+}
+
+func (w csvWriter) Close() error {
+	w.Flush()
+	return w.Error()
+}
+
+// escapeFormulas prefixes cells that spreadsheet programs would run as formulas, so that
+// data entered by users cannot run in the spreadsheet of the staff exporting it.
+func escapeFormulas(cells []string) []string {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			cell = "'" + cell
+		}
+		escaped = append(escaped, cell)
+	}
+
+	return escaped
+}
+
+// Stream sends every row of the query as a file named after the resource, fetching
+// the rows in batches with list as the response is written.
+//
+// db should be filtered and sorted but not paginated. The rows are also sorted by
+// primary key so that batches neither skip nor repeat rows.
+//
+// Errors after the response has started cannot be sent to the client, so they are
+// logged and the file is cut short.
+func Stream[T any](
+	c *fiber.Ctx,
+	format Format,
+	resource string,
+	db *gorm.DB,
+	list func(*gorm.DB) ([]T, error),
+	columns []Column[T],
+) error {
+	fileName := fmt.Sprintf("%s-%s.%s", resource, time.Now().Format("2006-01-02"), format)
+	c.Attachment(fileName)
+
+	switch format {
+	case FormatCSV:
+		c.Set(fiber.HeaderContentType, MIMECSV)
+	case FormatXLSX:
+		c.Set(fiber.HeaderContentType, MIMEXLSX)
+	default:
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a supported export format", format))
+	}
+
+	db = db.Scopes(func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{
+			Column: clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey},
+		})
+	})
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := writeRows(w, format, resource, db, list, columns); err != nil {
+			log.Printf("export of %s failed: %s\n", resource, err)
+		}
+	})
+
+	return nil
+}
+
+func writeRows[T any](
+	w *bufio.Writer,
+	format Format,
+	resource string,
+	db *gorm.DB,
+	list func(*gorm.DB) ([]T, error),
+	columns []Column[T],
+) error {
+	var writer rowWriter
+	if format == FormatXLSX {
+		xlsxWriter, err := xlsx.NewWriter(w, resource)
+		if err != nil {
+			return err
+		}
+		writer = xlsxWriter
+	} else {
+		writer = csvWriter{csv.NewWriter(w)}
+	}
+
+	headers := make([]string, 0, len(columns))
+	for _, column := range columns {
+		headers = append(headers, column.Header)
+	}
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	for offset := 0; ; offset += BatchSize {
+		rows, err := list(db.Offset(offset).Limit(BatchSize))
+		if err != nil {
+			return err
+		}
+
+		for i := range rows {
+			cells := make([]string, 0, len(columns))
+			for _, column := range columns {
+				cells = append(cells, column.Value(&rows[i]))
+			}
+			if err := writer.Write(cells); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < BatchSize {
+			break
+		}
+		// Send each batch as it is ready
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// Time formats a time the way the JSON views do.
+func Time(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// NullTime formats a time, or is empty for NULL.
+func NullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+
+	return Time(t.Time)
+}
+
+func ID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,169 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"lms-backend/internal/policy"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// fields[<resource>]=<field>,<field>
+	fieldsKeyFormat = "fields[%s]"
+	// include=<include>,<include>
+	includeKey = "include"
+	// Always kept so that the client can tell the rows apart
+	idField = "id"
+)
+
+// Include is an association a client can ask for with include=<name>.
+type Include struct {
+	// Key of the association in the view
+	Key string
+	// Checked before the association is loaded, nil if everyone may include it
+	Policy policy.Policy
+}
+
+// Resource whitelists the fields and includes a client can ask for.
+//
+// Fields are keys of the view. Views may have keys that are not fields, such as the
+// rank of a search result, which are only shown when fields is not sent.
+type Resource struct {
+	Name     string
+	Fields   []string
+	Includes map[string]Include
+	// Used when include is not sent, so that the view is unchanged for clients that do not ask
+	DefaultIncludes []string
+}
+
+// Selection is the fields and includes a client asked for.
+type Selection struct {
+	resource *Resource
+	// nil when fields is not sent
+	fields   []string
+	includes []string
+	sparse   bool
+}
+
+// Parse reads fields[<resource>] and include from the query.
+//
+// A bad request error is returned for fields and includes not whitelisted by the resource,
+// and a forbidden error for includes the client is not authorized to load.
+func (r *Resource) Parse(c *fiber.Ctx) (*Selection, error) {
+	selection := &Selection{
+		resource: r,
+		includes: r.DefaultIncludes,
+	}
+
+	args := c.Context().QueryArgs()
+	fieldsKey := fmt.Sprintf(fieldsKeyFormat, r.Name)
+
+	if args.Has(fieldsKey) {
+		selection.sparse = true
+		selection.fields = splitList(c.Query(fieldsKey))
+		for _, field := range selection.fields {
+			if !sliceutil.Contains(r.Fields, field) {
+				return nil, externalerrors.BadRequest(fmt.Sprintf(
+					"%s is not a field of %s, use any of %s", field, r.Name, strings.Join(r.Fields, ", "),
+				))
+			}
+		}
+	}
+
+	if args.Has(includeKey) {
+		selection.sparse = true
+		selection.includes = splitList(c.Query(includeKey))
+		for _, name := range selection.includes {
+			include, ok := r.Includes[name]
+			if !ok {
+				return nil, externalerrors.BadRequest(fmt.Sprintf("%s cannot be included with %s", name, r.Name))
+			}
+
+			if include.Policy == nil {
+				continue
+			}
+
+			err := policy.Authorize(c, "include "+name, include.Policy)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return selection, nil
+}
+
+func splitList(value string) []string {
+	values := []string{}
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			values = append(values, s)
+		}
+	}
+
+	return values
+}
+
+// HasField reports whether the field should be shown, which is always the case if fields is not sent.
+func (s *Selection) HasField(field string) bool {
+	return s.fields == nil || sliceutil.Contains(s.fields, field)
+}
+
+// HasInclude reports whether the association should be loaded and shown.
+func (s *Selection) HasInclude(name string) bool {
+	return sliceutil.Contains(s.includes, name)
+}
+
+// Apply keeps the selected fields and includes of the view.
+//
+// The view is returned as is if neither fields nor include was sent.
+func (s *Selection) Apply(view interface{}) (interface{}, error) {
+	if !s.sparse {
+		return view, nil
+	}
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	included := map[string]bool{}
+	for name, include := range s.resource.Includes {
+		included[include.Key] = s.HasInclude(name)
+	}
+
+	for key := range values {
+		isIncluded, isInclude := included[key]
+		switch {
+		case isInclude && isIncluded, key == idField:
+			continue
+		case isInclude, s.fields != nil && !sliceutil.Contains(s.fields, key):
+			delete(values, key)
+		}
+	}
+
+	return values, nil
+}
+
+// ApplyAll keeps the selected fields and includes of each view.
+func ApplyAll[T any](s *Selection, views []T) ([]interface{}, error) {
+	selected := make([]interface{}, 0, len(views))
+	for _, view := range views {
+		v, err := s.Apply(view)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, v)
+	}
+
+	return selected, nil
+}
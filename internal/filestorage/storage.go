@@ -47,6 +47,37 @@ func SaveFileToDisk(c *fiber.Ctx, fileHeader *multipart.FileHeader, subdirectory
 	return filename, filePath, nil
 }
 
+// SaveBytesToDisk saves downloaded file content to the disk.
+// It returns the filename of the saved file and the path to the file.
+//
+//nolint:revive
+func SaveBytesToDisk(data []byte, contentType string, subdirectory ...string) (string, string, error) {
+	err := ValidateFileContent(data, contentType)
+	if err != nil {
+		return "", "", err
+	}
+
+	fileUUID := utils.UUIDv4()
+	filename := fileUUID + allowedContentTypes[contentType][0]
+
+	filePath, err := Storage.ConstructFilePath(append(subdirectory, filename)...)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Create directory
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return "", "", errors.Wrap(err, "creating directory failed")
+	}
+
+	if err := os.WriteFile(filePath, data, 0o600); err != nil {
+		return "", "", errors.Wrap(err, "writing file failed")
+	}
+
+	return filename, filePath, nil
+}
+
 // filePath should not be a user input.
 func DeleteFileFromDisk(filePath string) error {
 	if err := Storage.ValidateFilePath(filePath); err != nil {
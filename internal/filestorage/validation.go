@@ -10,7 +10,7 @@ import (
 
 const (
 	megabyte    = 1_000_000
-	maxFileSize = 10 * megabyte
+	MaxFileSize = 10 * megabyte
 )
 
 var allowedContentTypes = map[string][]string{
@@ -23,9 +23,9 @@ var allowedContentTypes = map[string][]string{
 
 // isLessThanMaxFileSize checks if the uploaded file size is within the allowed limit.
 func isLessThanMaxFileSize(fileHeader *multipart.FileHeader) error {
-	if fileHeader.Size > maxFileSize {
+	if fileHeader.Size > MaxFileSize {
 		return externalerrors.BadRequest(
-			fmt.Sprintf("file size %d exceeds maximum limit of %d bytes", fileHeader.Size, maxFileSize),
+			fmt.Sprintf("file size %d exceeds maximum limit of %d bytes", fileHeader.Size, MaxFileSize),
 		)
 	}
 
@@ -77,3 +77,20 @@ func ValidateFileUpload(fileHeader *multipart.FileHeader) error {
 
 	return extensionMatchesContentType(fileHeader)
 }
+
+// ValidateFileContent checks content that did not come from a multipart upload, such as a download.
+func ValidateFileContent(data []byte, contentType string) error {
+	if len(data) > MaxFileSize {
+		return externalerrors.BadRequest(
+			fmt.Sprintf("file size %d exceeds maximum limit of %d bytes", len(data), MaxFileSize),
+		)
+	}
+
+	if _, ok := allowedContentTypes[contentType]; !ok {
+		return externalerrors.BadRequest(
+			fmt.Sprintf("%s is not an acceptable content type", contentType),
+		)
+	}
+
+	return nil
+}
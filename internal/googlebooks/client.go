@@ -0,0 +1,162 @@
+// Package googlebooks reads volumes from the Google Books API.
+package googlebooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"lms-backend/internal/config"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/view/googlebookview"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/httpretry"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://www.googleapis.com/books/v1"
+	defaultTimeout = 10 * time.Second
+)
+
+var errNotFound = errors.New("not found")
+
+// Client talks to the Google Books API.
+// HTTPClient and BaseURL can be swapped, for example to point at a local fake server.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	// APIKey defaults to config.GoogleAPIKey, which is only loaded at start up.
+	APIKey string
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	return &Client{
+		HTTPClient: httpClient,
+		BaseURL:    DefaultBaseURL,
+	}
+}
+
+func (c *Client) apiKey() string {
+	if c.APIKey != "" {
+		return c.APIKey
+	}
+
+	return config.GoogleAPIKey
+}
+
+func (c *Client) get(ctx context.Context, rawURL string) (*http.Response, error) {
+	res, err := httpretry.Get(ctx, c.HTTPClient, rawURL, httpretry.DefaultAttempts)
+	if err != nil {
+		return nil, externalerrors.UnprocessableEntity(
+			fmt.Sprintf("Failed to query Google Books API: %s", err.Error()),
+		)
+	}
+
+	return res, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, rawURL string, v interface{}) error {
+	res, err := c.get(ctx, rawURL)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case res.StatusCode != http.StatusOK:
+		return externalerrors.UnprocessableEntity(
+			fmt.Sprintf("Failed to query Google Books API: %s", string(body)),
+		)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return externalerrors.UnprocessableEntity(
+			fmt.Sprintf("Failed to unmarshal Google Books API response: %s", err.Error()),
+		)
+	}
+
+	return nil
+}
+
+func (c *Client) query() url.Values {
+	query := url.Values{}
+	if key := c.apiKey(); key != "" {
+		query.Set("key", key)
+	}
+
+	return query
+}
+
+// SearchVolumes runs a search using the Google Books query syntax, e.g. intitle:"go" isbn:123.
+func (c *Client) SearchVolumes(ctx context.Context, q string, maxResults int) (*googlebookview.ResponseView, error) {
+	query := c.query()
+	query.Set("q", q)
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	var response googlebookview.ResponseView
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/volumes?%s", c.BaseURL, query.Encode()), &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ReadVolume reads a single volume by its Google Books ID.
+func (c *Client) ReadVolume(ctx context.Context, volumeID string) (*googlebookview.ItemView, error) {
+	rawURL := fmt.Sprintf("%s/volumes/%s?%s", c.BaseURL, url.PathEscape(volumeID), c.query().Encode())
+
+	var item googlebookview.ItemView
+	if err := c.getJSON(ctx, rawURL, &item); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("Google Books volume %s not found", volumeID))
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// DownloadImage downloads a cover image and returns its content and content type.
+func (c *Client) DownloadImage(ctx context.Context, rawURL string) ([]byte, string, error) {
+	res, err := c.get(ctx, rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", externalerrors.UnprocessableEntity(
+			fmt.Sprintf("Failed to download cover: status %d", res.StatusCode),
+		)
+	}
+
+	// Read one byte past the limit so that oversized covers are rejected rather than truncated
+	data, err := io.ReadAll(io.LimitReader(res.Body, filestorage.MaxFileSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	contentType, _, err := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = http.DetectContentType(data)
+	}
+
+	return data, contentType, nil
+}
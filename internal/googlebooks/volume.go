@@ -0,0 +1,95 @@
+package googlebooks
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/googlebookview"
+	"lms-backend/util/sliceutil"
+	"strings"
+	"time"
+)
+
+// publishedDateLayouts are the precisions Google Books reports publication dates in.
+var publishedDateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// ToBook maps a volume onto a book. Names are trimmed and de-duplicated and
+// the ISBN-13 is preferred over the ISBN-10. The book is not validated.
+func ToBook(item *googlebookview.ItemView) *model.Book {
+	info := item.VolumeInfo
+
+	book := &model.Book{
+		Title:    strings.TrimSpace(info.Title),
+		ISBN:     isbnOf(info.IndustryIdentifiers),
+		Language: strings.ToLower(strings.TrimSpace(info.Language)),
+	}
+
+	if subtitle := strings.TrimSpace(info.Subtitle); subtitle != "" {
+		book.Title += ": " + subtitle
+	}
+
+	for _, layout := range publishedDateLayouts {
+		if t, err := time.Parse(layout, info.PublishedDate); err == nil {
+			book.PublicationDate = t
+			break
+		}
+	}
+
+	for _, name := range NormalizeNames(info.Authors) {
+		book.BookContributors = append(book.BookContributors, model.BookContributor{
+			Contributor: &model.Contributor{Name: name},
+			Role:        model.ContributorRoleAuthor,
+		})
+	}
+
+	for _, name := range NormalizeNames(info.Categories) {
+		book.Subjects = append(book.Subjects, model.Subject{Name: name})
+	}
+
+	// Publishers are sometimes returned wrapped in quotes
+	for _, name := range NormalizeNames([]string{strings.Trim(info.Publisher, `"`)}) {
+		book.Publishers = append(book.Publishers, model.Publisher{Name: name})
+	}
+
+	return book
+}
+
+func isbnOf(identifiers []googlebookview.IndustryIdentifierView) string {
+	var isbn string
+	for _, identifier := range identifiers {
+		if identifier.Type == "ISBN_13" {
+			return identifier.Identifier
+		}
+
+		if identifier.Type == "ISBN_10" {
+			isbn = identifier.Identifier
+		}
+	}
+
+	return isbn
+}
+
+// NormalizeNames trims the names and drops blank and repeated ones, ignoring case.
+func NormalizeNames(names []string) []string {
+	names = sliceutil.Map(names, func(name string) string {
+		return strings.Join(strings.Fields(name), " ")
+	})
+	names = sliceutil.Filter(names, func(name string) bool {
+		return name != ""
+	})
+
+	return sliceutil.UniqueBy(names, strings.ToLower)
+}
+
+// CoverURL returns the largest cover image of the volume, or "" if it has none.
+func CoverURL(item *googlebookview.ItemView) string {
+	links := item.VolumeInfo.ImageLinks
+	for _, link := range []string{
+		links.ExtraLarge, links.Large, links.Medium, links.Small, links.Thumbnail, links.SmallThumbnail,
+	} {
+		if link != "" {
+			// Links are served over plain http by default
+			return strings.Replace(link, "http://", "https://", 1)
+		}
+	}
+
+	return ""
+}
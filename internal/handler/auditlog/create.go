@@ -5,6 +5,8 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	audlog "lms-backend/internal/dataaccess/auditlog"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/middleware"
 	"lms-backend/internal/params/auditlogparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/auditlogpolicy"
@@ -39,6 +41,8 @@ func HandleCreate(c *fiber.Ctx) error {
 	}
 
 	log := params.ToModel(userID)
+	log.RequestID = middleware.GetRequestID(c)
+	log.IPAddress = c.IP()
 	tx, rollBackOrCommit := audit.Begin(c, fmt.Sprintf("User id - /'%d/' creating an entry in audit log", userID))
 	defer func() { rollBackOrCommit(err) }()
 
@@ -50,8 +54,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: auditlogview.ToDetailedView(log),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Entry in audit log created successfully: %s", log.Action,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.AuditLogCreated, log.Action)),
+		),
 	})
 }
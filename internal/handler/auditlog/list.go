@@ -4,10 +4,14 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/auditlog"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/auditlogpolicy"
 	"lms-backend/internal/view/auditlogview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,7 +34,18 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, auditlog.Filters(), auditlog.JoinUser)
+	dbFiltered, err := cq.Filter(db, auditlog.Filters(), auditlog.JoinUser)
+	if err != nil {
+		return err
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "audit-logs", cq.Sort(dbFiltered, auditlog.Sorters()), auditlog.ListDetailed, auditlogview.ExportColumns)
+	}
 
 	filteredCount, err := auditlog.Count(dbFiltered)
 	if err != nil {
@@ -44,6 +59,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(logs, func(a model.AuditLog) uint {
+		return a.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []*auditlogview.DetailedView{}
 	for _, log := range logs {
 		//nolint:gosec // loop does not modify struct
@@ -55,9 +77,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("auditlog listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.AuditLogListed)),
 		),
 	})
 }
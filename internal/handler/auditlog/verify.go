@@ -0,0 +1,39 @@
+package auditloghandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/auditlog"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/auditlogpolicy"
+	"lms-backend/internal/view/auditlogview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	verifyAuditLogAction = "verify audit log"
+)
+
+func HandleVerify(c *fiber.Ctx) error {
+	err := policy.Authorize(c, verifyAuditLogAction, auditlogpolicy.ReadPolicy())
+	if err != nil {
+		return err
+	}
+
+	verification, err := auditlog.Verify(database.GetDB())
+	if err != nil {
+		return err
+	}
+
+	message := api.SuccessMessage(i18n.T(c, i18n.AuditLogVerified, verification.Checked))
+	if !verification.Valid() {
+		message = api.ErrorMessage(i18n.T(c, i18n.AuditLogChainBroken, verification.BrokenID))
+	}
+
+	return c.JSON(api.Response{
+		Data:     auditlogview.ToVerificationView(verification),
+		Messages: api.Messages(message),
+	})
+}
@@ -1,10 +1,10 @@
 package auth
 
 import (
-	"fmt"
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/middleware"
 	"lms-backend/internal/params/userparams"
 	"lms-backend/internal/session"
@@ -41,6 +41,8 @@ func HandleSignIn(c *fiber.Ctx) error {
 	}
 
 	sess.Set(session.CookieKey, usr.ID)
+	sess.Set(session.LocaleKey, usr.Locale)
+	sess.Set(session.TimeZoneKey, usr.TimeZone)
 	err = sess.Save()
 	if err != nil {
 		return err
@@ -59,8 +61,7 @@ func HandleSignIn(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(api.Response{
 		Data: userview.ToLoginView(usr, abilities, csrfToken),
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"%s is logged in successfully", usr.Username,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.SignedIn, usr.Username)),
+		),
 	})
 }
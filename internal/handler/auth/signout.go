@@ -2,6 +2,7 @@ package auth
 
 import (
 	"lms-backend/internal/api"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/session"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,7 +11,7 @@ import (
 func HandleSignOut(c *fiber.Ctx) error {
 	if !session.HasSession(c) {
 		return c.Status(fiber.StatusOK).JSON(api.Response{
-			Messages: []api.Message{api.ErrorMessage("User is not logged in")},
+			Messages: []api.Message{api.ErrorMessage(i18n.T(c, i18n.NotLoggedIn))},
 		})
 	}
 
@@ -32,7 +33,7 @@ func HandleSignOut(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(api.Response{
 		Messages: api.Messages(
-			api.SilentMessage("User is logged out successfully"),
+			api.SilentMessage(i18n.T(c, i18n.SignedOut)),
 		),
 	})
 }
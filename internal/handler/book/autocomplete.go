@@ -1,10 +1,10 @@
 package bookhandler
 
 import (
-	"fmt"
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookview"
@@ -27,17 +27,16 @@ func HandleAutoComplete(c *fiber.Ctx) error {
 		return err
 	}
 
-	views := make([]*bookview.SimpleView, len(books))
+	views := make([]*bookview.AutoCompleteView, len(books))
 	for i, usr := range books {
 		//nolint:gosec // loop does not modify struct
-		views[i] = bookview.ToSimpleView(&usr)
+		views[i] = bookview.ToAutoCompleteView(&usr)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: views,
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Autocomplete for \"%s\"", value,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.Autocomplete, value)),
+		),
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/params/bookparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
@@ -46,8 +47,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: bookview.ToDetailedView(bookModel),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" added to library.", bookModel.Title,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookAdded, bookModel.Title)),
+		),
 	})
 }
@@ -6,6 +6,8 @@ import (
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookview"
@@ -27,6 +29,14 @@ func HandleDelete(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book id.", param))
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookID)
+	}
+	if err != nil {
+		return err
+	}
+
 	db := database.GetDB()
 
 	bookTitle, err := book.GetBookTitle(db, bookID)
@@ -39,7 +49,10 @@ func HandleDelete(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	bookModel, err := book.Delete(tx, bookID)
+	bookModel, err := book.Delete(tx, bookID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookID)
+	}
 	if err != nil {
 		return err
 	}
@@ -47,8 +60,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: bookview.ToView(bookModel),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" removed from library.", bookModel.Title,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookRemoved, bookModel.Title)),
+		),
 	})
 }
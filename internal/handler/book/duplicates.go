@@ -0,0 +1,37 @@
+package bookhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listDuplicateBooksAction = "list duplicate books"
+)
+
+func HandleListDuplicates(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listDuplicateBooksAction, bookpolicy.MergePolicy())
+	if err != nil {
+		return err
+	}
+
+	db := database.GetDB()
+	report, err := book.ListDuplicates(db)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: bookview.ToDuplicateReportView(report),
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.DuplicatesListed)),
+		),
+	})
+}
@@ -0,0 +1,75 @@
+package bookhandler
+
+import (
+	"bytes"
+	"fmt"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/marcmapping"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/marc"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	exportMARCAction = "export books as marc"
+)
+
+// HandleExportMARC exports the books matching the collection filters as a MARC21 (default) or MARCXML file.
+func HandleExportMARC(c *fiber.Ctx) error {
+	err := policy.Authorize(c, exportMARCAction, bookpolicy.ExportPolicy())
+	if err != nil {
+		return err
+	}
+
+	format := c.Query(marcFormatQueryKey, marc.FormatISO2709)
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	dbFiltered, err := cq.Filter(db, book.Filters())
+	if err != nil {
+		return err
+	}
+	dbSorted := cq.Sort(dbFiltered, book.Sorters())
+	books, err := book.ListForMARCExport(dbSorted)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	var writer marc.RecordWriter
+	var closeWriter func() error
+
+	switch format {
+	case marc.FormatISO2709:
+		writer = marc.NewWriter(&buf)
+		closeWriter = func() error { return nil }
+		c.Attachment("catalogue.mrc")
+		c.Set(fiber.HeaderContentType, "application/marc")
+	case marc.FormatXML:
+		xmlWriter := marc.NewXMLWriter(&buf)
+		writer, closeWriter = xmlWriter, xmlWriter.Close
+		c.Attachment("catalogue.xml")
+		c.Set(fiber.HeaderContentType, "application/marcxml+xml")
+	default:
+		return externalerrors.BadRequest(fmt.Sprintf("unsupported marc format %q", format))
+	}
+
+	for _, b := range books {
+		//nolint:gosec // loop does not modify struct
+		if err := writer.Write(marcmapping.FromBook(&b)); err != nil {
+			return err
+		}
+	}
+
+	if err := closeWriter(); err != nil {
+		return err
+	}
+
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,57 @@
+package bookhandler
+
+import (
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/fieldset"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	bookResourceName  = "book"
+	availabilityField = "availability"
+)
+
+var bookFields = []string{
+	"id", "title", "author", "isbn", "publisher", "publication_date", "genre", "language",
+	book.FieldContributors, book.FieldSubjects, book.FieldPublishers,
+}
+
+func bookIncludes() map[string]fieldset.Include {
+	return map[string]fieldset.Include{
+		book.IncludeCopies:    {Key: "book_copies"},
+		book.IncludeThumbnail: {Key: "thumbnail_url"},
+		book.IncludeBookmarks: {Key: "bookmarks", Policy: bookpolicy.IncludeBookmarksPolicy()},
+	}
+}
+
+// readResource is the book as read on its own, with its copies by default.
+func readResource() *fieldset.Resource {
+	return &fieldset.Resource{
+		Name:            bookResourceName,
+		Fields:          bookFields,
+		Includes:        bookIncludes(),
+		DefaultIncludes: []string{book.IncludeCopies, book.IncludeThumbnail},
+	}
+}
+
+// listResource is the book as listed, with its availability and search match but not its copies by default.
+func listResource() *fieldset.Resource {
+	return &fieldset.Resource{
+		Name:            bookResourceName,
+		Fields:          append([]string{availabilityField, "rank", "highlight"}, bookFields...),
+		Includes:        bookIncludes(),
+		DefaultIncludes: []string{book.IncludeThumbnail},
+	}
+}
+
+// selectionUserID is the current user if their bookmarks are included, as only they may be loaded.
+func selectionUserID(c *fiber.Ctx, selection *fieldset.Selection) (int64, error) {
+	if !selection.HasInclude(book.IncludeBookmarks) {
+		return 0, nil
+	}
+
+	return session.GetLoginSession(c)
+}
@@ -0,0 +1,111 @@
+package bookhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/bookimporter"
+	"lms-backend/internal/dataaccess/bookimport"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/params/bookimportparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/session"
+	"lms-backend/internal/view/bookimportview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	importBookAction = "import books from spreadsheet"
+)
+
+// HandleImport starts importing the rows of an uploaded CSV or XLSX file in the background.
+//
+// The import is returned right away and its progress can be polled.
+// With dry_run=true the rows are only validated.
+func HandleImport(c *fiber.Ctx) error {
+	err := policy.Authorize(c, importBookAction, bookpolicy.ImportPolicy())
+	if err != nil {
+		return err
+	}
+
+	userID, err := session.GetLoginSession(c)
+	if err != nil {
+		return err
+	}
+
+	var params bookimportparams.ImportParams
+	if err := c.BodyParser(&params); err != nil {
+		return err
+	}
+
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	mapping, err := params.ToMapping()
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := filestorage.ReadFileFromRequest(c, UploadField)
+	if err != nil {
+		return err
+	}
+
+	rows, err := bookimporter.ReadSpreadsheet(fileHeader)
+	if err != nil {
+		return err
+	}
+
+	db := database.GetDB()
+
+	branchName, err := branch.GetBranchName(db, params.DefaultBranchID())
+	if err != nil {
+		return err
+	}
+
+	dryRun := c.QueryBool(dryRunQueryKey, false)
+	job, err := bookimporter.NewJob(rows, mapping, uint(params.DefaultBranchID()), dryRun)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(c, fmt.Sprintf(
+		"Importing %d rows from %s into %s", len(job.Rows), fileHeader.Filename, branchName,
+	))
+
+	bookImport, err := bookimport.Create(tx, &model.BookImport{
+		UserID:    uint(userID),
+		FileName:  fileHeader.Filename,
+		Status:    model.BookImportStatusRunning,
+		DryRun:    dryRun,
+		TotalRows: len(job.Rows),
+	})
+	// Committed right away, the background run records its progress on the import
+	rollBackOrCommit(err)
+	if err != nil {
+		return err
+	}
+
+	// Viewed before the run starts updating the counters
+	view := bookimportview.ToView(bookImport)
+	go job.Run(bookImport)
+
+	message := i18n.T(c, i18n.RowsImporting, len(job.Rows), fileHeader.Filename)
+	if dryRun {
+		message = i18n.T(c, i18n.RowsValidating, len(job.Rows), fileHeader.Filename)
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(api.Response{
+		Data: view,
+		Messages: api.Messages(
+			api.SuccessMessage(message),
+		),
+	})
+}
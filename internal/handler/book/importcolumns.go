@@ -0,0 +1,45 @@
+package bookhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/bookimporter"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookimportview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	readImportColumnsAction = "read columns of book import file"
+)
+
+const importSampleSize = 5
+
+// HandleReadImportColumns reads the header row of an uploaded CSV or XLSX file and suggests
+// which book field each column maps to, so that the mapping can be confirmed before importing.
+func HandleReadImportColumns(c *fiber.Ctx) error {
+	err := policy.Authorize(c, readImportColumnsAction, bookpolicy.ImportPolicy())
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := filestorage.ReadFileFromRequest(c, UploadField)
+	if err != nil {
+		return err
+	}
+
+	rows, err := bookimporter.ReadSpreadsheet(fileHeader)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: bookimportview.ToColumnsView(rows, importSampleSize),
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.ImportColumnsRead)),
+		),
+	})
+}
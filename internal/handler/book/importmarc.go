@@ -0,0 +1,92 @@
+package bookhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookview"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/marc"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	importMARCAction = "import books from marc"
+)
+
+const (
+	marcFormatQueryKey = "format"
+	dryRunQueryKey     = "dry_run"
+)
+
+// HandleImportMARC imports the records of an uploaded MARC21 or MARCXML file.
+//
+// The format is detected from the file unless given in the query.
+// With dry_run=true the records are only mapped and checked.
+func HandleImportMARC(c *fiber.Ctx) error {
+	err := policy.Authorize(c, importMARCAction, bookpolicy.ImportPolicy())
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := filestorage.ReadFileFromRequest(c, UploadField)
+	if err != nil {
+		return err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader, err := marc.NewRecordReader(file, c.Query(marcFormatQueryKey))
+	if err != nil {
+		return externalerrors.BadRequest(err.Error())
+	}
+
+	records, err := marc.ReadAll(reader)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("could not read marc file: %s", err))
+	}
+
+	dryRun := c.QueryBool(dryRunQueryKey, false)
+
+	var report *viewmodel.MARCImportReportViewModel
+	if dryRun {
+		report, err = book.ImportMARC(database.GetDB(), records, true)
+		if err != nil {
+			return err
+		}
+	} else {
+		tx, rollBackOrCommit := audit.Begin(
+			c, fmt.Sprintf("Importing %d MARC records from %s", len(records), fileHeader.Filename),
+		)
+		defer func() { rollBackOrCommit(err) }()
+
+		report, err = book.ImportMARC(tx, records, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	message := i18n.T(c, i18n.RecordsImported, report.Accepted, len(records))
+	if dryRun {
+		message = i18n.T(c, i18n.RecordsImportable, report.Accepted, len(records))
+	}
+
+	return c.JSON(api.Response{
+		Data: bookview.ToMARCImportReportView(report),
+		Messages: api.Messages(
+			api.SuccessMessage(message),
+		),
+	})
+}
@@ -4,20 +4,59 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/fieldset"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookview"
+	"lms-backend/internal/viewmodel"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
+const (
+	searchQueryKey = "q"
+	facetsQueryKey = "facets"
+)
+
+// HandleList lists books matching the filters.
+//
+// With q the books are searched by title, author, genre and publisher. Unless another sort is
+// requested, the best matches come first and each book comes with its matched words highlighted.
+// Pages by cursor cannot follow the rank, so they are sorted by the requested sort or ID instead.
+//
+// Each book comes with its copies counted by status. Sorting by available puts the books with the
+// most copies on the shelf first.
+//
+// fields[book] limits the fields shown, and include replaces the default thumbnail with any of
+// copies, thumbnail and the current user's bookmarks. Only the requested associations are loaded.
+//
+// With format=csv or xlsx, or an Accept header asking for either, every matching book is
+// exported as a file instead.
+//
+// With facets=true the meta also counts the filtered books by genre, language, publication
+// decade, branch and availability.
 func HandleList(c *fiber.Ctx) error {
 	err := policy.Authorize(c, readBookAction, bookpolicy.ListPolicy())
 	if err != nil {
 		return err
 	}
 
+	selection, err := listResource().Parse(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := selectionUserID(c, selection)
+	if err != nil {
+		return err
+	}
+
 	cq := collection.GetCollectionQueryFromParam(c)
 	db := database.GetDB()
 
@@ -26,34 +65,150 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, book.Filters())
+	dbFiltered, err := cq.Filter(db, book.Filters())
+	if err != nil {
+		return err
+	}
+
+	searchQuery := c.Query(searchQueryKey)
+	if searchQuery != "" {
+		dbFiltered = dbFiltered.Scopes(book.Search(searchQuery))
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "books", cq.Sort(dbFiltered, book.Sorters()), book.List, bookview.ExportColumns)
+	}
 
 	filteredCount, err := book.Count(dbFiltered)
 	if err != nil {
 		return err
 	}
 
+	meta := api.Meta{
+		TotalCount:    totalCount,
+		FilteredCount: filteredCount,
+	}
+
+	if c.QueryBool(facetsQueryKey, false) {
+		facets, err := book.ListFacets(dbFiltered)
+		if err != nil {
+			return err
+		}
+
+		facetViews := []bookview.FacetView{}
+		for _, f := range facets {
+			//nolint:gosec // loop does not modify struct
+			facetViews = append(facetViews, *bookview.ToFacetView(&f))
+		}
+		meta.Facets = facetViews
+	}
+
 	dbSorted := cq.Sort(dbFiltered, book.Sorters())
+	if searchQuery != "" && !cq.IsCursorMode() {
+		dbSorted = dbSorted.Scopes(book.OrderByRank)
+	}
 	dbPaginated := cq.Paginate(dbSorted)
-	books, err := book.ListDetailed(dbPaginated)
+	books, err := book.List(dbPaginated.Scopes(book.PreloadSelection(selection, userID)))
 	if err != nil {
 		return err
 	}
 
-	var view = []bookview.DetailedView{}
-	for _, w := range books {
+	bookIDs := sliceutil.Map(books, func(b model.Book) uint {
+		return b.ID
+	})
+
+	availabilities := map[uint]viewmodel.BookAvailabilityViewModel{}
+	if selection.HasField(availabilityField) {
+		availabilities, err = book.ListAvailability(db, bookIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	meta.NextCursor, meta.PrevCursor, err = cq.Cursors(dbSorted, bookIDs)
+	if err != nil {
+		return err
+	}
+
+	if searchQuery != "" {
+		return listSearchResults(c, db, searchQuery, books, bookIDs, availabilities, selection, meta)
+	}
+
+	var views = []bookview.ListView{}
+	for _, b := range books {
+		//nolint:gosec // loop does not modify struct
+		views = append(views, *bookview.ToListView(&b, availabilityOf(availabilities, b.ID)))
+	}
+
+	view, err := fieldset.ApplyAll(selection, views)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: meta,
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.BooksListed)),
+		),
+	})
+}
+
+func listSearchResults(
+	c *fiber.Ctx,
+	db *gorm.DB,
+	searchQuery string,
+	books []model.Book,
+	bookIDs []uint,
+	availabilities map[uint]viewmodel.BookAvailabilityViewModel,
+	selection *fieldset.Selection,
+	meta api.Meta,
+) error {
+	highlights := map[uint]viewmodel.BookSearchHighlightViewModel{}
+	if len(bookIDs) > 0 {
+		var err error
+		highlights, err = book.ListSearchHighlights(db, searchQuery, bookIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	var views = []bookview.SearchResultView{}
+	for _, b := range books {
+		var highlight *viewmodel.BookSearchHighlightViewModel
+		if h, ok := highlights[b.ID]; ok {
+			highlight = &h
+		}
+
 		//nolint:gosec // loop does not modify struct
-		view = append(view, *bookview.ToDetailedView(&w))
+		views = append(views, *bookview.ToSearchResultView(&b, availabilityOf(availabilities, b.ID), highlight))
+	}
+
+	view, err := fieldset.ApplyAll(selection, views)
+	if err != nil {
+		return err
 	}
 
 	return c.JSON(api.Response{
 		Data: view,
-		Meta: api.Meta{
-			TotalCount:    totalCount,
-			FilteredCount: filteredCount,
-		},
+		Meta: meta,
 		Messages: api.Messages(
-			api.SilentMessage("books listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.BooksSearched)),
 		),
 	})
 }
+
+func availabilityOf(
+	availabilities map[uint]viewmodel.BookAvailabilityViewModel, bookID uint,
+) *viewmodel.BookAvailabilityViewModel {
+	availability, ok := availabilities[bookID]
+	if !ok {
+		return nil
+	}
+
+	return &availability
+}
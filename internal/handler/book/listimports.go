@@ -0,0 +1,78 @@
+package bookhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/bookimport"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookimportview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listBookImportAction = "list book imports"
+)
+
+func HandleListImports(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listBookImportAction, bookpolicy.ImportPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := bookimport.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, bookimport.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := bookimport.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, bookimport.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	bookImports, err := bookimport.List(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(bookImports, func(b model.BookImport) uint {
+		return b.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []bookimportview.View{}
+	for _, b := range bookImports {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *bookimportview.ToView(&b))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.BookImportsListed)),
+		),
+	})
+}
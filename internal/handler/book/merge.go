@@ -0,0 +1,68 @@
+package bookhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/params/bookparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	mergeBookAction = "merge books"
+)
+
+func HandleMerge(c *fiber.Ctx) error {
+	err := policy.Authorize(c, mergeBookAction, bookpolicy.MergePolicy())
+	if err != nil {
+		return err
+	}
+
+	param := c.Params("book_id")
+	bookID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book id.", param))
+	}
+
+	var mergeParams bookparams.MergeParams
+	if err := c.BodyParser(&mergeParams); err != nil {
+		return err
+	}
+
+	if err := mergeParams.Validate(bookID); err != nil {
+		return err
+	}
+
+	db := database.GetDB()
+
+	bookTitle, err := book.GetBookTitle(db, bookID)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Merging books %v into \"%s\"", mergeParams.SourceIDs, bookTitle),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	bookModel, err := book.Merge(tx, bookID, mergeParams.SourceIDs)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: bookview.ToDetailedView(bookModel),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.BooksMerged, len(mergeParams.SourceIDs), bookModel.Title)),
+		),
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookview"
@@ -32,7 +33,7 @@ func HandlePopular(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: view,
 		Messages: api.Messages(
-			api.SilentMessage("Popular books listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.PopularListed)),
 		),
 	})
 }
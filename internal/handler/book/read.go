@@ -5,6 +5,7 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookview"
@@ -18,6 +19,10 @@ const (
 	readBookAction = "read book"
 )
 
+// HandleRead reads a book with its copies and thumbnail.
+//
+// fields[book] limits the fields shown, and include replaces the default associations
+// with any of copies, thumbnail and the current user's bookmarks.
 func HandleRead(c *fiber.Ctx) error {
 	err := policy.Authorize(c, readBookAction, bookpolicy.ReadPolicy())
 	if err != nil {
@@ -30,18 +35,44 @@ func HandleRead(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book id.", param))
 	}
 
+	selection, err := readResource().Parse(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := selectionUserID(c, selection)
+	if err != nil {
+		return err
+	}
+
 	db := database.GetDB()
 
-	bookModel, err := book.ReadDetailed(db, bookID)
+	bookModel, err := book.Read(db.Scopes(book.PreloadSelection(selection, userID)), bookID)
 	if err != nil {
 		return err
 	}
 
+	view, err := selection.Apply(bookview.ToDetailedView(bookModel))
+	if err != nil {
+		return err
+	}
+
+	api.SetETag(c, bookModel.Version)
 	return c.JSON(api.Response{
-		Data: bookview.ToDetailedView(bookModel),
+		Data: view,
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"\"%s\" retrieved.", bookModel.Title,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.BookRetrieved, bookModel.Title)),
+		),
 	})
 }
+
+// preconditionFailed responds to a change made from an outdated version of the book
+// with the book as it is now.
+func preconditionFailed(c *fiber.Ctx, err error, bookID int64) error {
+	bookModel, readErr := book.ReadDetailed(database.GetDB(), bookID)
+	if readErr != nil {
+		return readErr
+	}
+
+	return api.PreconditionFailed(c, err, bookModel.Version, bookview.ToDetailedView(bookModel))
+}
@@ -0,0 +1,46 @@
+package bookhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/bookimport"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookimportview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	readBookImportAction = "read book import"
+)
+
+// HandleReadImport reports the progress of an import and the errors of its rows so far.
+func HandleReadImport(c *fiber.Ctx) error {
+	err := policy.Authorize(c, readBookImportAction, bookpolicy.ImportPolicy())
+	if err != nil {
+		return err
+	}
+
+	param := c.Params("import_id")
+	importID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid import id.", param))
+	}
+
+	bookImport, err := bookimport.ReadDetailed(database.GetDB(), importID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: bookimportview.ToDetailedView(bookImport),
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.BookImportRead)),
+		),
+	})
+}
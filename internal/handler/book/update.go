@@ -5,6 +5,8 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/params/bookparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
@@ -40,22 +42,34 @@ func HandleUpdate(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookID)
+	}
+	if err != nil {
+		return err
+	}
+
 	tx, rollBackOrCommit := audit.Begin(
 		c, fmt.Sprintf("Updating existing book in library: %s.", bookParams.Title),
 	)
 	defer func() { rollBackOrCommit(err) }()
 
 	bookModel := bookParams.ToModel()
+	bookModel.Version = version
 	bookModel, err = book.Update(tx, bookModel)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookID)
+	}
 	if err != nil {
 		return err
 	}
 
+	api.SetETag(c, bookModel.Version)
 	return c.JSON(api.Response{
 		Data: bookview.ToView(bookModel),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" modified successfully.", bookModel.Title,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookModified, bookModel.Title)),
+		),
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
 	"lms-backend/internal/filestorage"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
@@ -87,9 +88,7 @@ func HandleUpdateThumbnail(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: bookview.ToDetailedView(bookModel),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" uploaded successfully for \"%s\".", fileName, bookTitle,
-			)),
+			api.SuccessMessage(i18n.T(c, i18n.ThumbnailUploaded, fileName, bookTitle)),
 		),
 	})
 }
@@ -0,0 +1,31 @@
+package bookcopyhandler
+
+import (
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/database"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HandleResolveBarcode serves /bookcopy/barcode/:barcode/... by looking up the
+// copy with the accession number and redirecting to /bookcopy/:bookcopy_id/...
+//
+// Every endpoint under /bookcopy/:bookcopy_id is thereby also available by barcode.
+// The redirect keeps the method and body of the request, so that it is only handled,
+// rate limited and logged once the copy is known.
+func HandleResolveBarcode(c *fiber.Ctx) error {
+	barcode := c.Params("barcode")
+
+	db := database.GetDB()
+	bookCopy, err := bookcopy.ReadByAccessionNumber(db, barcode)
+	if err != nil {
+		return err
+	}
+
+	location := strings.Replace(
+		c.OriginalURL(), "/barcode/"+barcode, "/"+strconv.FormatUint(uint64(bookCopy.ID), 10), 1,
+	)
+	return c.Redirect(location, fiber.StatusTemporaryRedirect)
+}
@@ -0,0 +1,136 @@
+package bookcopyhandler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"lms-backend/internal/database"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeCopies is a database in which every query returns the copies.
+type fakeCopies struct {
+	rows [][]driver.Value
+}
+
+func (db *fakeCopies) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeCopies) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeCopies
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{rows: c.db.rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id", "accession_number"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+// useFakeCopies makes database.GetDB return a connection to db for the duration of the test.
+func useFakeCopies(t *testing.T, db *fakeCopies) {
+	t.Helper()
+
+	gormDB, err := gorm.Open(
+		postgres.New(postgres.Config{Conn: sql.OpenDB(db)}),
+		&gorm.Config{Logger: logger.Discard},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := database.DB
+	database.DB = gormDB
+	t.Cleanup(func() { database.DB = previous })
+}
+
+func TestHandleResolveBarcode(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		rows         [][]driver.Value
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:         "copy",
+			method:       fiber.MethodGet,
+			target:       "/bookcopy/barcode/LMS00000042",
+			rows:         [][]driver.Value{{int64(42), "LMS00000042"}},
+			wantStatus:   fiber.StatusTemporaryRedirect,
+			wantLocation: "/bookcopy/42",
+		},
+		{
+			name:         "endpoint of the copy with a query",
+			method:       fiber.MethodPatch,
+			target:       "/bookcopy/barcode/LMS00000042/loan/return?lang=ms",
+			rows:         [][]driver.Value{{int64(42), "LMS00000042"}},
+			wantStatus:   fiber.StatusTemporaryRedirect,
+			wantLocation: "/bookcopy/42/loan/return?lang=ms",
+		},
+		{
+			name:       "unknown barcode",
+			method:     fiber.MethodGet,
+			target:     "/bookcopy/barcode/LMS99999999",
+			wantStatus: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeCopies(t, &fakeCopies{rows: tt.rows})
+
+			handled := 0
+			app := fiber.New()
+			app.Use("/bookcopy/barcode/:barcode", HandleResolveBarcode)
+			app.All("/bookcopy/:bookcopy_id/*", func(c *fiber.Ctx) error {
+				handled++
+				return nil
+			})
+
+			res, err := app.Test(httptest.NewRequest(tt.method, tt.target, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			if got := res.Header.Get(fiber.HeaderLocation); got != tt.wantLocation {
+				t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+			}
+			// The copy's endpoint is only handled once the client follows the redirect
+			if handled != 0 {
+				t.Errorf("copy endpoint handled %d times, want 0", handled)
+			}
+		})
+	}
+}
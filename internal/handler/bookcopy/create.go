@@ -7,6 +7,8 @@ import (
 	"lms-backend/internal/dataaccess/book"
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/sharedview"
@@ -19,10 +21,13 @@ import (
 const (
 	createBookcopyAction = "create book copy"
 	countQueryKey        = "count"
+	branchQueryKey       = "branch_id"
 )
 
 func HandleCreate(c *fiber.Ctx) error {
-	err := policy.Authorize(c, createBookcopyAction, bookpolicy.CreatePolicy())
+	branchID := c.QueryInt(branchQueryKey, model.DefaultBranchID)
+
+	err := policy.Authorize(c, createBookcopyAction, bookpolicy.CreateCopyPolicy(int64(branchID)))
 	if err != nil {
 		return err
 	}
@@ -46,7 +51,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	copies, err := bookcopy.CreateMultiple(tx, bookID, int64(count))
+	copies, err := bookcopy.CreateMultiple(tx, bookID, int64(branchID), int64(count))
 	if err != nil {
 		return err
 	}
@@ -60,7 +65,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: view,
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf("%d copies of %s created successfully", count, title)),
+			api.SuccessMessage(i18n.T(c, i18n.BookCopiesCreated, count, title)),
 		),
 	})
 }
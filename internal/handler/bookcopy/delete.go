@@ -5,6 +5,9 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookpolicy"
 	"lms-backend/internal/view/bookcopyview"
@@ -19,15 +22,28 @@ const (
 )
 
 func HandleDelete(c *fiber.Ctx) error {
-	err := policy.Authorize(c, deleteBookcopyAction, bookpolicy.DeletePolicy())
+	param := c.Params("bookcopy_id")
+	bookcopyID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book id.", param))
+	}
+
+	current, err := bookcopy.Read(database.GetDB(), bookcopyID)
 	if err != nil {
 		return err
 	}
 
-	param := c.Params("bookcopy_id")
-	bookcopyID, err := strconv.ParseInt(param, 10, 64)
+	err = policy.Authorize(c, deleteBookcopyAction, bookpolicy.DeleteCopyPolicy(int64(current.HomeBranchID)))
 	if err != nil {
-		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book id.", param))
+		return err
+	}
+
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookcopyID)
+	}
+	if err != nil {
+		return err
 	}
 
 	tx, rollBackOrCommit := audit.Begin(
@@ -35,13 +51,16 @@ func HandleDelete(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	bookCopy, err := bookcopy.Delete(tx, bookcopyID)
+	bookCopy, err := bookcopy.Delete(tx, bookcopyID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookcopyID)
+	}
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(api.Response{
 		Data:     bookcopyview.ToDetailedView(bookCopy),
-		Messages: api.Messages(api.SilentMessage("book copy deleted successfully")),
+		Messages: api.Messages(api.SilentMessage(i18n.T(c, i18n.BookCopyDeleted))),
 	})
 }
@@ -0,0 +1,58 @@
+package bookcopyhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookcopyview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	readBookcopyAction = "read book copy"
+)
+
+func HandleRead(c *fiber.Ctx) error {
+	err := policy.Authorize(c, readBookcopyAction, bookpolicy.ReadPolicy())
+	if err != nil {
+		return err
+	}
+
+	param := c.Params("bookcopy_id")
+	bookcopyID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book copy id.", param))
+	}
+
+	db := database.GetDB()
+	bookCopy, err := bookcopy.ReadWithBook(db, bookcopyID)
+	if err != nil {
+		return err
+	}
+
+	api.SetETag(c, bookCopy.Version)
+	return c.JSON(api.Response{
+		Data: bookcopyview.ToDetailedView(bookCopy),
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.BookCopyRead)),
+		),
+	})
+}
+
+// preconditionFailed responds to a change made from an outdated version of the copy
+// with the copy as it is now.
+func preconditionFailed(c *fiber.Ctx, err error, bookcopyID int64) error {
+	bookCopy, readErr := bookcopy.ReadWithBook(database.GetDB(), bookcopyID)
+	if readErr != nil {
+		return readErr
+	}
+
+	return api.PreconditionFailed(c, err, bookCopy.Version, bookcopyview.ToDetailedView(bookCopy))
+}
@@ -0,0 +1,78 @@
+package bookcopyhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
+	"lms-backend/internal/params/bookcopyparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookcopyview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	updateBookcopyAction = "update book copy"
+)
+
+func HandleUpdate(c *fiber.Ctx) error {
+	param := c.Params("bookcopy_id")
+	bookcopyID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book copy id.", param))
+	}
+
+	current, err := bookcopy.Read(database.GetDB(), bookcopyID)
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, updateBookcopyAction, bookpolicy.UpdateCopyPolicy(int64(current.HomeBranchID)))
+	if err != nil {
+		return err
+	}
+
+	var params bookcopyparams.UpdateParams
+	if err := c.BodyParser(&params); err != nil {
+		return err
+	}
+
+	if err := params.Validate(bookcopyID); err != nil {
+		return err
+	}
+
+	version, err := api.IfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Updating details of book copy %s", params.AccessionNumber),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	bookCopy := params.ToModel()
+	bookCopy.Version = version
+	bookCopy, err = bookcopy.UpdateDetails(tx, bookCopy)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, bookcopyID)
+	}
+	if err != nil {
+		return err
+	}
+
+	api.SetETag(c, bookCopy.Version)
+	return c.JSON(api.Response{
+		Data: bookcopyview.ToDetailedView(bookCopy),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.BookCopyModified, bookCopy.AccessionNumber)),
+		),
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"lms-backend/internal/dataaccess/bookmark"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookmarkpolicy"
@@ -67,7 +68,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: bookmarkview.ToDetailedView(b),
 		Messages: api.Messages(
-			api.SilentMessage("bookmark created successfully"),
+			api.SilentMessage(i18n.T(c, i18n.BookmarkCreated)),
 		),
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"lms-backend/internal/dataaccess/bookmark"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookmarkpolicy"
 	"lms-backend/internal/session"
@@ -58,7 +59,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: bookmarkview.ToDetailedView(b),
 		Messages: api.Messages(
-			api.SilentMessage("bookmark deleted successfully"),
+			api.SilentMessage(i18n.T(c, i18n.BookmarkDeleted)),
 		),
 	})
 }
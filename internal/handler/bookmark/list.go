@@ -4,10 +4,13 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/bookmark"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/bookmarkpolicy"
 	"lms-backend/internal/view/bookmarkview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,7 +33,10 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, bookmark.Filters())
+	dbFiltered, err := cq.Filter(db, bookmark.Filters())
+	if err != nil {
+		return err
+	}
 
 	filteredCount, err := bookmark.Count(dbFiltered)
 	if err != nil {
@@ -45,6 +51,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(fns, func(b model.Bookmark) uint {
+		return b.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []bookmarkview.DetailedView{}
 	for _, f := range fns {
 		//nolint:gosec // loop does not modify struct
@@ -56,9 +69,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("bookmarks listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.BookmarksListed)),
 		),
 	})
 }
@@ -0,0 +1,53 @@
+package branchhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/params/branchparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/branchpolicy"
+	"lms-backend/internal/view/branchview"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	createBranchAction = "create branch"
+)
+
+func HandleCreate(c *fiber.Ctx) error {
+	err := policy.Authorize(c, createBranchAction, branchpolicy.CreatePolicy())
+	if err != nil {
+		return err
+	}
+
+	var branchParams branchparams.CreateParams
+	if err := c.BodyParser(&branchParams); err != nil {
+		return err
+	}
+
+	if err := branchParams.Validate(); err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Adding a new branch: %s.", branchParams.Name),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	branchModel := branchParams.ToModel()
+	branchModel, err = branch.Create(tx, branchModel)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(api.Response{
+		Data: branchview.ToView(branchModel),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.BranchCreated, branchModel.Name)),
+		),
+	})
+}
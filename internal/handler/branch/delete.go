@@ -0,0 +1,58 @@
+package branchhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/branchpolicy"
+	"lms-backend/internal/view/branchview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	deleteBranchAction = "delete branch"
+)
+
+func HandleDelete(c *fiber.Ctx) error {
+	err := policy.Authorize(c, deleteBranchAction, branchpolicy.DeletePolicy())
+	if err != nil {
+		return err
+	}
+
+	param := c.Params("branch_id")
+	branchID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid branch id.", param))
+	}
+
+	db := database.GetDB()
+
+	branchName, err := branch.GetBranchName(db, branchID)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Deleting branch \"%s\"", branchName),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	branchModel, err := branch.Delete(tx, branchID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: branchview.ToView(branchModel),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.BranchRemoved, branchModel.Name)),
+		),
+	})
+}
@@ -0,0 +1,78 @@
+package branchhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/branchpolicy"
+	"lms-backend/internal/view/branchview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listBranchAction = "list branches"
+)
+
+func HandleList(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listBranchAction, branchpolicy.ListPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := branch.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, branch.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := branch.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, branch.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	branches, err := branch.List(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(branches, func(b model.Branch) uint {
+		return b.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []branchview.View{}
+	for _, b := range branches {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *branchview.ToView(&b))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.BranchesListed)),
+		),
+	})
+}
@@ -0,0 +1,61 @@
+package branchhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/params/branchparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/branchpolicy"
+	"lms-backend/internal/view/branchview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	updateBranchAction = "update branch"
+)
+
+func HandleUpdate(c *fiber.Ctx) error {
+	err := policy.Authorize(c, updateBranchAction, branchpolicy.UpdatePolicy())
+	if err != nil {
+		return err
+	}
+
+	param := c.Params("branch_id")
+	branchID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid branch id.", param))
+	}
+
+	var branchParams branchparams.UpdateParams
+	if err := c.BodyParser(&branchParams); err != nil {
+		return err
+	}
+
+	if err := branchParams.Validate(branchID); err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Updating existing branch: %s.", branchParams.Name),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	branchModel := branchParams.ToModel()
+	branchModel, err = branch.Update(tx, branchModel)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: branchview.ToView(branchModel),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.BranchModified, branchModel.Name)),
+		),
+	})
+}
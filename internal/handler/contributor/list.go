@@ -0,0 +1,78 @@
+package contributorhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/contributor"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/contributorview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listContributorAction = "list contributors"
+)
+
+func HandleList(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listContributorAction, bookpolicy.ListPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := contributor.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, contributor.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := contributor.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, contributor.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	contributors, err := contributor.List(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(contributors, func(c model.Contributor) uint {
+		return c.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []contributorview.View{}
+	for _, ct := range contributors {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *contributorview.ToView(&ct))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.ContributorsListed)),
+		),
+	})
+}
@@ -0,0 +1,25 @@
+package docshandler
+
+import (
+	"lms-backend/internal/apidocs"
+	"lms-backend/pkg/openapi"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	document     *openapi.Document
+	documentOnce sync.Once
+)
+
+// HandleOpenAPI serves the OpenAPI document of the routes of the app.
+//
+// The routes do not change once the app is listening, so the document is generated once.
+func HandleOpenAPI(c *fiber.Ctx) error {
+	documentOnce.Do(func() {
+		document, _ = apidocs.Generate(c.App().GetRoutes(true))
+	})
+
+	return c.JSON(document)
+}
@@ -0,0 +1,37 @@
+package docshandler
+
+import (
+	"lms-backend/internal/apidocs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Renders the OpenAPI document served next to it with Swagger UI
+const docsHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>` + apidocs.Title + `</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+	<script>
+		window.onload = () => {
+			window.ui = SwaggerUIBundle({
+				url: "` + apidocs.BasePath + `/openapi.json",
+				dom_id: "#swagger-ui",
+				withCredentials: true,
+			});
+		};
+	</script>
+</body>
+</html>`
+
+// HandleDocs serves a page to browse and try out the API.
+func HandleDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(docsHTML)
+}
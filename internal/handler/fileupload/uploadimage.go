@@ -7,6 +7,7 @@ import (
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
 	"lms-backend/internal/filestorage"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/model"
 	"lms-backend/internal/session"
 	"lms-backend/internal/view/sharedview"
@@ -66,9 +67,7 @@ func HandleUploadImage(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: sharedview.ToFileUploadView(uploadModel),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" uploaded successfully.", uploadModel.FileName,
-			)),
+			api.SuccessMessage(i18n.T(c, i18n.FileUploaded, uploadModel.FileName)),
 		),
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"lms-backend/internal/dataaccess/fine"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/finepolicy"
 	"lms-backend/internal/session"
@@ -57,8 +58,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: fineview.ToDetailedView(fn),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Fine id - \"%d\" has been deleted", fineID,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.FineDeleted, fineID)),
+		),
 	})
 }
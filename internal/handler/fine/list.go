@@ -4,10 +4,14 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/fine"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/finepolicy"
 	"lms-backend/internal/view/fineview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,7 +34,18 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, fine.Filters(), fine.JoinLoan)
+	dbFiltered, err := cq.Filter(db, fine.Filters(), fine.JoinLoan)
+	if err != nil {
+		return err
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "fines", cq.Sort(dbFiltered, fine.Sorters()), fine.ListDetailed, fineview.ExportColumns)
+	}
 
 	filteredCount, err := fine.Count(dbFiltered)
 	if err != nil {
@@ -45,6 +60,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(fns, func(f model.Fine) uint {
+		return f.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []*fineview.DetailedView{}
 	for _, f := range fns {
 		//nolint:gosec // loop does not modify struct
@@ -56,9 +78,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("fines listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.FinesListed)),
 		),
 	})
 }
@@ -1,5 +0,0 @@
-package googlebook
-
-const (
-	googleAPIBaseURL = "https://www.googleapis.com/books/v1"
-)
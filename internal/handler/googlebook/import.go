@@ -0,0 +1,129 @@
+package googlebook
+
+import (
+	"context"
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/googlebooks"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/bookview"
+	"lms-backend/pkg/error/externalerrors"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	importVolumeAction = "import book from google books"
+)
+
+// client is used to read volumes and download covers.
+var client = googlebooks.NewClient(nil)
+
+var volumeIDReg = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// HandleImport creates a book with one copy from a Google Books volume and
+// downloads its cover as the thumbnail. A missing cover does not stop the import.
+func HandleImport(c *fiber.Ctx) error {
+	err := policy.Authorize(c, importVolumeAction, bookpolicy.CreatePolicy())
+	if err != nil {
+		return err
+	}
+
+	volumeID := c.Params("volume_id")
+	if !volumeIDReg.MatchString(volumeID) {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid volume id.", volumeID))
+	}
+
+	volume, err := fetchVolume(c.UserContext(), client, volumeID)
+	if err != nil {
+		return err
+	}
+
+	bookModel, thumbnail := volume.book, volume.thumbnail
+	if thumbnail != nil {
+		defer func() { // Delete file if the book is not created
+			if r := recover(); r != nil || err != nil {
+				//nolint
+				filestorage.DeleteFileFromDisk(thumbnail.FilePath)
+				return
+			}
+		}()
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Importing \"%s\" from Google Books volume %s.", bookModel.Title, volumeID),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	bookModel, err = book.CreateWithCopy(tx, bookModel)
+	if err != nil {
+		return err
+	}
+
+	if thumbnail != nil {
+		bookModel, err = book.CreateOrUpdateThumbnail(tx, int64(bookModel.ID), thumbnail)
+		if err != nil {
+			return err
+		}
+	}
+
+	messages := api.Messages(
+		api.SuccessMessage(i18n.T(c, i18n.BookAdded, bookModel.Title)),
+	)
+	if volume.coverErr != nil {
+		messages = append(messages, api.WarningMessage(i18n.T(c, i18n.CoverNotSaved, volume.coverErr)))
+	}
+
+	return c.JSON(api.Response{
+		Data:     bookview.ToDetailedView(bookModel),
+		Messages: messages,
+	})
+}
+
+// importedVolume is a volume mapped onto a book, with its cover saved to disk.
+type importedVolume struct {
+	book      *model.Book
+	thumbnail *model.FileUpload // Nil if the volume has no cover or it could not be saved
+	coverErr  error
+}
+
+// fetchVolume reads the volume and saves its cover. A cover that cannot be downloaded
+// or saved is reported in coverErr rather than failing the import.
+func fetchVolume(ctx context.Context, client *googlebooks.Client, volumeID string) (*importedVolume, error) {
+	item, err := client.ReadVolume(ctx, volumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	volume := &importedVolume{book: googlebooks.ToBook(item)}
+	if coverURL := googlebooks.CoverURL(item); coverURL != "" {
+		volume.thumbnail, volume.coverErr = downloadCover(ctx, client, coverURL)
+	}
+
+	return volume, nil
+}
+
+func downloadCover(ctx context.Context, client *googlebooks.Client, coverURL string) (*model.FileUpload, error) {
+	data, contentType, err := client.DownloadImage(ctx, coverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName, filePath, err := filestorage.SaveBytesToDisk(data, contentType, model.ThumbnailFolder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.FileUpload{
+		FileName:    fileName,
+		FilePath:    filePath,
+		ContentType: contentType,
+	}, nil
+}
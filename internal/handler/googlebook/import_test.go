@@ -0,0 +1,183 @@
+package googlebook
+
+import (
+	"context"
+	"errors"
+	"lms-backend/internal/filestorage"
+	"lms-backend/internal/googlebooks"
+	"lms-backend/pkg/error/externalerrors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// pngHeader is enough of an image for the cover to be saved.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// volumeJSON is a volume as returned by the Google Books API. Covers are linked over
+// plain http, as Google Books does, with $HOST standing for the fake server.
+func volumeJSON(coverPath string) string {
+	imageLinks := "{}"
+	if coverPath != "" {
+		imageLinks = `{"smallThumbnail": "http://$HOST/small", "thumbnail": "http://$HOST` + coverPath + `"}`
+	}
+
+	return `{
+		"id": "zyTCAlFPjgYC",
+		"volumeInfo": {
+			"title": " The Google Story ",
+			"subtitle": "Inside the Hottest Business",
+			"authors": ["David A. Vise", "Mark  Malseed", "David A. Vise"],
+			"publisher": "\"Random House\"",
+			"publishedDate": "2005-11",
+			"industryIdentifiers": [
+				{"type": "ISBN_10", "identifier": "055380457X"},
+				{"type": "ISBN_13", "identifier": "9780553804577"}
+			],
+			"categories": ["Business & Economics"],
+			"imageLinks": ` + imageLinks + `,
+			"language": "EN"
+		}
+	}`
+}
+
+// newTestClient returns a client of a fake Google Books API serving the volume under the
+// ID "zyTCAlFPjgYC" and PNG images at /covers/png and plain text at /covers/text.
+func newTestClient(t *testing.T, volume string) *googlebooks.Client {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/volumes/zyTCAlFPjgYC":
+			if r.URL.Query().Get("key") != "test-key" {
+				http.Error(w, "missing key", http.StatusForbidden)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(strings.ReplaceAll(volume, "$HOST", r.Host)))
+		case "/covers/png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(pngHeader)
+		case "/covers/text":
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte("not an image"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := googlebooks.NewClient(server.Client())
+	client.BaseURL = server.URL
+	client.APIKey = "test-key"
+
+	return client
+}
+
+// useTempStorage saves files under a temporary directory for the duration of the test.
+func useTempStorage(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	base := filestorage.Storage.BaseDirectoryElems
+	filestorage.Storage.BaseDirectoryElems = []string{dir}
+	t.Cleanup(func() { filestorage.Storage.BaseDirectoryElems = base })
+
+	return dir
+}
+
+func TestFetchVolume(t *testing.T) {
+	tests := []struct {
+		name          string
+		coverPath     string
+		wantThumbnail bool
+		wantCoverErr  bool
+	}{
+		{name: "cover downloaded", coverPath: "/covers/png", wantThumbnail: true},
+		{name: "no cover", coverPath: ""},
+		{name: "cover not found", coverPath: "/covers/missing", wantCoverErr: true},
+		{name: "cover not an image", coverPath: "/covers/text", wantCoverErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := useTempStorage(t)
+			client := newTestClient(t, volumeJSON(tt.coverPath))
+
+			volume, err := fetchVolume(context.Background(), client, "zyTCAlFPjgYC")
+			if err != nil {
+				t.Fatalf("fetchVolume() error = %v", err)
+			}
+
+			book := volume.book
+			if book.Title != "The Google Story: Inside the Hottest Business" {
+				t.Errorf("Title = %q, want the title and subtitle", book.Title)
+			}
+			if book.ISBN != "9780553804577" {
+				t.Errorf("ISBN = %q, want the ISBN-13", book.ISBN)
+			}
+			if want := time.Date(2005, time.November, 1, 0, 0, 0, 0, time.UTC); !book.PublicationDate.Equal(want) {
+				t.Errorf("PublicationDate = %v, want %v", book.PublicationDate, want)
+			}
+			if len(book.BookContributors) != 2 {
+				t.Errorf("contributors = %+v, want the 2 distinct authors", book.BookContributors)
+			}
+			if len(book.Publishers) != 1 || book.Publishers[0].Name != "Random House" {
+				t.Errorf("publishers = %+v, want Random House without quotes", book.Publishers)
+			}
+
+			if (volume.coverErr != nil) != tt.wantCoverErr {
+				t.Errorf("coverErr = %v, want an error: %t", volume.coverErr, tt.wantCoverErr)
+			}
+			if (volume.thumbnail != nil) != tt.wantThumbnail {
+				t.Fatalf("thumbnail = %+v, want a thumbnail: %t", volume.thumbnail, tt.wantThumbnail)
+			}
+
+			saved, err := filepath.Glob(filepath.Join(dir, "*", "*"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantThumbnail {
+				if len(saved) != 0 {
+					t.Errorf("saved files = %v, want none", saved)
+				}
+				return
+			}
+
+			if volume.thumbnail.ContentType != "image/png" || filepath.Ext(volume.thumbnail.FileName) != ".png" {
+				t.Errorf("thumbnail = %+v, want a png", volume.thumbnail)
+			}
+			if len(saved) != 1 || saved[0] != volume.thumbnail.FilePath {
+				t.Errorf("saved files = %v, want only %s", saved, volume.thumbnail.FilePath)
+			}
+			if data, err := os.ReadFile(volume.thumbnail.FilePath); err != nil || string(data) != string(pngHeader) {
+				t.Errorf("saved cover = %q, %v, want the downloaded image", data, err)
+			}
+		})
+	}
+}
+
+func TestFetchVolumeNotFound(t *testing.T) {
+	dir := useTempStorage(t)
+	client := newTestClient(t, volumeJSON("/covers/png"))
+
+	volume, err := fetchVolume(context.Background(), client, "missing")
+	if volume != nil {
+		t.Errorf("fetchVolume() = %+v, want nil", volume)
+	}
+
+	var externalErr *externalerrors.Error
+	if !errors.As(err, &externalErr) || externalErr.Status != fiber.StatusBadRequest {
+		t.Errorf("fetchVolume() error = %v, want a bad request", err)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("storage has %d entries, want none", len(entries))
+	}
+}
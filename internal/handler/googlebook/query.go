@@ -1,82 +1,53 @@
 package googlebook
 
 import (
-	"encoding/json"
-	"fmt"
 	"lms-backend/internal/api"
-	"lms-backend/internal/config"
-	"lms-backend/internal/view/bookview"
-	"lms-backend/internal/view/googlebookview"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/metadata"
+	"lms-backend/internal/view/metadataview"
 	"lms-backend/pkg/error/externalerrors"
-	"lms-backend/util/sliceutil"
-	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func HandleQuery(c *fiber.Ctx) error {
-	queries := c.Queries()
-	queryArr := []string{}
-
-	inTitle := queries["title"]
-	if len(inTitle) > 0 {
-		inTitle = strings.ReplaceAll(inTitle, " ", "+")
-		queryArr = append(queryArr, "intitle:"+inTitle)
-	}
+// Searcher looks up the query in the catalogue first, then Google Books and Open Library.
+// Results sharing an ISBN are merged in that order of preference.
+var Searcher = metadata.NewSearcher().
+	Add(metadata.NewLocalProvider(), metadata.DefaultTimeout).
+	Add(metadata.NewGoogleBooksProvider(client), metadata.DefaultTimeout).
+	Add(metadata.NewOpenLibraryProvider(nil), metadata.DefaultTimeout)
 
-	inAuthor := queries["author"]
-	if len(inAuthor) > 0 {
-		inAuthor = strings.ReplaceAll(inAuthor, " ", "+")
-		queryArr = append(queryArr, "inauthor:"+inAuthor)
-	}
-
-	inPublisher := queries["publisher"]
-	if len(inPublisher) > 0 {
-		inPublisher = strings.ReplaceAll(inPublisher, " ", "+")
-		queryArr = append(queryArr, "inpublisher:"+inPublisher)
-	}
-
-	isbn := queries["isbn"]
-	if len(isbn) > 0 {
-		queryArr = append(queryArr, "isbn:"+isbn)
+// HandleQuery searches the metadata providers by title, author, publisher and isbn.
+// Providers that fail are reported as warnings next to the results of the others.
+func HandleQuery(c *fiber.Ctx) error {
+	query := metadata.Query{
+		Title:     c.Query("title"),
+		Author:    c.Query("author"),
+		Publisher: c.Query("publisher"),
+		ISBN:      c.Query("isbn"),
 	}
 
-	query := strings.Join(queryArr, "+")
-
-	if len(query) == 0 {
+	if query.IsEmpty() {
 		return externalerrors.BadRequest("No query is provided.")
 	}
 
-	url := fmt.Sprintf("%s/volumes?q=%s&key=%s", googleAPIBaseURL, query, config.GoogleAPIKey)
-
-	agent := fiber.Get(url)
-	statusCode, body, errs := agent.Bytes()
-	if errs != nil {
-		errStrs := sliceutil.Map(errs, func(err error) string {
-			return err.Error()
-		})
-		return externalerrors.UnprocessableEntity(
-			fmt.Sprintf("Failed to query Google Books API: %s", strings.Join(errStrs, ", ")),
-		)
-	}
+	results, providerErrs := Searcher.Search(c.UserContext(), query)
 
-	if statusCode != fiber.StatusOK {
-		return externalerrors.UnprocessableEntity(
-			fmt.Sprintf("Failed to query Google Books API: %s", string(body)),
-		)
+	var view = []metadataview.ResultView{}
+	for _, result := range results {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *metadataview.ToResultView(&result))
 	}
 
-	var response googlebookview.ResponseView
-	if err := json.Unmarshal(body, &response); err != nil {
-		return externalerrors.UnprocessableEntity(
-			fmt.Sprintf("Failed to unmarshal Google Books API response: %s", err.Error()),
-		)
+	messages := api.Messages(
+		api.SilentMessage(i18n.T(c, i18n.ResultsFound, len(results))),
+	)
+	for _, providerErr := range providerErrs {
+		messages = append(messages, api.WarningMessage(i18n.T(c, i18n.SearchFailed, providerErr)))
 	}
 
 	return c.JSON(api.Response{
-		Data: bookview.GoogleResponseToView(&response),
-		Messages: api.Messages(
-			api.SilentMessage("Successfully queried Google Books API."),
-		),
+		Data:     view,
+		Messages: messages,
 	})
 }
@@ -2,6 +2,7 @@ package health
 
 import (
 	"lms-backend/internal/api"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,7 +17,7 @@ func HandleHealth(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(api.Response{
 		Data: csrfToken,
 		Messages: api.Messages(
-			api.SilentMessage("server is running"),
+			api.SilentMessage(i18n.T(c, i18n.ServerRunning)),
 		),
 	})
 }
@@ -8,11 +8,12 @@ import (
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/params/sharedparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/view/loanview"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,11 +23,6 @@ const (
 )
 
 func HandleCreate(c *fiber.Ctx) error {
-	err := policy.Authorize(c, createLoanAction, loanpolicy.CreatePolicy())
-	if err != nil {
-		return err
-	}
-
 	var params sharedparams.UserBookcopyParams
 	if err := c.BodyParser(&params); err != nil {
 		return err
@@ -38,6 +34,24 @@ func HandleCreate(c *fiber.Ctx) error {
 
 	db := database.GetDB()
 
+	// Look up the copy by its barcode if no ID is given
+	var bookCopy *model.BookCopy
+	var err error
+	if params.BookCopyID <= 0 {
+		bookCopy, err = bookcopy.ReadByAccessionNumber(db, params.Barcode)
+	} else {
+		bookCopy, err = bookcopy.Read(db, params.BookCopyID)
+	}
+	if err != nil {
+		return err
+	}
+	params.BookCopyID = int64(bookCopy.ID)
+
+	err = policy.Authorize(c, createLoanAction, loanpolicy.CreatePolicy(int64(bookCopy.CurrentBranchID)))
+	if err != nil {
+		return err
+	}
+
 	username, err := user.GetUserName(db, params.UserID)
 	if err != nil {
 		return err
@@ -61,19 +75,12 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" is loaned until %s.", bookTitle,
-				ln.DueDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookLoaned, bookTitle, i18n.For(c).Date(ln.DueDate))),
+		),
 	})
 }
 
 func HandleCreateByBook(c *fiber.Ctx) error {
-	err := policy.Authorize(c, createLoanAction, loanpolicy.CreatePolicy())
-	if err != nil {
-		return err
-	}
-
 	var params sharedparams.UserBookParams
 	if err := c.BodyParser(&params); err != nil {
 		return err
@@ -105,12 +112,16 @@ func HandleCreateByBook(c *fiber.Ctx) error {
 		return err
 	}
 
+	// The copy is only known once loaned, and the loan is rolled back if it is at another branch
+	err = policy.Authorize(c, createLoanAction, loanpolicy.CreatePolicy(int64(ln.BookCopy.CurrentBranchID)))
+	if err != nil {
+		return err
+	}
+
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" is loaned until %s.", bookTitle,
-				ln.DueDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookLoaned, bookTitle, i18n.For(c).Date(ln.DueDate))),
+		),
 	})
 }
@@ -7,6 +7,8 @@ import (
 	"lms-backend/internal/dataaccess/loan"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/session"
@@ -39,6 +41,14 @@ func HandleDelete(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid loan id.", param))
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
+	if err != nil {
+		return err
+	}
+
 	db := database.GetDB()
 
 	username, err := user.GetUserName(db, userID)
@@ -51,7 +61,10 @@ func HandleDelete(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	ln, err := loan.Delete(tx, loanID)
+	ln, err := loan.Delete(tx, loanID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
 	if err != nil {
 		return err
 	}
@@ -59,8 +72,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Loan id - \"%d\" has been deleted", loanID,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.LoanDeleted, loanID)),
+		),
 	})
 }
@@ -4,10 +4,14 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/loan"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/view/loanview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,7 +34,18 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, loan.Filters(), loan.JoinBookCopy, loan.JoinBook)
+	dbFiltered, err := cq.Filter(db, loan.Filters(), loan.JoinBookCopy, loan.JoinBook)
+	if err != nil {
+		return err
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "loans", cq.Sort(dbFiltered, loan.Sorters()), loan.ListWithBookUser, loanview.ExportColumns)
+	}
 
 	filteredCount, err := loan.Count(dbFiltered)
 	if err != nil {
@@ -44,6 +59,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(lns, func(l model.Loan) uint {
+		return l.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []loanview.DetailedView{}
 	for _, l := range lns {
 		//nolint:gosec // loop does not modify struct
@@ -55,9 +77,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("books listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.LoansListed)),
 		),
 	})
 }
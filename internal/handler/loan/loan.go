@@ -7,13 +7,13 @@ import (
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/session"
 	"lms-backend/internal/view/loanview"
 	"lms-backend/pkg/error/externalerrors"
 	"strconv"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -64,9 +64,7 @@ func HandleLoan(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" is loaned until %s.", bookTitle,
-				ln.DueDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookLoaned, bookTitle, i18n.For(c).Date(ln.DueDate))),
+		),
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/loan"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/view/loanview"
@@ -42,11 +43,22 @@ func HandleRead(c *fiber.Ctx) error {
 		return err
 	}
 
+	api.SetETag(c, loanModel.Version)
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(loanModel),
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"Loan %d retrieved", loanID,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.LoanRetrieved, loanID)),
+		),
 	})
 }
+
+// preconditionFailed responds to a change made from an outdated version of the loan
+// with the loan as it is now.
+func preconditionFailed(c *fiber.Ctx, err error, loanID int64) error {
+	loanModel, readErr := loan.ReadDetailed(database.GetDB(), loanID)
+	if readErr != nil {
+		return readErr
+	}
+
+	return api.PreconditionFailed(c, err, loanModel.Version, loanview.ToDetailedView(loanModel))
+}
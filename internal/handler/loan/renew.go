@@ -7,13 +7,14 @@ import (
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/session"
 	"lms-backend/internal/view/loanview"
 	"lms-backend/pkg/error/externalerrors"
 	"strconv"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -39,6 +40,14 @@ func HandleRenew(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
+	if err != nil {
+		return err
+	}
+
 	db := database.GetDB()
 
 	username, err := user.GetUserName(db, userID)
@@ -51,17 +60,19 @@ func HandleRenew(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	ln, err := bookcopy.RenewCopy(tx, loanID)
+	ln, err := bookcopy.RenewCopy(tx, loanID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
 	if err != nil {
 		return err
 	}
 
+	api.SetETag(c, ln.Version)
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Loan id \"%d\" has been extended to %s.", loanID,
-				ln.DueDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.LoanRenewed, loanID, i18n.For(c).Date(ln.DueDate))),
+		),
 	})
 }
@@ -5,8 +5,11 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/dataaccess/loan"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/loanpolicy"
 	"lms-backend/internal/session"
@@ -28,7 +31,19 @@ func HandleReturn(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid loan id.", param))
 	}
 
-	err = policy.Authorize(c, returnBookAction, loanpolicy.ReturnPolicy())
+	db := database.GetDB()
+
+	ln, err := loan.Read(db, loanID)
+	if err != nil {
+		return err
+	}
+
+	bookCopy, err := bookcopy.Read(db, int64(ln.BookCopyID))
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, returnBookAction, loanpolicy.ReturnPolicy(int64(bookCopy.CurrentBranchID)))
 	if err != nil {
 		return err
 	}
@@ -38,7 +53,13 @@ func HandleReturn(c *fiber.Ctx) error {
 		return err
 	}
 
-	db := database.GetDB()
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
+	if err != nil {
+		return err
+	}
 
 	username, err := user.GetUserName(db, userID)
 	if err != nil {
@@ -50,17 +71,20 @@ func HandleReturn(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	ln, err := bookcopy.ReturnCopy(tx, loanID)
+	ln, err = bookcopy.ReturnCopy(tx, loanID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, loanID)
+	}
 	if err != nil {
 		return err
 	}
 
+	api.SetETag(c, ln.Version)
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Loan id - \"%d\" has been returned.", loanID,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.LoanReturned, loanID)),
+		),
 	})
 }
 
@@ -71,7 +95,14 @@ func HandleReturnByBookcopy(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book copy id.", param))
 	}
 
-	err = policy.Authorize(c, returnBookAction, loanpolicy.ReturnPolicy())
+	db := database.GetDB()
+
+	bookCopy, err := bookcopy.Read(db, bookcopyID)
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, returnBookAction, loanpolicy.ReturnPolicy(int64(bookCopy.CurrentBranchID)))
 	if err != nil {
 		return err
 	}
@@ -81,8 +112,6 @@ func HandleReturnByBookcopy(c *fiber.Ctx) error {
 		return err
 	}
 
-	db := database.GetDB()
-
 	username, err := user.GetUserName(db, userID)
 	if err != nil {
 		return err
@@ -106,8 +135,7 @@ func HandleReturnByBookcopy(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: loanview.ToDetailedView(ln),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" has been returned.", title,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookReturned, title)),
+		),
 	})
 }
@@ -0,0 +1,78 @@
+package publisherhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/publisher"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/publisherview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listPublisherAction = "list publishers"
+)
+
+func HandleList(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listPublisherAction, bookpolicy.ListPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := publisher.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, publisher.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := publisher.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, publisher.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	publishers, err := publisher.List(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(publishers, func(p model.Publisher) uint {
+		return p.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []publisherview.View{}
+	for _, p := range publishers {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *publisherview.ToView(&p))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.PublishersListed)),
+		),
+	})
+}
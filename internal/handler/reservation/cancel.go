@@ -8,6 +8,7 @@ import (
 	"lms-backend/internal/dataaccess/reservation"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/session"
@@ -29,17 +30,29 @@ func HandleCancel(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid reservation id.", param))
 	}
 
-	err = policy.Authorize(c, cancelReservationAction, reservationpolicy.CancelPolicy(resID))
+	db := database.GetDB()
+
+	res, err := reservation.Read(db, resID)
 	if err != nil {
 		return err
 	}
 
-	userID, err := session.GetLoginSession(c)
+	bookCopy, err := bookcopy.Read(db, int64(res.BookCopyID))
 	if err != nil {
 		return err
 	}
 
-	db := database.GetDB()
+	err = policy.Authorize(
+		c, cancelReservationAction, reservationpolicy.CancelPolicy(resID, int64(bookCopy.CurrentBranchID)),
+	)
+	if err != nil {
+		return err
+	}
+
+	userID, err := session.GetLoginSession(c)
+	if err != nil {
+		return err
+	}
 
 	username, err := user.GetUserName(db, userID)
 	if err != nil {
@@ -51,7 +64,7 @@ func HandleCancel(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	res, err := bookcopy.CancelReservationCopy(tx, resID)
+	res, err = bookcopy.CancelReservationCopy(tx, resID)
 	if err != nil {
 		return err
 	}
@@ -59,9 +72,8 @@ func HandleCancel(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Reservation id \"%d\" is canceled.", resID,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.ReservationCanceled, resID)),
+		),
 	})
 }
 
@@ -79,7 +91,14 @@ func HandleCancelByBookcopy(c *fiber.Ctx) error {
 		return err
 	}
 
-	err = policy.Authorize(c, cancelReservationAction, reservationpolicy.CancelPolicy(int64(res.ID)))
+	bookCopy, err := bookcopy.Read(db, bookcopyID)
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(
+		c, cancelReservationAction, reservationpolicy.CancelPolicy(int64(res.ID), int64(bookCopy.CurrentBranchID)),
+	)
 	if err != nil {
 		return err
 	}
@@ -112,8 +131,7 @@ func HandleCancelByBookcopy(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Reservation id \"%d\" is canceled.", int64(res.ID),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.ReservationCanceled, int64(res.ID))),
+		),
 	})
 }
@@ -8,11 +8,12 @@ import (
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
-	"lms-backend/internal/params/sharedparams"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/params/reservationparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/view/reservationview"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -22,12 +23,7 @@ const (
 )
 
 func HandleCreate(c *fiber.Ctx) error {
-	err := policy.Authorize(c, createReservationAction, reservationpolicy.CreatePolicy())
-	if err != nil {
-		return err
-	}
-
-	var params sharedparams.UserBookcopyParams
+	var params reservationparams.CreateParams
 	if err := c.BodyParser(&params); err != nil {
 		return err
 	}
@@ -38,6 +34,26 @@ func HandleCreate(c *fiber.Ctx) error {
 
 	db := database.GetDB()
 
+	// Look up the copy by its barcode if no ID is given
+	var bookCopy *model.BookCopy
+	var err error
+	if params.BookCopyID <= 0 {
+		bookCopy, err = bookcopy.ReadByAccessionNumber(db, params.Barcode)
+	} else {
+		bookCopy, err = bookcopy.Read(db, params.BookCopyID)
+	}
+	if err != nil {
+		return err
+	}
+	params.BookCopyID = int64(bookCopy.ID)
+
+	err = policy.Authorize(
+		c, createReservationAction, reservationpolicy.CreatePolicy(int64(bookCopy.CurrentBranchID)),
+	)
+	if err != nil {
+		return err
+	}
+
 	username, err := user.GetUserName(db, params.UserID)
 	if err != nil {
 		return err
@@ -53,7 +69,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	res, err := bookcopy.ReserveCopy(tx, params.UserID, params.BookCopyID)
+	res, err := bookcopy.ReserveCopy(tx, params.UserID, params.BookCopyID, params.PickupBranchID)
 	if err != nil {
 		return err
 	}
@@ -61,20 +77,13 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" has been reserved until %s.", bookTitle,
-				res.ReservationDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookReserved, bookTitle, i18n.For(c).Date(res.ReservationDate))),
+		),
 	})
 }
 
 func HandleCreateByBook(c *fiber.Ctx) error {
-	err := policy.Authorize(c, createReservationAction, reservationpolicy.CreatePolicy())
-	if err != nil {
-		return err
-	}
-
-	var params sharedparams.UserBookParams
+	var params reservationparams.CreateByBookParams
 	if err := c.BodyParser(&params); err != nil {
 		return err
 	}
@@ -100,7 +109,15 @@ func HandleCreateByBook(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	res, err := book.Reserve(tx, params.UserID, params.BookID)
+	res, err := book.Reserve(tx, params.UserID, params.BookID, params.PickupBranchID)
+	if err != nil {
+		return err
+	}
+
+	// The copy is only known once reserved, and the reservation is rolled back if it is at another branch
+	err = policy.Authorize(
+		c, createReservationAction, reservationpolicy.CreatePolicy(int64(res.BookCopy.CurrentBranchID)),
+	)
 	if err != nil {
 		return err
 	}
@@ -108,9 +125,7 @@ func HandleCreateByBook(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" has been reserved until %s.", bookTitle,
-				res.ReservationDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookReserved, bookTitle, i18n.For(c).Date(res.ReservationDate))),
+		),
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"lms-backend/internal/dataaccess/reservation"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/session"
@@ -58,8 +59,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Reservation id - \"%d\" is deleted.", resID,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.ReservationDeleted, resID)),
+		),
 	})
 }
@@ -4,10 +4,14 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/reservation"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/view/reservationview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -30,7 +34,18 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, reservation.Filters(), reservation.JoinBookCopy, reservation.JoinBook)
+	dbFiltered, err := cq.Filter(db, reservation.Filters(), reservation.JoinBookCopy, reservation.JoinBook)
+	if err != nil {
+		return err
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "reservations", cq.Sort(dbFiltered, reservation.Sorters()), reservation.ListWithBookUser, reservationview.ExportColumns)
+	}
 
 	filteredCount, err := reservation.Count(dbFiltered)
 	if err != nil {
@@ -45,6 +60,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(res, func(r model.Reservation) uint {
+		return r.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []reservationview.DetailedView{}
 	for _, r := range res {
 		//nolint:gosec // loop does not modify struct
@@ -56,9 +78,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("books listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.ReservationsListed)),
 		),
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/reservation"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/view/reservationview"
@@ -45,8 +46,7 @@ func HandleRead(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"Reservation %d retrieved.", resID,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.ReservationRetrieved, resID)),
+		),
 	})
 }
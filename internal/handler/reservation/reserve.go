@@ -7,19 +7,20 @@ import (
 	"lms-backend/internal/dataaccess/bookcopy"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/reservationpolicy"
 	"lms-backend/internal/session"
 	"lms-backend/internal/view/reservationview"
 	"lms-backend/pkg/error/externalerrors"
 	"strconv"
-	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 const (
 	reserveBookAction = "reserve book"
+	pickupBranchKey   = "pickup_branch_id"
 )
 
 func HandleReserve(c *fiber.Ctx) error {
@@ -39,6 +40,9 @@ func HandleReserve(c *fiber.Ctx) error {
 		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book copy id.", param))
 	}
 
+	// Defaults to the branch the copy is at
+	pickupBranchID := c.QueryInt(pickupBranchKey, 0)
+
 	db := database.GetDB()
 
 	username, err := user.GetUserName(db, userID)
@@ -56,7 +60,7 @@ func HandleReserve(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	res, err := bookcopy.ReserveCopy(tx, userID, copyID)
+	res, err := bookcopy.ReserveCopy(tx, userID, copyID, int64(pickupBranchID))
 	if err != nil {
 		return err
 	}
@@ -64,9 +68,7 @@ func HandleReserve(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: reservationview.ToDetailedView(res),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"\"%s\" has been reserved until %s.", bookTitle,
-				res.ReservationDate.Format(time.RFC3339),
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.BookReserved, bookTitle, i18n.For(c).Date(res.ReservationDate))),
+		),
 	})
 }
@@ -0,0 +1,78 @@
+package subjecthandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/subject"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/bookpolicy"
+	"lms-backend/internal/view/subjectview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listSubjectAction = "list subjects"
+)
+
+func HandleList(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listSubjectAction, bookpolicy.ListPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := subject.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, subject.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := subject.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, subject.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	subjects, err := subject.List(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(subjects, func(s model.Subject) uint {
+		return s.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []subjectview.View{}
+	for _, s := range subjects {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *subjectview.ToView(&s))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.SubjectsListed)),
+		),
+	})
+}
@@ -0,0 +1,85 @@
+package transferhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/params/transferparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/transferpolicy"
+	"lms-backend/internal/session"
+	"lms-backend/internal/view/transferview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	createTransferAction = "transfer book copy"
+)
+
+func HandleCreate(c *fiber.Ctx) error {
+	param := c.Params("bookcopy_id")
+	copyID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid book copy id.", param))
+	}
+
+	var params transferparams.CreateParams
+	if err := c.BodyParser(&params); err != nil {
+		return err
+	}
+
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	db := database.GetDB()
+
+	bookCopy, err := bookcopy.Read(db, copyID)
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, createTransferAction, transferpolicy.SendPolicy(int64(bookCopy.CurrentBranchID)))
+	if err != nil {
+		return err
+	}
+
+	userID, err := session.GetLoginSession(c)
+	if err != nil {
+		return err
+	}
+
+	bookTitle, err := bookcopy.GetBookTitle(db, copyID)
+	if err != nil {
+		return err
+	}
+
+	branchName, err := branch.GetBranchName(db, params.ToBranchID)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Transferring a copy of \"%s\" to %s", bookTitle, branchName),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	t, err := bookcopy.TransferCopy(tx, userID, copyID, params.ToBranchID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: transferview.ToDetailedView(t),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.TransferCreated, bookTitle, branchName)),
+		),
+	})
+}
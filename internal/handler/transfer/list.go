@@ -0,0 +1,78 @@
+package transferhandler
+
+import (
+	"lms-backend/internal/api"
+	"lms-backend/internal/dataaccess/transfer"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/transferpolicy"
+	"lms-backend/internal/view/transferview"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	listTransferAction = "list transfers"
+)
+
+func HandleList(c *fiber.Ctx) error {
+	err := policy.Authorize(c, listTransferAction, transferpolicy.ReadPolicy())
+	if err != nil {
+		return err
+	}
+
+	cq := collection.GetCollectionQueryFromParam(c)
+	db := database.GetDB()
+
+	totalCount, err := transfer.Count(db)
+	if err != nil {
+		return err
+	}
+
+	dbFiltered, err := cq.Filter(db, transfer.Filters())
+	if err != nil {
+		return err
+	}
+
+	filteredCount, err := transfer.Count(dbFiltered)
+	if err != nil {
+		return err
+	}
+
+	dbSorted := cq.Sort(dbFiltered, transfer.Sorters())
+	dbPaginated := cq.Paginate(dbSorted)
+	transfers, err := transfer.ListDetailed(dbPaginated)
+	if err != nil {
+		return err
+	}
+
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(transfers, func(t model.Transfer) uint {
+		return t.ID
+	}))
+	if err != nil {
+		return err
+	}
+
+	var view = []transferview.DetailedView{}
+	for _, t := range transfers {
+		//nolint:gosec // loop does not modify struct
+		view = append(view, *transferview.ToDetailedView(&t))
+	}
+
+	return c.JSON(api.Response{
+		Data: view,
+		Meta: api.Meta{
+			TotalCount:    totalCount,
+			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
+		},
+		Messages: api.Messages(
+			api.SilentMessage(i18n.T(c, i18n.TransfersListed)),
+		),
+	})
+}
@@ -0,0 +1,59 @@
+package transferhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/bookcopy"
+	"lms-backend/internal/dataaccess/transfer"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/transferpolicy"
+	"lms-backend/internal/view/transferview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	receiveTransferAction = "receive book copy"
+)
+
+func HandleReceive(c *fiber.Ctx) error {
+	param := c.Params("transfer_id")
+	transferID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid transfer id.", param))
+	}
+
+	db := database.GetDB()
+
+	t, err := transfer.ReadDetailed(db, transferID)
+	if err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, receiveTransferAction, transferpolicy.ReceivePolicy(int64(t.ToBranchID)))
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("Receiving a copy of \"%s\" at %s", t.BookCopy.Book.Title, t.ToBranch.Name),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	t, err = bookcopy.ReceiveCopy(tx, transferID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: transferview.ToDetailedView(t),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.TransferReceived, t.BookCopy.Book.Title, t.ToBranch.Name)),
+		),
+	})
+}
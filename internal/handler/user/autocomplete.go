@@ -1,10 +1,10 @@
 package userhandler
 
 import (
-	"fmt"
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
 	"lms-backend/internal/view/userview"
@@ -27,17 +27,16 @@ func HandleAutoComplete(c *fiber.Ctx) error {
 		return err
 	}
 
-	views := make([]*userview.SimpleView, len(users))
+	views := make([]*userview.AutoCompleteView, len(users))
 	for i, usr := range users {
 		//nolint:gosec // loop does not modify struct
-		views[i] = userview.ToSimpleView(&usr)
+		views[i] = userview.ToAutoCompleteView(&usr)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: views,
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Autocomplete for \"%s\"", value,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.Autocomplete, value)),
+		),
 	})
 }
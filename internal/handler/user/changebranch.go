@@ -0,0 +1,73 @@
+package userhandler
+
+import (
+	"fmt"
+	"lms-backend/internal/api"
+	audit "lms-backend/internal/auditlog"
+	"lms-backend/internal/dataaccess/user"
+	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/params/userparams"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/userpolicy"
+	"lms-backend/internal/view/userview"
+	"lms-backend/pkg/error/externalerrors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	changeBranchAction = "change branch"
+)
+
+func HandleChangeBranch(c *fiber.Ctx) error {
+	param := c.Params("user_id")
+	userID, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		return externalerrors.BadRequest(fmt.Sprintf("%s is not a valid user id.", param))
+	}
+
+	var params userparams.UpdateBranchParams
+	err = c.BodyParser(&params)
+	if err != nil {
+		return err
+	}
+
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	err = policy.Authorize(c, changeBranchAction, userpolicy.UpdateBranchPolicy(userID))
+	if err != nil {
+		return err
+	}
+
+	db := database.GetDB()
+	username, err := user.GetUserName(db, userID)
+	if err != nil {
+		return err
+	}
+
+	tx, rollBackOrCommit := audit.Begin(
+		c, fmt.Sprintf("updating user %s's branches to %v", username, params.BranchIDs),
+	)
+	defer func() { rollBackOrCommit(err) }()
+
+	usr, err := user.UpdateBranches(tx, userID, params.BranchIDs...)
+	if err != nil {
+		return err
+	}
+
+	abilities, err := user.GetAbilities(tx, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(api.Response{
+		Data: userview.ToView(usr, abilities...),
+		Messages: api.Messages(
+			api.SuccessMessage(i18n.T(c, i18n.UserBranchesUpdated, username)),
+		),
+	})
+}
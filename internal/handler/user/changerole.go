@@ -6,6 +6,7 @@ import (
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/params/userparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
@@ -63,9 +64,7 @@ func HandleChangeRole(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: userview.ToView(usr, abilities...),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"Successfully updated user %s's role to role %d.", username, params.RoleID),
-			),
+			api.SuccessMessage(i18n.T(c, i18n.UserRoleUpdated, username, params.RoleID)),
 		),
 	})
 }
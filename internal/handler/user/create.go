@@ -8,6 +8,7 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/user"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/model"
 	"lms-backend/internal/params/userparams"
 	"lms-backend/internal/view/userview"
@@ -48,8 +49,7 @@ func HandleCreate(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: userview.ToView(usr, abilities...),
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"User %s created successfully", usr.Username,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.UserCreated, usr.Username)),
+		),
 	})
 }
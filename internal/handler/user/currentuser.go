@@ -1,10 +1,10 @@
 package userhandler
 
 import (
-	"fmt"
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/middleware"
 	"lms-backend/internal/session"
 	"lms-backend/internal/view/userview"
@@ -24,7 +24,7 @@ func HandleGetCurrentUser(c *fiber.Ctx) error {
 		return c.JSON(api.Response{
 			Data: userview.ToGuestView(),
 			Messages: api.Messages(
-				api.SuccessMessage("Welcome guest!"),
+				api.SuccessMessage(i18n.T(c, i18n.WelcomeGuest)),
 			),
 		})
 	}
@@ -51,7 +51,7 @@ func HandleGetCurrentUser(c *fiber.Ctx) error {
 	return c.JSON(api.Response{
 		Data: userview.ToCurrentUserView(usr, abilities, csrfToken),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf("Welcome back, %s!", usr.Username)),
+			api.SuccessMessage(i18n.T(c, i18n.WelcomeBack, usr.Username)),
 		),
 	})
 }
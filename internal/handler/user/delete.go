@@ -6,6 +6,8 @@ import (
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
 	"lms-backend/internal/view/userview"
@@ -31,6 +33,14 @@ func HandleDelete(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, userID)
+	}
+	if err != nil {
+		return err
+	}
+
 	db := database.GetDB()
 	username, err := user.GetUserName(db, userID)
 
@@ -39,7 +49,10 @@ func HandleDelete(c *fiber.Ctx) error {
 	)
 	defer func() { rollBackOrCommit(err) }()
 
-	usr, err := user.Delete(tx, userID)
+	usr, err := user.Delete(tx, userID, version)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, userID)
+	}
 	if err != nil {
 		return err
 	}
@@ -52,8 +65,7 @@ func HandleDelete(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: userview.ToView(usr, abilities...),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"User %s deleted successfully", usr.Username,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.UserDeleted, usr.Username)),
+		),
 	})
 }
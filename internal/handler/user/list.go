@@ -4,10 +4,14 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/export"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
 	"lms-backend/internal/view/userview"
 	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/util/sliceutil"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -26,7 +30,18 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
-	dbFiltered := cq.Filter(db, user.Filters())
+	dbFiltered, err := cq.Filter(db, user.Filters())
+	if err != nil {
+		return err
+	}
+
+	format, err := export.RequestedFormat(c)
+	if err != nil {
+		return err
+	}
+	if format != "" {
+		return export.Stream(c, format, "users", cq.Sort(dbFiltered, user.Sorters()), user.List, userview.ExportColumns)
+	}
 
 	filteredCount, err := user.Count(dbFiltered)
 	if err != nil {
@@ -41,6 +56,13 @@ func HandleList(c *fiber.Ctx) error {
 		return err
 	}
 
+	nextCursor, prevCursor, err := cq.Cursors(dbSorted, sliceutil.Map(rvs, func(u model.User) uint {
+		return u.ID
+	}))
+	if err != nil {
+		return err
+	}
+
 	var view = []*userview.View{}
 	for _, r := range rvs {
 		//nolint:gosec // loop does not modify struct
@@ -52,9 +74,11 @@ func HandleList(c *fiber.Ctx) error {
 		Meta: api.Meta{
 			TotalCount:    totalCount,
 			FilteredCount: filteredCount,
+			NextCursor:    nextCursor,
+			PrevCursor:    prevCursor,
 		},
 		Messages: api.Messages(
-			api.SilentMessage("users listed successfully"),
+			api.SilentMessage(i18n.T(c, i18n.UsersListed)),
 		),
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"lms-backend/internal/api"
 	"lms-backend/internal/dataaccess/user"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
 	"lms-backend/internal/view/userview"
@@ -42,11 +43,29 @@ func HandleRead(c *fiber.Ctx) error {
 		return err
 	}
 
+	api.SetETag(c, usr.Version)
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: userview.ToView(usr, abilities...),
 		Messages: api.Messages(
-			api.SilentMessage(fmt.Sprintf(
-				"User %s retrieved successfully", usr.Username,
-			))),
+			api.SilentMessage(i18n.T(c, i18n.UserRetrieved, usr.Username)),
+		),
 	})
 }
+
+// preconditionFailed responds to a change made from an outdated version of the user
+// with the user as it is now.
+func preconditionFailed(c *fiber.Ctx, err error, userID int64) error {
+	db := database.GetDB()
+
+	usr, readErr := user.Read(db, userID)
+	if readErr != nil {
+		return readErr
+	}
+
+	abilities, readErr := user.GetAbilities(db, userID)
+	if readErr != nil {
+		return readErr
+	}
+
+	return api.PreconditionFailed(c, err, usr.Version, userview.ToView(usr, abilities...))
+}
@@ -9,9 +9,13 @@ import (
 	"lms-backend/internal/api"
 	audit "lms-backend/internal/auditlog"
 	"lms-backend/internal/dataaccess/user"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/internal/orm"
 	"lms-backend/internal/params/userparams"
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/userpolicy"
+	"lms-backend/internal/session"
 	"lms-backend/internal/view/userview"
 	"lms-backend/pkg/error/externalerrors"
 )
@@ -42,27 +46,58 @@ func HandleUpdate(c *fiber.Ctx) error {
 		return err
 	}
 
+	version, err := api.IfMatch(c)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, userID)
+	}
+	if err != nil {
+		return err
+	}
+
 	usr := params.ToModel()
+	usr.Version = version
 	tx, rollBackOrCommit := audit.Begin(
 		c, fmt.Sprintf("updating user %s", usr.Username),
 	)
 	defer func() { rollBackOrCommit(err) }()
 
 	usr, err = user.UpdateParticulars(tx, usr)
+	if orm.IsVersionConflict(err) {
+		return preconditionFailed(c, err, userID)
+	}
 	if err != nil {
 		return err
 	}
 
+	currentUserID, err := session.GetLoginSession(c)
+	if err != nil {
+		return err
+	}
+
+	if currentUserID == userID {
+		// Localizes the next requests of the user in their preference
+		var saved *model.User
+		saved, err = user.Read(tx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = session.SavePreference(c, saved.Locale, saved.TimeZone)
+		if err != nil {
+			return err
+		}
+	}
+
 	abilities, err := user.GetAbilities(tx, userID)
 	if err != nil {
 		return err
 	}
 
+	api.SetETag(c, usr.Version)
 	return c.Status(fiber.StatusCreated).JSON(api.Response{
 		Data: userview.ToView(usr, abilities...),
 		Messages: api.Messages(
-			api.SuccessMessage(fmt.Sprintf(
-				"User %s updated successfully", usr.Username,
-			))),
+			api.SuccessMessage(i18n.T(c, i18n.UserUpdated, usr.Username)),
+		),
 	})
 }
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"fmt"
+	"lms-backend/util/sliceutil"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// catalogue holds the messages of a language and how it writes dates.
+type catalogue struct {
+	messages map[Key]string
+	months   [12]string
+	// Layout of the time of day, as of time.Format
+	clock string
+}
+
+var (
+	catalogues = map[Language]*catalogue{
+		English: &english,
+		Malay:   &malay,
+	}
+
+	// Verbs of fmt, optionally with an explicit argument index, e.g. %d or %[2]s
+	verbRegex = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*(?:\.\d+)?([a-zA-Z%])`)
+)
+
+// Check lists the problems of the catalogues: keys missing from a language, keys
+// that are not Keys, and translations whose arguments differ from those in the
+// default language.
+func Check() []string {
+	problems := []string{}
+
+	for _, language := range Languages {
+		c, ok := catalogues[language]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: no catalogue", language))
+			continue
+		}
+
+		for _, key := range Keys {
+			message, ok := c.messages[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: %s is missing", language, key))
+				continue
+			}
+
+			if language == DefaultLanguage {
+				continue
+			}
+			defaultMessage, ok := catalogues[DefaultLanguage].messages[key]
+			if ok && fmt.Sprint(arguments(message)) != fmt.Sprint(arguments(defaultMessage)) {
+				problems = append(problems, fmt.Sprintf(
+					"%s: %s formats %v instead of %v",
+					language, key, arguments(message), arguments(defaultMessage),
+				))
+			}
+		}
+
+		unknown := []string{}
+		for key := range c.messages {
+			if !sliceutil.Contains(Keys, key) {
+				unknown = append(unknown, string(key))
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			problems = append(problems, fmt.Sprintf("%s: %s is not a key", language, key))
+		}
+	}
+
+	return problems
+}
+
+// arguments lists the verb of each argument formatted by the message, in order of
+// the arguments, so that translations may reorder them with explicit indexes.
+func arguments(message string) []string {
+	verbs := map[int]string{}
+	next := 1
+	for _, match := range verbRegex.FindAllStringSubmatch(message, -1) {
+		if match[2] == "%" {
+			continue
+		}
+		if match[1] != "" {
+			//nolint:errcheck // matched digits
+			next, _ = strconv.Atoi(match[1])
+		}
+		verbs[next] = match[2]
+		next++
+	}
+
+	args := make([]string, len(verbs))
+	for i := range args {
+		args[i] = verbs[i+1]
+	}
+
+	return args
+}
@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalogues(t *testing.T) {
+	for _, problem := range Check() {
+		t.Error(problem)
+	}
+}
+
+func TestCheckReportsProblems(t *testing.T) {
+	key := Keys[0]
+	messages := map[Key]string{}
+	for k, message := range english.messages {
+		messages[k] = message
+	}
+	messages[key] = "%d things for %s"
+	messages["not.a.key"] = "Unused"
+
+	previous := catalogues
+	catalogues = map[Language]*catalogue{
+		English: {messages: map[Key]string{key: "%s has %d things"}},
+		Malay:   {messages: messages},
+	}
+	t.Cleanup(func() { catalogues = previous })
+
+	problems := Check()
+
+	// English only has the first key, and Malay formats it differently
+	want := map[string]bool{
+		"en: " + string(Keys[1]) + " is missing":                 true,
+		"ms: " + string(key) + " formats [d s] instead of [s d]": true,
+		"ms: not.a.key is not a key":                             true,
+	}
+	for _, problem := range problems {
+		delete(want, problem)
+	}
+	for problem := range want {
+		t.Errorf("Check() does not report %q", problem)
+	}
+}
+
+func TestArguments(t *testing.T) {
+	tests := []struct {
+		message string
+		want    []string
+	}{
+		{message: "No arguments", want: []string{}},
+		{message: "%s returned %d books", want: []string{"s", "d"}},
+		{message: "%[2]d books returned by %[1]s", want: []string{"s", "d"}},
+		{message: "100%% of %v", want: []string{"v"}},
+		{message: "%-10s|%05.2f", want: []string{"s", "f"}},
+	}
+
+	for _, tt := range tests {
+		if got := arguments(tt.message); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("arguments(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
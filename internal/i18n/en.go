@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+)
+
+var english = catalogue{
+	months: [12]string{
+		"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December",
+	},
+	clock: "3:04 PM",
+	messages: map[Key]string{
+		ServerRunning:      "server is running",
+		SomethingWentWrong: "Something went wrong: %s",
+
+		NotLoggedIn:  "User is not logged in",
+		SignedIn:     "%s is logged in successfully",
+		SignedOut:    "User is logged out successfully",
+		WelcomeGuest: "Welcome guest!",
+		WelcomeBack:  "Welcome back, %s!",
+
+		Autocomplete: "Autocomplete for \"%s\"",
+
+		UserCreated:         "User %s created successfully",
+		UserRetrieved:       "User %s retrieved successfully",
+		UserUpdated:         "User %s updated successfully",
+		UserDeleted:         "User %s deleted successfully",
+		UsersListed:         "users listed successfully",
+		UserRoleUpdated:     "Successfully updated user %s's role to role %d.",
+		UserBranchesUpdated: "Successfully updated user %s's branches.",
+
+		BookAdded:         "\"%s\" added to library.",
+		BookRetrieved:     "\"%s\" retrieved.",
+		BookModified:      "\"%s\" modified successfully.",
+		BookRemoved:       "\"%s\" removed from library.",
+		BooksListed:       "books listed successfully",
+		BooksSearched:     "books searched successfully",
+		PopularListed:     "Popular books listed successfully",
+		DuplicatesListed:  "Duplicate books listed successfully",
+		BooksMerged:       "%d book(s) merged into \"%s\".",
+		ThumbnailUploaded: "\"%s\" uploaded successfully for \"%s\".",
+		CoverNotSaved:     "Cover could not be saved: %s",
+
+		BookImportsListed: "book imports listed successfully",
+		BookImportRead:    "book import read successfully",
+		ImportColumnsRead: "import columns read successfully",
+		RowsImporting:     "Importing %d rows from %s.",
+		RowsValidating:    "Validating %d rows from %s.",
+		RecordsImported:   "%d of %d records imported.",
+		RecordsImportable: "%d of %d records can be imported.",
+
+		ResultsFound: "%d results found.",
+		SearchFailed: "Could not search %s",
+
+		BookCopiesCreated: "%d copies of %s created successfully",
+		BookCopyRead:      "book copy read successfully",
+		BookCopyModified:  "Book copy %s modified successfully.",
+		BookCopyDeleted:   "book copy deleted successfully",
+
+		BranchCreated:  "Branch \"%s\" created.",
+		BranchModified: "Branch \"%s\" modified successfully.",
+		BranchRemoved:  "Branch \"%s\" removed.",
+		BranchesListed: "branches listed successfully",
+
+		TransferCreated:  "A copy of \"%s\" is in transit to %s.",
+		TransferReceived: "A copy of \"%s\" has been received at %s.",
+		TransfersListed:  "transfers listed successfully",
+
+		BookLoaned:    "\"%s\" is loaned until %s.",
+		LoanRetrieved: "Loan %d retrieved",
+		LoanRenewed:   "Loan id \"%d\" has been extended to %s.",
+		LoanReturned:  "Loan id - \"%d\" has been returned.",
+		BookReturned:  "\"%s\" has been returned.",
+		LoanDeleted:   "Loan id - \"%d\" has been deleted",
+		LoansListed:   "loans listed successfully",
+
+		BookReserved:         "\"%s\" has been reserved until %s.",
+		ReservationRetrieved: "Reservation %d retrieved.",
+		ReservationCanceled:  "Reservation id \"%d\" is canceled.",
+		ReservationDeleted:   "Reservation id - \"%d\" is deleted.",
+		ReservationsListed:   "reservations listed successfully",
+
+		BookmarkCreated: "bookmark created successfully",
+		BookmarkDeleted: "bookmark deleted successfully",
+		BookmarksListed: "bookmarks listed successfully",
+
+		FineSettled: "Fine id - \"%d\" is settled.",
+		FineDeleted: "Fine id - \"%d\" has been deleted",
+		FinesListed: "fines listed successfully",
+
+		ContributorsListed: "contributors listed successfully",
+		PublishersListed:   "publishers listed successfully",
+		SubjectsListed:     "subjects listed successfully",
+		FileUploaded:       "\"%s\" uploaded successfully.",
+
+		AuditLogCreated:     "Entry in audit log created successfully: %s",
+		AuditLogListed:      "auditlog listed successfully",
+		AuditLogVerified:    "%d audit log entries verified, none were changed or removed.",
+		AuditLogChainBroken: "Audit log entry %d was changed or does not follow the entry before it.",
+
+		ErrorKey(externalerrors.InvalidCredentials):      "User not found or invalid password",
+		ErrorKey(externalerrors.RecordNotFound):          "The record was not found",
+		ErrorKey(externalerrors.AlreadyExists):           "The record already exists",
+		ErrorKey(externalerrors.InvalidValue):            "A value is invalid",
+		ErrorKey(externalerrors.LoanLimitReached):        "You have reached the maximum number of loans",
+		ErrorKey(externalerrors.ReservationLimitReached): "You have reached the maximum number of reservations",
+		ErrorKey(externalerrors.BookmarkLimitReached):    "You have reached the maximum number of bookmarks",
+		ErrorKey(externalerrors.LoanDurationExceeded):    "The loan cannot be extended beyond the maximum duration",
+		ErrorKey(externalerrors.BookAlreadyLoaned):       "You have already loaned a copy of this book",
+		ErrorKey(externalerrors.BookAlreadyReserved):     "You have already reserved a copy of this book",
+		ErrorKey(externalerrors.CopyOnLoan):              "The book copy is on loan",
+		ErrorKey(externalerrors.CopyOnReserve):           "The book copy is on reserve",
+		ErrorKey(externalerrors.CopyInTransit):           "The book copy is in transit",
+		ErrorKey(externalerrors.CopyNotOnLoan):           "The book copy is not on loan",
+		ErrorKey(externalerrors.CopyNotOnReserve):        "The book copy is not on reserve",
+		ErrorKey(externalerrors.CopyNotInTransit):        "The book copy is not in transit",
+		ErrorKey(externalerrors.CopyNotAvailable):        "No copy of the book is available",
+		ErrorKey(externalerrors.CopyAtDestination):       "The book copy is already at the destination branch",
+		ErrorKey(externalerrors.ReservationNotPending):   "The reservation is not pending",
+		ErrorKey(externalerrors.TransferNotInTransit):    "The transfer is not in transit",
+		ErrorKey(externalerrors.BranchHasCopies):         "The branch still has book copies",
+		ErrorKey(externalerrors.BranchHasReservations):   "The branch is still the pickup branch of pending reservations",
+		ErrorKey(externalerrors.BranchHasStaff):          "The branch still has staff assigned to it",
+		ErrorKey(externalerrors.DefaultBranch):           "The default branch cannot be deleted",
+		ErrorKey(externalerrors.VersionConflict):         "The record was changed by someone else, review the changes and try again",
+		ErrorKey(externalerrors.IdempotencyKeyInUse):     "The same request is still being processed",
+		ErrorKey(externalerrors.IdempotencyKeyReused):    "The idempotency key was already used for a different request",
+		ErrorKey(externalerrors.RateLimitExceeded):       "Too many requests, please wait a moment and try again",
+
+		FieldErrorKey(externalerrors.Required):      "%s is required",
+		FieldErrorKey(externalerrors.InvalidFormat): "%s is not in a valid format",
+		FieldErrorKey(externalerrors.InvalidValue):  "%s is invalid",
+		FieldErrorKey(externalerrors.AlreadyExists): "%s already exists",
+		FieldErrorKey(externalerrors.Duplicated):    "%s is repeated",
+		FieldErrorKey(externalerrors.MismatchedID):  "%s does not match the URL",
+	},
+}
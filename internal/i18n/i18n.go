@@ -0,0 +1,124 @@
+// Package i18n
+//
+// Translates the messages of the API from a catalogue per language, keyed by Key.
+// The language of a request is the preference of the signed in user, or else negotiated
+// from its Accept-Language header, and dates are formatted in the time zone of the user.
+package i18n
+
+import (
+	"fmt"
+	"lms-backend/util/sliceutil"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Language = string
+
+const (
+	English Language = "en"
+	Malay   Language = "ms"
+
+	DefaultLanguage = English
+
+	localizerKey = "localizer"
+)
+
+// Languages are the languages with a catalogue, in order of preference.
+var Languages = []Language{English, Malay}
+
+// IsSupported reports whether there is a catalogue for the language.
+func IsSupported(language Language) bool {
+	return sliceutil.Contains(Languages, language)
+}
+
+// Localizer translates messages to a language and formats dates in a time zone.
+type Localizer struct {
+	Language Language
+	Location *time.Location
+}
+
+// Negotiate picks the preferred language if supported, or else the best match of the
+// Accept-Language header of the request. Dates are formatted in the time zone, or in
+// the local time zone of the server if it is empty or unknown.
+func Negotiate(c *fiber.Ctx, preference Language, timeZone string) *Localizer {
+	language := preference
+	if !IsSupported(language) {
+		language = c.AcceptsLanguages(Languages...)
+	}
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	location := time.Local
+	if timeZone != "" {
+		if loc, err := time.LoadLocation(timeZone); err == nil {
+			location = loc
+		}
+	}
+
+	return &Localizer{
+		Language: language,
+		Location: location,
+	}
+}
+
+// Set makes the localizer that of the request.
+func Set(c *fiber.Ctx, l *Localizer) {
+	c.Locals(localizerKey, l)
+}
+
+// For returns the localizer of the request, negotiated from its headers alone if none was set.
+func For(c *fiber.Ctx) *Localizer {
+	if l, ok := c.Locals(localizerKey).(*Localizer); ok {
+		return l
+	}
+
+	l := Negotiate(c, "", "")
+	Set(c, l)
+	return l
+}
+
+// T translates the message to the language of the request.
+func T(c *fiber.Ctx, key Key, args ...interface{}) string {
+	return For(c).T(key, args...)
+}
+
+// T formats the message of the key with the args. Messages missing from the catalogue
+// of the language fall back to the default language, and then to the key itself.
+func (l *Localizer) T(key Key, args ...interface{}) string {
+	message, ok := catalogues[l.Language].messages[key]
+	if !ok {
+		message, ok = catalogues[DefaultLanguage].messages[key]
+	}
+	if !ok {
+		return string(key)
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// Has reports whether the catalogue of the language translates the key.
+func (l *Localizer) Has(key Key) bool {
+	_, ok := catalogues[l.Language].messages[key]
+	return ok
+}
+
+// Date formats the day of t in the time zone, e.g. 2 January 2006.
+func (l *Localizer) Date(t time.Time) string {
+	t = t.In(l.Location)
+	months := catalogues[l.Language].months
+
+	return fmt.Sprintf("%d %s %d", t.Day(), months[t.Month()-1], t.Year())
+}
+
+// DateTime formats t in the time zone, e.g. 2 January 2006, 3:04 PM +08.
+func (l *Localizer) DateTime(t time.Time) string {
+	t = t.In(l.Location)
+
+	return l.Date(t) + ", " + t.Format(catalogues[l.Language].clock+" MST")
+}
@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+)
+
+// Key identifies a message in the catalogues, e.g. loan.created.
+type Key string
+
+// Keys are every key that must be in the catalogue of each language.
+var Keys []Key
+
+func newKey(key string) Key {
+	Keys = append(Keys, Key(key))
+	return Key(key)
+}
+
+var (
+	ServerRunning      = newKey("health.running")
+	SomethingWentWrong = newKey("error.something_went_wrong")
+
+	NotLoggedIn  = newKey("auth.not_logged_in")
+	SignedIn     = newKey("auth.signed_in")
+	SignedOut    = newKey("auth.signed_out")
+	WelcomeGuest = newKey("auth.welcome_guest")
+	WelcomeBack  = newKey("auth.welcome_back")
+
+	Autocomplete = newKey("autocomplete")
+
+	UserCreated         = newKey("user.created")
+	UserRetrieved       = newKey("user.retrieved")
+	UserUpdated         = newKey("user.updated")
+	UserDeleted         = newKey("user.deleted")
+	UsersListed         = newKey("user.listed")
+	UserRoleUpdated     = newKey("user.role_updated")
+	UserBranchesUpdated = newKey("user.branches_updated")
+
+	BookAdded         = newKey("book.added")
+	BookRetrieved     = newKey("book.retrieved")
+	BookModified      = newKey("book.modified")
+	BookRemoved       = newKey("book.removed")
+	BooksListed       = newKey("book.listed")
+	BooksSearched     = newKey("book.searched")
+	PopularListed     = newKey("book.popular_listed")
+	DuplicatesListed  = newKey("book.duplicates_listed")
+	BooksMerged       = newKey("book.merged")
+	ThumbnailUploaded = newKey("book.thumbnail_uploaded")
+	CoverNotSaved     = newKey("book.cover_not_saved")
+
+	BookImportsListed = newKey("book_import.listed")
+	BookImportRead    = newKey("book_import.read")
+	ImportColumnsRead = newKey("book_import.columns_read")
+	RowsImporting     = newKey("book_import.importing")
+	RowsValidating    = newKey("book_import.validating")
+	RecordsImported   = newKey("book_import.records_imported")
+	RecordsImportable = newKey("book_import.records_importable")
+
+	ResultsFound = newKey("external.results_found")
+	SearchFailed = newKey("external.search_failed")
+
+	BookCopiesCreated = newKey("bookcopy.created")
+	BookCopyRead      = newKey("bookcopy.read")
+	BookCopyModified  = newKey("bookcopy.modified")
+	BookCopyDeleted   = newKey("bookcopy.deleted")
+
+	BranchCreated  = newKey("branch.created")
+	BranchModified = newKey("branch.modified")
+	BranchRemoved  = newKey("branch.removed")
+	BranchesListed = newKey("branch.listed")
+
+	TransferCreated  = newKey("transfer.created")
+	TransferReceived = newKey("transfer.received")
+	TransfersListed  = newKey("transfer.listed")
+
+	BookLoaned    = newKey("loan.created")
+	LoanRetrieved = newKey("loan.retrieved")
+	LoanRenewed   = newKey("loan.renewed")
+	LoanReturned  = newKey("loan.returned")
+	BookReturned  = newKey("loan.book_returned")
+	LoanDeleted   = newKey("loan.deleted")
+	LoansListed   = newKey("loan.listed")
+
+	BookReserved         = newKey("reservation.created")
+	ReservationRetrieved = newKey("reservation.retrieved")
+	ReservationCanceled  = newKey("reservation.canceled")
+	ReservationDeleted   = newKey("reservation.deleted")
+	ReservationsListed   = newKey("reservation.listed")
+
+	BookmarkCreated = newKey("bookmark.created")
+	BookmarkDeleted = newKey("bookmark.deleted")
+	BookmarksListed = newKey("bookmark.listed")
+
+	FineSettled = newKey("fine.settled")
+	FineDeleted = newKey("fine.deleted")
+	FinesListed = newKey("fine.listed")
+
+	ContributorsListed = newKey("contributor.listed")
+	PublishersListed   = newKey("publisher.listed")
+	SubjectsListed     = newKey("subject.listed")
+	FileUploaded       = newKey("file_upload.uploaded")
+
+	AuditLogCreated     = newKey("audit_log.created")
+	AuditLogListed      = newKey("audit_log.listed")
+	AuditLogVerified    = newKey("audit_log.verified")
+	AuditLogChainBroken = newKey("audit_log.chain_broken")
+)
+
+// Codes of errors translated regardless of their message. Errors of other codes,
+// such as those that only have a status, keep their message.
+var errorCodes = []externalerrors.Code{
+	externalerrors.InvalidCredentials,
+	externalerrors.RecordNotFound,
+	externalerrors.AlreadyExists,
+	externalerrors.InvalidValue,
+	externalerrors.LoanLimitReached,
+	externalerrors.ReservationLimitReached,
+	externalerrors.BookmarkLimitReached,
+	externalerrors.LoanDurationExceeded,
+	externalerrors.BookAlreadyLoaned,
+	externalerrors.BookAlreadyReserved,
+	externalerrors.CopyOnLoan,
+	externalerrors.CopyOnReserve,
+	externalerrors.CopyInTransit,
+	externalerrors.CopyNotOnLoan,
+	externalerrors.CopyNotOnReserve,
+	externalerrors.CopyNotInTransit,
+	externalerrors.CopyNotAvailable,
+	externalerrors.CopyAtDestination,
+	externalerrors.ReservationNotPending,
+	externalerrors.TransferNotInTransit,
+	externalerrors.BranchHasCopies,
+	externalerrors.BranchHasReservations,
+	externalerrors.BranchHasStaff,
+	externalerrors.DefaultBranch,
+	externalerrors.VersionConflict,
+	externalerrors.IdempotencyKeyInUse,
+	externalerrors.IdempotencyKeyReused,
+	externalerrors.RateLimitExceeded,
+}
+
+// Codes of the errors of a field, translated with the path of the field
+var fieldErrorCodes = []externalerrors.Code{
+	externalerrors.Required,
+	externalerrors.InvalidFormat,
+	externalerrors.InvalidValue,
+	externalerrors.AlreadyExists,
+	externalerrors.Duplicated,
+	externalerrors.MismatchedID,
+}
+
+func init() {
+	for _, code := range errorCodes {
+		newKey(string(ErrorKey(code)))
+	}
+	for _, code := range fieldErrorCodes {
+		newKey(string(FieldErrorKey(code)))
+	}
+}
+
+// ErrorKey is the key of the message of an error, e.g. error.LOAN_LIMIT_REACHED.
+func ErrorKey(code externalerrors.Code) Key {
+	return Key("error." + code)
+}
+
+// FieldErrorKey is the key of the message of the error of a field, e.g. error.field.REQUIRED.
+func FieldErrorKey(code externalerrors.Code) Key {
+	return Key("error.field." + code)
+}
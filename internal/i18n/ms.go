@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+)
+
+var malay = catalogue{
+	months: [12]string{
+		"Januari", "Februari", "Mac", "April", "Mei", "Jun",
+		"Julai", "Ogos", "September", "Oktober", "November", "Disember",
+	},
+	clock: "15:04",
+	messages: map[Key]string{
+		ServerRunning:      "pelayan sedang berjalan",
+		SomethingWentWrong: "Ralat telah berlaku: %s",
+
+		NotLoggedIn:  "Pengguna belum log masuk",
+		SignedIn:     "%s berjaya log masuk",
+		SignedOut:    "Pengguna berjaya log keluar",
+		WelcomeGuest: "Selamat datang, tetamu!",
+		WelcomeBack:  "Selamat kembali, %s!",
+
+		Autocomplete: "Autolengkap untuk \"%s\"",
+
+		UserCreated:         "Pengguna %s berjaya dicipta",
+		UserRetrieved:       "Pengguna %s berjaya diambil",
+		UserUpdated:         "Pengguna %s berjaya dikemas kini",
+		UserDeleted:         "Pengguna %s berjaya dipadam",
+		UsersListed:         "senarai pengguna berjaya diambil",
+		UserRoleUpdated:     "Peranan pengguna %s berjaya dikemas kini kepada peranan %d.",
+		UserBranchesUpdated: "Cawangan pengguna %s berjaya dikemas kini.",
+
+		BookAdded:         "\"%s\" ditambah ke perpustakaan.",
+		BookRetrieved:     "\"%s\" diambil.",
+		BookModified:      "\"%s\" berjaya diubah.",
+		BookRemoved:       "\"%s\" dikeluarkan daripada perpustakaan.",
+		BooksListed:       "senarai buku berjaya diambil",
+		BooksSearched:     "carian buku berjaya",
+		PopularListed:     "Senarai buku popular berjaya diambil",
+		DuplicatesListed:  "Senarai buku pendua berjaya diambil",
+		BooksMerged:       "%d buku digabungkan ke dalam \"%s\".",
+		ThumbnailUploaded: "\"%s\" berjaya dimuat naik untuk \"%s\".",
+		CoverNotSaved:     "Kulit buku tidak dapat disimpan: %s",
+
+		BookImportsListed: "senarai import buku berjaya diambil",
+		BookImportRead:    "import buku berjaya dibaca",
+		ImportColumnsRead: "lajur import berjaya dibaca",
+		RowsImporting:     "Mengimport %d baris daripada %s.",
+		RowsValidating:    "Mengesahkan %d baris daripada %s.",
+		RecordsImported:   "%d daripada %d rekod diimport.",
+		RecordsImportable: "%d daripada %d rekod boleh diimport.",
+
+		ResultsFound: "%d hasil ditemui.",
+		SearchFailed: "Tidak dapat membuat carian dalam %s",
+
+		BookCopiesCreated: "%d naskhah %s berjaya dicipta",
+		BookCopyRead:      "naskhah buku berjaya dibaca",
+		BookCopyModified:  "Naskhah buku %s berjaya diubah.",
+		BookCopyDeleted:   "naskhah buku berjaya dipadam",
+
+		BranchCreated:  "Cawangan \"%s\" dicipta.",
+		BranchModified: "Cawangan \"%s\" berjaya diubah.",
+		BranchRemoved:  "Cawangan \"%s\" dikeluarkan.",
+		BranchesListed: "senarai cawangan berjaya diambil",
+
+		TransferCreated:  "Satu naskhah \"%s\" sedang dihantar ke %s.",
+		TransferReceived: "Satu naskhah \"%s\" telah diterima di %s.",
+		TransfersListed:  "senarai pemindahan berjaya diambil",
+
+		BookLoaned:    "\"%s\" dipinjam sehingga %s.",
+		LoanRetrieved: "Pinjaman %d diambil",
+		LoanRenewed:   "Pinjaman id \"%d\" telah dilanjutkan sehingga %s.",
+		LoanReturned:  "Pinjaman id - \"%d\" telah dipulangkan.",
+		BookReturned:  "\"%s\" telah dipulangkan.",
+		LoanDeleted:   "Pinjaman id - \"%d\" telah dipadam",
+		LoansListed:   "senarai pinjaman berjaya diambil",
+
+		BookReserved:         "\"%s\" telah ditempah sehingga %s.",
+		ReservationRetrieved: "Tempahan %d diambil.",
+		ReservationCanceled:  "Tempahan id \"%d\" dibatalkan.",
+		ReservationDeleted:   "Tempahan id - \"%d\" dipadam.",
+		ReservationsListed:   "senarai tempahan berjaya diambil",
+
+		BookmarkCreated: "penanda buku berjaya dicipta",
+		BookmarkDeleted: "penanda buku berjaya dipadam",
+		BookmarksListed: "senarai penanda buku berjaya diambil",
+
+		FineSettled: "Denda id - \"%d\" telah dijelaskan.",
+		FineDeleted: "Denda id - \"%d\" telah dipadam",
+		FinesListed: "senarai denda berjaya diambil",
+
+		ContributorsListed: "senarai penyumbang berjaya diambil",
+		PublishersListed:   "senarai penerbit berjaya diambil",
+		SubjectsListed:     "senarai subjek berjaya diambil",
+		FileUploaded:       "\"%s\" berjaya dimuat naik.",
+
+		AuditLogCreated:     "Catatan log audit berjaya dicipta: %s",
+		AuditLogListed:      "senarai log audit berjaya diambil",
+		AuditLogVerified:    "%d catatan log audit disahkan, tiada yang diubah atau dibuang.",
+		AuditLogChainBroken: "Catatan log audit %d telah diubah atau tidak mengikuti catatan sebelumnya.",
+
+		ErrorKey(externalerrors.InvalidCredentials):      "Pengguna tidak dijumpai atau kata laluan tidak sah",
+		ErrorKey(externalerrors.RecordNotFound):          "Rekod tidak dijumpai",
+		ErrorKey(externalerrors.AlreadyExists):           "Rekod ini sudah wujud",
+		ErrorKey(externalerrors.InvalidValue):            "Terdapat nilai yang tidak sah",
+		ErrorKey(externalerrors.LoanLimitReached):        "Anda telah mencapai had maksimum pinjaman",
+		ErrorKey(externalerrors.ReservationLimitReached): "Anda telah mencapai had maksimum tempahan",
+		ErrorKey(externalerrors.BookmarkLimitReached):    "Anda telah mencapai had maksimum penanda buku",
+		ErrorKey(externalerrors.LoanDurationExceeded):    "Pinjaman tidak boleh dilanjutkan melebihi tempoh maksimum",
+		ErrorKey(externalerrors.BookAlreadyLoaned):       "Anda telah meminjam satu naskhah buku ini",
+		ErrorKey(externalerrors.BookAlreadyReserved):     "Anda telah menempah satu naskhah buku ini",
+		ErrorKey(externalerrors.CopyOnLoan):              "Naskhah buku sedang dipinjam",
+		ErrorKey(externalerrors.CopyOnReserve):           "Naskhah buku sedang ditempah",
+		ErrorKey(externalerrors.CopyInTransit):           "Naskhah buku sedang dalam penghantaran",
+		ErrorKey(externalerrors.CopyNotOnLoan):           "Naskhah buku tidak dipinjam",
+		ErrorKey(externalerrors.CopyNotOnReserve):        "Naskhah buku tidak ditempah",
+		ErrorKey(externalerrors.CopyNotInTransit):        "Naskhah buku tidak dalam penghantaran",
+		ErrorKey(externalerrors.CopyNotAvailable):        "Tiada naskhah buku yang tersedia",
+		ErrorKey(externalerrors.CopyAtDestination):       "Naskhah buku sudah berada di cawangan destinasi",
+		ErrorKey(externalerrors.ReservationNotPending):   "Tempahan tidak lagi menunggu",
+		ErrorKey(externalerrors.TransferNotInTransit):    "Pemindahan tidak dalam penghantaran",
+		ErrorKey(externalerrors.BranchHasCopies):         "Cawangan masih mempunyai naskhah buku",
+		ErrorKey(externalerrors.BranchHasReservations):   "Cawangan masih menjadi cawangan pengambilan tempahan yang menunggu",
+		ErrorKey(externalerrors.BranchHasStaff):          "Cawangan masih mempunyai kakitangan yang ditugaskan",
+		ErrorKey(externalerrors.DefaultBranch):           "Cawangan lalai tidak boleh dipadam",
+		ErrorKey(externalerrors.VersionConflict):         "Rekod telah diubah oleh orang lain, semak perubahan dan cuba lagi",
+		ErrorKey(externalerrors.IdempotencyKeyInUse):     "Permintaan yang sama masih sedang diproses",
+		ErrorKey(externalerrors.IdempotencyKeyReused):    "Kunci idempotensi telah digunakan untuk permintaan yang lain",
+		ErrorKey(externalerrors.RateLimitExceeded):       "Terlalu banyak permintaan, sila tunggu sebentar dan cuba lagi",
+
+		FieldErrorKey(externalerrors.Required):      "%s diperlukan",
+		FieldErrorKey(externalerrors.InvalidFormat): "Format %s tidak sah",
+		FieldErrorKey(externalerrors.InvalidValue):  "%s tidak sah",
+		FieldErrorKey(externalerrors.AlreadyExists): "%s sudah wujud",
+		FieldErrorKey(externalerrors.Duplicated):    "%s berulang",
+		FieldErrorKey(externalerrors.MismatchedID):  "%s tidak sepadan dengan URL",
+	},
+}
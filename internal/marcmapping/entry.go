@@ -0,0 +1,62 @@
+// Package marcmapping maps MARC 21 bibliographic records onto books and book copies and back.
+//
+// Supported fields:
+//
+//	001      control number
+//	008      date of publication (07-10) and language (35-37)
+//	020 $a   ISBN
+//	041 $a   language
+//	050, 082 call number fallback for copies without 852 $h
+//	100, 110 main entry, 700, 710 added entries: $a name, $e or $4 relator
+//	245 $a $b title
+//	260, 264 $b publisher, $c date of publication
+//	541      acquisition of a copy, linked with $3 to the copy's accession number:
+//	         $a source, $d date (YYYYMMDD), $h price
+//	650, 651, 655 $a subjects
+//	852      one copy per field: $b branch name, $c shelf location, $h $i call number, $p accession number
+package marcmapping
+
+import (
+	"lms-backend/internal/model"
+)
+
+// Problem is an issue found while mapping a record.
+// Records with fatal problems cannot be imported.
+type Problem struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+	Fatal   bool   `json:"fatal"`
+}
+
+// Copy is a copy described by an 852 field. BranchName is resolved to a branch on import.
+type Copy struct {
+	model.BookCopy
+	BranchName string
+}
+
+// Entry is a record mapped onto a book and its copies.
+type Entry struct {
+	ControlNumber string
+	Book          *model.Book
+	Copies        []Copy
+	Problems      []Problem
+}
+
+func (e *Entry) Warn(field, message string) {
+	e.Problems = append(e.Problems, Problem{Field: field, Message: message})
+}
+
+func (e *Entry) Fail(field, message string) {
+	e.Problems = append(e.Problems, Problem{Field: field, Message: message, Fatal: true})
+}
+
+// HasFatalProblems reports whether the entry cannot be imported.
+func (e *Entry) HasFatalProblems() bool {
+	for _, p := range e.Problems {
+		if p.Fatal {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package marcmapping
+
+import (
+	"fmt"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/marc"
+	"strconv"
+)
+
+// FromBook maps a book onto a record.
+//
+// The book's contributors, publishers, subjects and copies with their home branch need to be preloaded.
+func FromBook(book *model.Book) *marc.Record {
+	record := marc.NewRecord()
+
+	language, known := toMARCLanguage(book.Language)
+
+	record.AddControlField("001", strconv.FormatUint(uint64(book.ID), 10))
+	record.AddControlField("005", book.UpdatedAt.Format("20060102150405.0"))
+	record.AddControlField("008", fmt.Sprintf(
+		"%ss%04d    xx %17s%3s d",
+		book.CreatedAt.Format("060102"), book.PublicationDate.Year(), "", language,
+	))
+
+	record.AddDataField("020", " ", " ", marc.Subfield{Code: "a", Value: book.ISBN})
+	if !known {
+		record.AddDataField("041", " ", " ", marc.Subfield{Code: "a", Value: book.Language})
+	}
+
+	// The first author is the main entry, everyone else is an added entry
+	addedEntries := []model.BookContributor{}
+	mainEntry := true
+	for _, bc := range book.BookContributors {
+		if bc.Contributor == nil {
+			continue
+		}
+
+		if mainEntry && bc.Role == model.ContributorRoleAuthor {
+			record.AddDataField("100", "1", " ",
+				marc.Subfield{Code: "a", Value: bc.Contributor.Name},
+				marc.Subfield{Code: "e", Value: bc.Role},
+			)
+			mainEntry = false
+			continue
+		}
+
+		addedEntries = append(addedEntries, bc)
+	}
+
+	record.AddDataField("245", "1", "0", marc.Subfield{Code: "a", Value: book.Title})
+
+	publication := []marc.Subfield{}
+	for _, publisher := range book.Publishers {
+		publication = append(publication, marc.Subfield{Code: "b", Value: publisher.Name})
+	}
+	publication = append(publication, marc.Subfield{Code: "c", Value: strconv.Itoa(book.PublicationDate.Year())})
+	record.AddDataField("264", " ", "1", publication...)
+
+	for _, copy := range book.BookCopies {
+		acquisition := []marc.Subfield{
+			{Code: "3", Value: copy.AccessionNumber},
+			{Code: "a", Value: copy.AcquisitionSource},
+		}
+		if copy.AcquisitionDate.Valid {
+			acquisition = append(acquisition, marc.Subfield{Code: "d", Value: copy.AcquisitionDate.Time.Format("20060102")})
+		}
+		if copy.AcquisitionPrice > 0 {
+			acquisition = append(acquisition, marc.Subfield{Code: "h", Value: copy.AcquisitionPrice.String()})
+		}
+
+		// $3 alone does not describe an acquisition
+		if len(acquisition) > 2 || acquisition[1].Value != "" {
+			record.AddDataField("541", " ", " ", acquisition...)
+		}
+	}
+
+	for _, subject := range book.Subjects {
+		record.AddDataField("650", " ", "4", marc.Subfield{Code: "a", Value: subject.Name})
+	}
+
+	for _, bc := range addedEntries {
+		record.AddDataField("700", "1", " ",
+			marc.Subfield{Code: "a", Value: bc.Contributor.Name},
+			marc.Subfield{Code: "e", Value: bc.Role},
+		)
+	}
+
+	for _, copy := range book.BookCopies {
+		branchName := ""
+		if copy.HomeBranch != nil {
+			branchName = copy.HomeBranch.Name
+		}
+
+		record.AddDataField("852", " ", " ",
+			marc.Subfield{Code: "b", Value: branchName},
+			marc.Subfield{Code: "c", Value: copy.ShelfLocation},
+			marc.Subfield{Code: "h", Value: copy.CallNumber},
+			marc.Subfield{Code: "p", Value: copy.AccessionNumber},
+		)
+	}
+
+	return record
+}
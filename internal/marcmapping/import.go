@@ -0,0 +1,295 @@
+package marcmapping
+
+import (
+	"database/sql"
+	"fmt"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/isbn"
+	"lms-backend/pkg/marc"
+	"lms-backend/pkg/money"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	yearReg = regexp.MustCompile(`\d{4}`)
+	// A trailing period is kept after an initial, e.g. "Tolkien, J. R. R."
+	initialReg = regexp.MustCompile(`(^|[\s.])\p{Lu}\.$`)
+)
+
+// relatorRoles maps MARC relator terms ($e) and codes ($4) onto contributor roles.
+var relatorRoles = map[string]model.ContributorRole{
+	"author":      model.ContributorRoleAuthor,
+	"aut":         model.ContributorRoleAuthor,
+	"editor":      model.ContributorRoleEditor,
+	"edt":         model.ContributorRoleEditor,
+	"translator":  model.ContributorRoleTranslator,
+	"trl":         model.ContributorRoleTranslator,
+	"illustrator": model.ContributorRoleIllustrator,
+	"ill":         model.ContributorRoleIllustrator,
+}
+
+// trimPunctuation removes the ISBD punctuation that ends MARC subfields.
+func trimPunctuation(value string) string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimRight(value, " ,;:/=")
+	if strings.HasSuffix(value, ".") && !initialReg.MatchString(value) {
+		value = strings.TrimSuffix(value, ".")
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// ToEntry maps a record onto a book and its copies.
+// Problems that prevent the record from being imported are reported as fatal.
+func ToEntry(record *marc.Record) *Entry {
+	entry := &Entry{
+		ControlNumber: strings.TrimSpace(record.ControlField("001")),
+		Book:          &model.Book{},
+	}
+
+	if len(record.Leader) == 24 && record.Leader[9] != 'a' {
+		entry.Warn("leader", "record is not Unicode encoded, non-ASCII characters may be garbled")
+	}
+
+	mapTitle(record, entry)
+	mapISBN(record, entry)
+	mapContributors(record, entry)
+	mapPublication(record, entry)
+	mapLanguage(record, entry)
+	mapSubjects(record, entry)
+	mapCopies(record, entry)
+
+	return entry
+}
+
+func mapTitle(record *marc.Record, entry *Entry) {
+	field := record.Fields("245")
+	if len(field) == 0 || trimPunctuation(field[0].Subfield("a")) == "" {
+		entry.Fail("245", "title is missing")
+		return
+	}
+
+	title := trimPunctuation(field[0].Subfield("a"))
+	if subtitle := trimPunctuation(field[0].Subfield("b")); subtitle != "" {
+		title = fmt.Sprintf("%s: %s", title, subtitle)
+	}
+
+	if !utf8.ValidString(title) {
+		entry.Fail("245", "title is not valid UTF-8")
+		return
+	}
+
+	entry.Book.Title = title
+}
+
+func mapISBN(record *marc.Record, entry *Entry) {
+	candidates := []string{}
+	for _, field := range record.Fields("020") {
+		for _, value := range field.SubfieldValues("a") {
+			// Qualifiers may follow the ISBN, e.g. "0306406152 (pbk.)"
+			if parts := strings.Fields(value); len(parts) > 0 {
+				candidates = append(candidates, parts[0])
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		entry.Fail("020", "isbn is missing")
+		return
+	}
+
+	for _, candidate := range candidates {
+		normalized, err := isbn.Normalize(candidate)
+		if err != nil {
+			entry.Warn("020", fmt.Sprintf("%s is not a valid isbn: %s", candidate, err))
+			continue
+		}
+
+		entry.Book.ISBN = normalized
+		return
+	}
+
+	entry.Fail("020", "no valid isbn")
+}
+
+func mapContributors(record *marc.Record, entry *Entry) {
+	for _, tag := range []string{"100", "110", "700", "710"} {
+		for _, field := range record.Fields(tag) {
+			name := trimPunctuation(field.Subfield("a"))
+			if name == "" {
+				entry.Warn(tag, "contributor without a name is skipped")
+				continue
+			}
+
+			role := model.ContributorRoleAuthor
+			relator := trimPunctuation(field.Subfield("e"))
+			if relator == "" {
+				relator = trimPunctuation(field.Subfield("4"))
+			}
+			if relator != "" {
+				mapped, ok := relatorRoles[strings.ToLower(relator)]
+				if ok {
+					role = mapped
+				} else {
+					entry.Warn(tag, fmt.Sprintf("unknown relator %q for %s, treated as author", relator, name))
+				}
+			}
+
+			entry.Book.BookContributors = append(entry.Book.BookContributors, model.BookContributor{
+				Contributor: &model.Contributor{Name: name},
+				Role:        role,
+			})
+		}
+	}
+
+	if len(entry.Book.BookContributors) == 0 {
+		entry.Fail("100", "no author or other contributor")
+	}
+}
+
+func mapPublication(record *marc.Record, entry *Entry) {
+	// RDA records use 264 with second indicator 1 for publication, older records use 260
+	fields := []marc.DataField{}
+	for _, field := range record.Fields("264") {
+		if field.Ind2 == "1" {
+			fields = append(fields, field)
+		}
+	}
+	fields = append(fields, record.Fields("260")...)
+
+	year := ""
+	for _, field := range fields {
+		for _, name := range field.SubfieldValues("b") {
+			if name = trimPunctuation(name); name != "" {
+				entry.Book.Publishers = append(entry.Book.Publishers, model.Publisher{Name: name})
+			}
+		}
+
+		if year == "" {
+			year = yearReg.FindString(field.Subfield("c"))
+		}
+	}
+
+	if len(entry.Book.Publishers) == 0 {
+		entry.Fail("264", "publisher is missing")
+	}
+
+	if fixed := record.ControlField("008"); year == "" && len(fixed) >= 11 {
+		year = yearReg.FindString(fixed[7:11])
+	}
+
+	if year == "" {
+		entry.Fail("264", "date of publication is missing")
+		return
+	}
+
+	//nolint:errcheck // year is four digits
+	y, _ := strconv.Atoi(year)
+	entry.Book.PublicationDate = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func mapLanguage(record *marc.Record, entry *Entry) {
+	field, code := "041", record.SubfieldValue("041", "a")
+	if fixed := record.ControlField("008"); code == "" && len(fixed) >= 38 {
+		field, code = "008", fixed[35:38]
+	}
+
+	if strings.TrimSpace(code) == "" || code == undeterminedLanguage {
+		entry.Fail("008", "language is missing")
+		return
+	}
+
+	language, ok := FromMARCLanguage(code)
+	if !ok {
+		entry.Warn(field, fmt.Sprintf("unknown language code %q is stored as is", code))
+		language = strings.ToLower(strings.TrimSpace(code))
+	}
+
+	entry.Book.Language = language
+}
+
+func mapSubjects(record *marc.Record, entry *Entry) {
+	seen := map[string]bool{}
+	for _, tag := range []string{"650", "651", "655"} {
+		for _, field := range record.Fields(tag) {
+			name := trimPunctuation(field.Subfield("a"))
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			entry.Book.Subjects = append(entry.Book.Subjects, model.Subject{Name: name})
+		}
+	}
+
+	if len(entry.Book.Subjects) == 0 {
+		entry.Fail("650", "no subjects")
+	}
+}
+
+func mapCopies(record *marc.Record, entry *Entry) {
+	recordCallNumber := strings.TrimSpace(strings.Join([]string{
+		record.SubfieldValue("050", "a"), record.SubfieldValue("050", "b"),
+	}, " "))
+	if recordCallNumber == "" {
+		recordCallNumber = record.SubfieldValue("082", "a")
+	}
+
+	acquisitions := map[string]marc.DataField{}
+	for _, field := range record.Fields("541") {
+		acquisitions[field.Subfield("3")] = field
+	}
+
+	for _, field := range record.Fields("852") {
+		copy := Copy{
+			BookCopy: model.BookCopy{
+				AccessionNumber: strings.TrimSpace(field.Subfield("p")),
+				ShelfLocation:   trimPunctuation(field.Subfield("c")),
+				CallNumber: strings.TrimSpace(strings.Join([]string{
+					field.Subfield("h"), field.Subfield("i"),
+				}, " ")),
+			},
+			BranchName: trimPunctuation(field.Subfield("b")),
+		}
+
+		if copy.CallNumber == "" {
+			copy.CallNumber = recordCallNumber
+		}
+
+		if copy.BranchName == "" {
+			entry.Warn("852", "copy without $b branch is placed at the default branch")
+		}
+
+		if acquisition, ok := acquisitions[copy.AccessionNumber]; ok && copy.AccessionNumber != "" {
+			mapAcquisition(acquisition, &copy, entry)
+		}
+
+		entry.Copies = append(entry.Copies, copy)
+	}
+}
+
+func mapAcquisition(field marc.DataField, copy *Copy, entry *Entry) {
+	copy.AcquisitionSource = trimPunctuation(field.Subfield("a"))
+
+	if date := field.Subfield("d"); date != "" {
+		t, err := time.Parse("20060102", strings.TrimSpace(date))
+		if err != nil {
+			entry.Warn("541", fmt.Sprintf("acquisition date %q is not in YYYYMMDD format", date))
+		} else {
+			copy.AcquisitionDate = sql.NullTime{Time: t, Valid: true}
+		}
+	}
+
+	if price := field.Subfield("h"); price != "" {
+		p, err := money.Parse(strings.Trim(price, " $"))
+		if err != nil {
+			entry.Warn("541", fmt.Sprintf("acquisition price %q is not valid: %s", price, err))
+		} else {
+			copy.AcquisitionPrice = p
+		}
+	}
+}
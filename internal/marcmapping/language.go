@@ -0,0 +1,61 @@
+package marcmapping
+
+import (
+	"strings"
+)
+
+// MARC records use three-letter MARC language codes while books store two-letter codes.
+var languageCodes = map[string]string{
+	"ara": "ar",
+	"chi": "zh",
+	"dut": "nl",
+	"eng": "en",
+	"fre": "fr",
+	"ger": "de",
+	"hin": "hi",
+	"ind": "id",
+	"ita": "it",
+	"jpn": "ja",
+	"khm": "km",
+	"kor": "ko",
+	"may": "ms",
+	"por": "pt",
+	"rus": "ru",
+	"spa": "es",
+	"tam": "ta",
+	"tha": "th",
+	"vie": "vi",
+}
+
+const (
+	undeterminedLanguage = "und"
+)
+
+// FromMARCLanguage returns the book language for a MARC language code
+// and whether the code is known.
+func FromMARCLanguage(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) == 2 {
+		return code, true
+	}
+
+	language, ok := languageCodes[code]
+	return language, ok
+}
+
+// toMARCLanguage returns the MARC language code for a book language
+// and whether the language is known.
+func toMARCLanguage(language string) (string, bool) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	for code, l := range languageCodes {
+		if l == language {
+			return code, true
+		}
+	}
+
+	if _, ok := languageCodes[language]; ok {
+		return language, true
+	}
+
+	return undeterminedLanguage, false
+}
@@ -0,0 +1,78 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"lms-backend/internal/googlebooks"
+	"strings"
+)
+
+const defaultLimit = 10
+
+// GoogleBooksProvider searches the Google Books API.
+type GoogleBooksProvider struct {
+	Client *googlebooks.Client
+}
+
+func NewGoogleBooksProvider(client *googlebooks.Client) *GoogleBooksProvider {
+	return &GoogleBooksProvider{Client: client}
+}
+
+func (p *GoogleBooksProvider) Name() string {
+	return "google_books"
+}
+
+// googleQuery builds a query in the Google Books syntax. Phrases are quoted so that
+// every word is matched against the field.
+func googleQuery(query Query) string {
+	terms := []string{}
+	for _, term := range []struct {
+		keyword string
+		value   string
+	}{
+		{"intitle", query.Title},
+		{"inauthor", query.Author},
+		{"inpublisher", query.Publisher},
+		{"isbn", strings.ReplaceAll(query.ISBN, "-", "")},
+	} {
+		value := strings.TrimSpace(strings.ReplaceAll(term.value, `"`, ""))
+		if value != "" {
+			terms = append(terms, fmt.Sprintf("%s:%q", term.keyword, value))
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
+
+func (p *GoogleBooksProvider) Search(ctx context.Context, query Query) ([]Result, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	response, err := p.Client.SearchVolumes(ctx, googleQuery(query), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for i := range response.Items {
+		item := &response.Items[i]
+		book := googlebooks.ToBook(item)
+
+		results = append(results, Result{
+			ISBN:            book.ISBN,
+			Title:           book.Title,
+			Authors:         item.VolumeInfo.Authors,
+			Publishers:      []string{strings.Trim(item.VolumeInfo.Publisher, `"`)},
+			PublicationDate: book.PublicationDate,
+			Subjects:        item.VolumeInfo.Categories,
+			Language:        book.Language,
+			Description:     item.VolumeInfo.Description,
+			CoverURL:        googlebooks.CoverURL(item),
+			Sources:         []Source{{Provider: p.Name(), ID: item.ID}},
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,127 @@
+package metadata
+
+import (
+	"context"
+	"lms-backend/internal/googlebooks"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newGoogleBooksServer serves the fixture for every search, after checking the search.
+func newGoogleBooksServer(t *testing.T, wantQuery, wantMaxResults string) *httptest.Server {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/googlebooks_search.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/volumes" {
+			http.NotFound(w, r)
+			return
+		}
+		if q := r.URL.Query().Get("q"); wantQuery != "" && q != wantQuery {
+			t.Errorf("q = %s, want %s", q, wantQuery)
+		}
+		if maxResults := r.URL.Query().Get("maxResults"); maxResults != wantMaxResults {
+			t.Errorf("maxResults = %s, want %s", maxResults, wantMaxResults)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixture)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newGoogleBooksTestProvider(serverURL string) *GoogleBooksProvider {
+	client := googlebooks.NewClient(nil)
+	client.BaseURL = serverURL
+	client.APIKey = "test-key"
+
+	return NewGoogleBooksProvider(client)
+}
+
+func TestGoogleQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query Query
+		want  string
+	}{
+		{name: "title", query: Query{Title: "the go programming language"}, want: `intitle:"the go programming language"`},
+		{
+			name:  "every field",
+			query: Query{Title: "go", Author: "Kernighan", Publisher: "Addison-Wesley", ISBN: "978-0-13-419044-0"},
+			want:  `intitle:"go" inauthor:"Kernighan" inpublisher:"Addison-Wesley" isbn:"9780134190440"`,
+		},
+		{name: "quotes removed", query: Query{Title: `"go" in action `}, want: `intitle:"go in action"`},
+		{name: "blank fields skipped", query: Query{Title: " ", Author: "Pike"}, want: `inauthor:"Pike"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := googleQuery(tt.query); got != tt.want {
+				t.Errorf("googleQuery() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoogleBooksProviderSearch(t *testing.T) {
+	server := newGoogleBooksServer(t, `intitle:"google story" isbn:"055380457X"`, "3")
+	provider := newGoogleBooksTestProvider(server.URL)
+
+	results, err := provider.Search(context.Background(), Query{Title: "google story", ISBN: "0-553-80457-X", Limit: 3})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Search() returned %d results, want 2", len(results))
+	}
+
+	want := Result{
+		ISBN:            "9780553804577",
+		Title:           "The  Google Story: Inside the Hottest Business",
+		Authors:         []string{"David A. Vise", "Mark Malseed"},
+		Publishers:      []string{"Random House"},
+		PublicationDate: time.Date(2005, time.November, 15, 0, 0, 0, 0, time.UTC),
+		Subjects:        []string{"Business & Economics"},
+		Language:        "en",
+		Description:     " The story of Google. ",
+		CoverURL:        "https://books.google.com/books/content?id=zyTCAlFPjgYC&zoom=1",
+		Sources:         []Source{{Provider: "google_books", ID: "zyTCAlFPjgYC"}},
+	}
+	if !reflect.DeepEqual(results[0], want) {
+		t.Errorf("Search()[0] = %+v, want %+v", results[0], want)
+	}
+
+	if results[1].ISBN != "" || results[1].Language != "ms" || results[1].PublicationDate.Year() != 1999 {
+		t.Errorf("Search()[1] = %+v, want no isbn, ms and 1999", results[1])
+	}
+}
+
+func TestGoogleBooksProviderDefaultLimit(t *testing.T) {
+	server := newGoogleBooksServer(t, "", "10")
+
+	if _, err := newGoogleBooksTestProvider(server.URL).Search(context.Background(), Query{Title: "go"}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+}
+
+func TestGoogleBooksProviderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"message": "API key not valid"}}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	results, err := newGoogleBooksTestProvider(server.URL).Search(context.Background(), Query{Title: "go"})
+	if err == nil {
+		t.Fatalf("Search() = %+v, want an error", results)
+	}
+}
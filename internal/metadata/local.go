@@ -0,0 +1,86 @@
+package metadata
+
+import (
+	"context"
+	"lms-backend/internal/dataaccess/book"
+	"lms-backend/internal/database"
+	"lms-backend/internal/model"
+	collection "lms-backend/pkg/collectionquery"
+	"lms-backend/pkg/isbn"
+	"lms-backend/util/sliceutil"
+	"strconv"
+)
+
+// LocalProvider searches the library's own catalogue, so that staff can see a book is
+// already catalogued before importing it again.
+type LocalProvider struct{}
+
+func NewLocalProvider() *LocalProvider {
+	return &LocalProvider{}
+}
+
+func (p *LocalProvider) Name() string {
+	return "local"
+}
+
+func (p *LocalProvider) Search(ctx context.Context, query Query) ([]Result, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	filters := book.Filters()
+	db := database.GetDB().WithContext(ctx)
+
+	if query.Title != "" {
+		db = db.Scopes(filters["title"].Apply(like(query.Title)))
+	}
+	if query.Author != "" {
+		db = db.Scopes(filters["author"].Apply(like(query.Author)))
+	}
+	if query.Publisher != "" {
+		db = db.Scopes(filters["publisher"].Apply(like(query.Publisher)))
+	}
+	if query.ISBN != "" {
+		value := query.ISBN
+		if normalized, err := isbn.Normalize(value); err == nil {
+			value = normalized
+		}
+		db = db.Scopes(filters["isbn"].Apply(like(value)))
+	}
+
+	books, err := book.ListWithEntities(db.Limit(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutil.Map(books, p.toResult), nil
+}
+
+// like is the condition of filter[<key>][like]=<value>.
+func like(value string) collection.Condition {
+	return collection.Condition{Operator: collection.Like, Values: []interface{}{value}}
+}
+
+func (p *LocalProvider) toResult(b model.Book) Result {
+	authors := sliceutil.Filter(b.BookContributors, func(bc model.BookContributor) bool {
+		return bc.Role == model.ContributorRoleAuthor && bc.Contributor != nil
+	})
+
+	return Result{
+		ISBN:  b.ISBN,
+		Title: b.Title,
+		Authors: sliceutil.Map(authors, func(bc model.BookContributor) string {
+			return bc.Contributor.Name
+		}),
+		Publishers: sliceutil.Map(b.Publishers, func(p model.Publisher) string {
+			return p.Name
+		}),
+		PublicationDate: b.PublicationDate,
+		Subjects: sliceutil.Map(b.Subjects, func(s model.Subject) string {
+			return s.Name
+		}),
+		Language: b.Language,
+		Sources:  []Source{{Provider: p.Name(), ID: strconv.FormatUint(uint64(b.ID), 10)}},
+	}
+}
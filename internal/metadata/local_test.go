@@ -0,0 +1,166 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"lms-backend/internal/database"
+	"lms-backend/internal/model"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeTable is the rows a fake database returns when a table is selected from.
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDB is a database that returns the rows of the first table a query selects from,
+// and records the queries with their arguments.
+type fakeDB struct {
+	tables map[string]fakeTable
+
+	mu      sync.Mutex
+	queries []string
+	args    [][]driver.NamedValue
+}
+
+var fromTableReg = regexp.MustCompile(`FROM "(\w+)"`)
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c *fakeConn) Commit() error                       { return nil }
+func (c *fakeConn) Rollback() error                     { return nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.queries = append(c.db.queries, query)
+	c.db.args = append(c.db.args, args)
+
+	table := fakeTable{}
+	if match := fromTableReg.FindStringSubmatch(query); match != nil {
+		table = c.db.tables[match[1]]
+	}
+
+	return &fakeRows{table: table}, nil
+}
+
+type fakeRows struct {
+	table fakeTable
+	next  int
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.table.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+// useFakeDB makes database.GetDB return a connection to db for the duration of the test.
+func useFakeDB(t *testing.T, db *fakeDB) {
+	t.Helper()
+
+	gormDB, err := gorm.Open(
+		postgres.New(postgres.Config{Conn: sql.OpenDB(db)}),
+		&gorm.Config{Logger: logger.Discard},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previous := database.DB
+	database.DB = gormDB
+	t.Cleanup(func() { database.DB = previous })
+}
+
+func TestLocalProviderSearch(t *testing.T) {
+	published := time.Date(2005, time.November, 15, 0, 0, 0, 0, time.UTC)
+	db := &fakeDB{tables: map[string]fakeTable{
+		model.BookTableName: {
+			columns: []string{"id", "title", "author", "isbn", "publisher", "publication_date", "genre", "language"},
+			rows: [][]driver.Value{
+				{int64(7), "The Google Story", "David A. Vise", "9780553804577", "Random House", published, "Business", "en"},
+			},
+		},
+		"book_contributors": {
+			columns: []string{"id", "book_id", "contributor_id", "role", "position"},
+			rows: [][]driver.Value{
+				{int64(1), int64(7), int64(10), model.ContributorRoleAuthor, int64(0)},
+				{int64(2), int64(7), int64(11), model.ContributorRoleEditor, int64(1)},
+				{int64(3), int64(7), int64(12), model.ContributorRoleAuthor, int64(2)},
+			},
+		},
+		"contributors": {
+			columns: []string{"id", "name"},
+			rows: [][]driver.Value{
+				{int64(10), "David A. Vise"},
+				{int64(11), "An Editor"},
+				{int64(12), "Mark Malseed"},
+			},
+		},
+	}}
+	useFakeDB(t, db)
+
+	results, err := NewLocalProvider().Search(context.Background(), Query{
+		Title: "google",
+		ISBN:  "0-553-80457-X",
+		Limit: 3,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	want := []Result{{
+		ISBN:            "9780553804577",
+		Title:           "The Google Story",
+		Authors:         []string{"David A. Vise", "Mark Malseed"},
+		Publishers:      []string{},
+		PublicationDate: published,
+		Subjects:        []string{},
+		Language:        "en",
+		Sources:         []Source{{Provider: "local", ID: "7"}},
+	}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Search() = %+v, want %+v", results, want)
+	}
+
+	query, args := db.queries[0], db.args[0]
+	if !strings.Contains(query, "title ILIKE") || !strings.Contains(query, "isbn ILIKE") || !strings.Contains(query, "LIMIT 3") {
+		t.Errorf("query = %s, want title and isbn filters limited to 3 books", query)
+	}
+
+	values := []driver.Value{}
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+	if !reflect.DeepEqual(values, []driver.Value{"%google%", "%9780553804577%"}) {
+		t.Errorf("args = %v, want the title and the normalized isbn", values)
+	}
+}
@@ -0,0 +1,158 @@
+// nolint:tagliatelle
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"lms-backend/internal/marcmapping"
+	"lms-backend/pkg/httpretry"
+	"lms-backend/pkg/isbn"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OpenLibraryBaseURL      = "https://openlibrary.org"
+	OpenLibraryCoverBaseURL = "https://covers.openlibrary.org"
+	openLibrarySearchFields = "key,title,subtitle,author_name,publisher,first_publish_year,subject,language,isbn,cover_i"
+)
+
+// OpenLibraryProvider searches the Open Library search API.
+type OpenLibraryProvider struct {
+	HTTPClient   *http.Client
+	BaseURL      string
+	CoverBaseURL string
+}
+
+func NewOpenLibraryProvider(httpClient *http.Client) *OpenLibraryProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &OpenLibraryProvider{
+		HTTPClient:   httpClient,
+		BaseURL:      OpenLibraryBaseURL,
+		CoverBaseURL: OpenLibraryCoverBaseURL,
+	}
+}
+
+func (p *OpenLibraryProvider) Name() string {
+	return "open_library"
+}
+
+type openLibraryResponse struct {
+	Docs []openLibraryDoc `json:"docs"`
+}
+
+type openLibraryDoc struct {
+	Key              string   `json:"key"`
+	Title            string   `json:"title"`
+	Subtitle         string   `json:"subtitle"`
+	AuthorName       []string `json:"author_name"`
+	Publisher        []string `json:"publisher"`
+	FirstPublishYear int      `json:"first_publish_year"`
+	Subject          []string `json:"subject"`
+	Language         []string `json:"language"` // MARC language codes
+	ISBN             []string `json:"isbn"`
+	CoverID          int      `json:"cover_i"`
+}
+
+func (p *OpenLibraryProvider) Search(ctx context.Context, query Query) ([]Result, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	params := url.Values{}
+	params.Set("fields", openLibrarySearchFields)
+	params.Set("limit", strconv.Itoa(limit))
+	for key, value := range map[string]string{
+		"title":     query.Title,
+		"author":    query.Author,
+		"publisher": query.Publisher,
+		"isbn":      strings.ReplaceAll(query.ISBN, "-", ""),
+	} {
+		if value = strings.TrimSpace(value); value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	res, err := httpretry.Get(ctx, p.HTTPClient, fmt.Sprintf("%s/search.json?%s", p.BaseURL, params.Encode()), httpretry.DefaultAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("open library responded with status %d", res.StatusCode)
+	}
+
+	var response openLibraryResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("could not decode open library response: %w", err)
+	}
+
+	results := []Result{}
+	for _, doc := range response.Docs {
+		results = append(results, p.toResult(doc))
+	}
+
+	return results, nil
+}
+
+func (p *OpenLibraryProvider) toResult(doc openLibraryDoc) Result {
+	result := Result{
+		ISBN:       preferredISBN(doc.ISBN),
+		Title:      doc.Title,
+		Authors:    doc.AuthorName,
+		Publishers: doc.Publisher,
+		Subjects:   doc.Subject,
+		Sources:    []Source{{Provider: p.Name(), ID: doc.Key}},
+	}
+
+	if doc.Subtitle != "" {
+		result.Title += ": " + doc.Subtitle
+	}
+
+	if doc.FirstPublishYear > 0 {
+		result.PublicationDate = time.Date(doc.FirstPublishYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, code := range doc.Language {
+		if language, ok := marcmapping.FromMARCLanguage(code); ok {
+			result.Language = language
+			break
+		}
+	}
+
+	if doc.CoverID > 0 {
+		result.CoverURL = fmt.Sprintf("%s/b/id/%d-L.jpg", p.CoverBaseURL, doc.CoverID)
+	}
+
+	return result
+}
+
+// preferredISBN picks the first valid ISBN-13 of a work, falling back to the first valid ISBN.
+// A work lists the ISBNs of all its editions.
+func preferredISBN(isbns []string) string {
+	var fallback string
+	for _, s := range isbns {
+		if !isbn.IsValid(s) {
+			continue
+		}
+
+		if len(s) == 13 {
+			return s
+		}
+
+		if fallback == "" {
+			fallback = s
+		}
+	}
+
+	return fallback
+}
@@ -0,0 +1,156 @@
+package metadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newOpenLibraryTestProvider searches a fake Open Library serving the fixture, after
+// checking the search parameters.
+func newOpenLibraryTestProvider(t *testing.T, wantParams url.Values) *OpenLibraryProvider {
+	t.Helper()
+
+	fixture, err := os.ReadFile("testdata/openlibrary_search.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search.json" {
+			http.NotFound(w, r)
+			return
+		}
+		for key := range wantParams {
+			if got := r.URL.Query().Get(key); got != wantParams.Get(key) {
+				t.Errorf("%s = %q, want %q", key, got, wantParams.Get(key))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(fixture)
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewOpenLibraryProvider(server.Client())
+	provider.BaseURL = server.URL
+	provider.CoverBaseURL = "https://covers.example.com"
+
+	return provider
+}
+
+func TestOpenLibraryProviderSearch(t *testing.T) {
+	provider := newOpenLibraryTestProvider(t, url.Values{
+		"title":     {"google story"},
+		"author":    {""},
+		"publisher": {"Random House"},
+		"isbn":      {"9780553804577"},
+		"limit":     {"5"},
+		"fields":    {openLibrarySearchFields},
+	})
+
+	results, err := provider.Search(context.Background(), Query{
+		Title:     " google story ",
+		Publisher: "Random House",
+		ISBN:      "978-0-553-80457-7",
+		Limit:     5,
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Search() returned %d results, want 2", len(results))
+	}
+
+	want := []Result{
+		{
+			ISBN:            "9780553804577",
+			Title:           "The Google story",
+			Authors:         []string{"David A. Vise", "Mark Malseed", "David A. Vise"},
+			Publishers:      []string{"Bantam Dell", "Random House"},
+			PublicationDate: time.Date(2005, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Subjects:        []string{"Google (Firm)", "Internet industry"},
+			Language:        "en",
+			CoverURL:        "https://covers.example.com/b/id/240726-L.jpg",
+			Sources:         []Source{{Provider: "open_library", ID: "/works/OL5733266W"}},
+		},
+		{
+			ISBN:     "9676530611",
+			Title:    "Sejarah Melayu: The Malay Annals",
+			Language: "ms",
+			Sources:  []Source{{Provider: "open_library", ID: "/works/OL1W"}},
+		},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Search() = %+v, want %+v", results, want)
+	}
+}
+
+func TestOpenLibraryProviderDefaultLimit(t *testing.T) {
+	provider := newOpenLibraryTestProvider(t, url.Values{"limit": {"10"}, "title": {"go"}})
+
+	if _, err := provider.Search(context.Background(), Query{Title: "go"}); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+}
+
+func TestOpenLibraryProviderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			name: "error status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.NotFound(w, r)
+			},
+		},
+		{
+			name: "malformed response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"docs": [`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			provider := NewOpenLibraryProvider(server.Client())
+			provider.BaseURL = server.URL
+
+			results, err := provider.Search(context.Background(), Query{Title: "go"})
+			if err == nil {
+				t.Fatalf("Search() = %+v, want an error", results)
+			}
+		})
+	}
+}
+
+func TestPreferredISBN(t *testing.T) {
+	tests := []struct {
+		name  string
+		isbns []string
+		want  string
+	}{
+		{name: "isbn-13 preferred", isbns: []string{"055380457X", "9780553804577"}, want: "9780553804577"},
+		{name: "first valid isbn-10", isbns: []string{"0553804571", "055380457X", "0306406152"}, want: "055380457X"},
+		{name: "invalid only", isbns: []string{"123", "9780553804578"}, want: ""},
+		{name: "none", isbns: nil, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := preferredISBN(tt.isbns); got != tt.want {
+				t.Errorf("preferredISBN(%v) = %q, want %q", tt.isbns, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Package metadata looks up bibliographic metadata across several providers.
+//
+// Every provider returns results in the same normalized form. A Searcher asks all providers
+// at once, each with its own timeout and circuit breaker, and merges results that share an ISBN.
+package metadata
+
+import (
+	"context"
+	"lms-backend/internal/googlebooks"
+	"lms-backend/pkg/isbn"
+	"strings"
+	"time"
+)
+
+// MetadataProvider looks up books in one source of bibliographic metadata.
+type MetadataProvider interface {
+	// Name identifies the provider in results and errors.
+	Name() string
+	Search(ctx context.Context, query Query) ([]Result, error)
+}
+
+type Query struct {
+	Title     string
+	Author    string
+	Publisher string
+	ISBN      string
+	Limit     int
+}
+
+func (q Query) IsEmpty() bool {
+	return q.Title == "" && q.Author == "" && q.Publisher == "" && q.ISBN == ""
+}
+
+// Source is where a result was found.
+type Source struct {
+	Provider string
+	ID       string // ID of the record at the provider
+}
+
+// Result is a book as described by one or more providers.
+type Result struct {
+	ISBN            string // ISBN-13 when the provider's ISBN is valid
+	Title           string
+	Authors         []string
+	Publishers      []string
+	PublicationDate time.Time // Zero when unknown
+	Subjects        []string
+	Language        string // ISO 639-1 code
+	Description     string
+	CoverURL        string
+	Sources         []Source
+}
+
+// normalize tidies up the result as returned by a provider.
+func (r *Result) normalize() {
+	r.Title = strings.Join(strings.Fields(r.Title), " ")
+	r.Authors = googlebooks.NormalizeNames(r.Authors)
+	r.Publishers = googlebooks.NormalizeNames(r.Publishers)
+	r.Subjects = googlebooks.NormalizeNames(r.Subjects)
+	r.Language = strings.ToLower(strings.TrimSpace(r.Language))
+	r.Description = strings.TrimSpace(r.Description)
+
+	if normalized, err := isbn.Normalize(r.ISBN); err == nil {
+		r.ISBN = normalized
+	}
+}
+
+// merge fills in what r is missing from other, which describes the same book.
+func (r *Result) merge(other Result) {
+	if r.Title == "" {
+		r.Title = other.Title
+	}
+
+	if (time.Time{}).Equal(r.PublicationDate) {
+		r.PublicationDate = other.PublicationDate
+	}
+
+	if r.Language == "" {
+		r.Language = other.Language
+	}
+
+	if r.Description == "" {
+		r.Description = other.Description
+	}
+
+	if r.CoverURL == "" {
+		r.CoverURL = other.CoverURL
+	}
+
+	r.Authors = googlebooks.NormalizeNames(append(r.Authors, other.Authors...))
+	r.Publishers = googlebooks.NormalizeNames(append(r.Publishers, other.Publishers...))
+	r.Subjects = googlebooks.NormalizeNames(append(r.Subjects, other.Subjects...))
+	r.Sources = append(r.Sources, other.Sources...)
+}
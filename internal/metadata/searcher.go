@@ -0,0 +1,125 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"lms-backend/pkg/circuitbreaker"
+	"lms-backend/pkg/isbn"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTimeout          = 5 * time.Second
+	DefaultFailureThreshold = 5
+	DefaultCoolDown         = time.Minute
+)
+
+// ProviderError is a provider that could not be searched.
+type ProviderError struct {
+	Provider string
+	Err      error
+}
+
+func (e ProviderError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Provider, e.Err)
+}
+
+type guardedProvider struct {
+	provider MetadataProvider
+	timeout  time.Duration
+	breaker  *circuitbreaker.Breaker
+}
+
+// Searcher searches several providers at once. Providers are searched in the order they were
+// added, which is also the order of preference when merging results.
+type Searcher struct {
+	providers []guardedProvider
+}
+
+func NewSearcher() *Searcher {
+	return &Searcher{}
+}
+
+// Add registers a provider with its own timeout and a circuit breaker that opens after
+// DefaultFailureThreshold consecutive failures for DefaultCoolDown.
+func (s *Searcher) Add(provider MetadataProvider, timeout time.Duration) *Searcher {
+	s.providers = append(s.providers, guardedProvider{
+		provider: provider,
+		timeout:  timeout,
+		breaker:  circuitbreaker.New(DefaultFailureThreshold, DefaultCoolDown),
+	})
+
+	return s
+}
+
+func (g *guardedProvider) search(ctx context.Context, query Query) ([]Result, error) {
+	var results []Result
+	err := g.breaker.Do(func() error {
+		ctx, cancel := context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+
+		var err error
+		results, err = g.provider.Search(ctx, query)
+		return err
+	})
+
+	return results, err
+}
+
+// Search asks every provider and merges their results. Providers that fail are reported
+// alongside the results of the others.
+func (s *Searcher) Search(ctx context.Context, query Query) ([]Result, []ProviderError) {
+	resultsByProvider := make([][]Result, len(s.providers))
+	errs := make([]error, len(s.providers))
+
+	var wg sync.WaitGroup
+	for i := range s.providers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resultsByProvider[i], errs[i] = s.providers[i].search(ctx, query)
+		}(i)
+	}
+	wg.Wait()
+
+	var providerErrs []ProviderError
+	for i, err := range errs {
+		if err != nil {
+			providerErrs = append(providerErrs, ProviderError{
+				Provider: s.providers[i].provider.Name(),
+				Err:      err,
+			})
+		}
+	}
+
+	return mergeResults(resultsByProvider), providerErrs
+}
+
+// mergeResults de-duplicates results by ISBN, keeping the order in which they were first found.
+// Results without a valid ISBN cannot be matched and are kept as they are.
+func mergeResults(resultsByProvider [][]Result) []Result {
+	merged := []Result{}
+	indexByISBN := map[string]int{}
+
+	for _, results := range resultsByProvider {
+		for _, result := range results {
+			result.normalize()
+
+			if !isbn.IsValid(result.ISBN) {
+				merged = append(merged, result)
+				continue
+			}
+
+			if i, ok := indexByISBN[result.ISBN]; ok {
+				merged[i].merge(result)
+				continue
+			}
+
+			indexByISBN[result.ISBN] = len(merged)
+			merged = append(merged, result)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,160 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"lms-backend/pkg/circuitbreaker"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearcherMergesResultsByISBN(t *testing.T) {
+	googleServer := newGoogleBooksServer(t, "", "10")
+	searcher := NewSearcher().
+		Add(newGoogleBooksTestProvider(googleServer.URL), DefaultTimeout).
+		Add(newOpenLibraryTestProvider(t, nil), DefaultTimeout)
+
+	results, errs := searcher.Search(context.Background(), Query{Title: "google story"})
+	if len(errs) != 0 {
+		t.Fatalf("Search() errors = %v", errs)
+	}
+
+	titles := []string{}
+	for _, result := range results {
+		titles = append(titles, result.Title)
+	}
+	wantTitles := []string{
+		"The Google Story: Inside the Hottest Business",
+		"Untitled Manuscript",
+		"Sejarah Melayu: The Malay Annals",
+	}
+	if !reflect.DeepEqual(titles, wantTitles) {
+		t.Fatalf("titles = %q, want %q", titles, wantTitles)
+	}
+
+	// Google Books is preferred, and Open Library fills in what it is missing
+	merged := results[0]
+	want := Result{
+		ISBN:            "9780553804577",
+		Title:           "The Google Story: Inside the Hottest Business",
+		Authors:         []string{"David A. Vise", "Mark Malseed"},
+		Publishers:      []string{"Random House", "Bantam Dell"},
+		PublicationDate: time.Date(2005, time.November, 15, 0, 0, 0, 0, time.UTC),
+		Subjects:        []string{"Business & Economics", "Google (Firm)", "Internet industry"},
+		Language:        "en",
+		Description:     "The story of Google.",
+		CoverURL:        "https://books.google.com/books/content?id=zyTCAlFPjgYC&zoom=1",
+		Sources: []Source{
+			{Provider: "google_books", ID: "zyTCAlFPjgYC"},
+			{Provider: "open_library", ID: "/works/OL5733266W"},
+		},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("merged result = %+v, want %+v", merged, want)
+	}
+
+	if results[2].ISBN != "9789676530615" {
+		t.Errorf("ISBN = %s, want the ISBN-10 converted to ISBN-13", results[2].ISBN)
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	results := mergeResults([][]Result{
+		{
+			{ISBN: "978-0-306-40615-7", Title: "First", Sources: []Source{{Provider: "a", ID: "1"}}},
+			{ISBN: "", Title: "No ISBN", Sources: []Source{{Provider: "a", ID: "2"}}},
+		},
+		{
+			{ISBN: "0306406152", Title: "Second", Language: "EN", Sources: []Source{{Provider: "b", ID: "3"}}},
+			{ISBN: "", Title: "No ISBN", Sources: []Source{{Provider: "b", ID: "4"}}},
+			{ISBN: "9780306406157", Authors: []string{"Author"}, Sources: []Source{{Provider: "b", ID: "5"}}},
+		},
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("mergeResults() returned %d results, want 3: %+v", len(results), results)
+	}
+
+	want := Result{
+		ISBN:     "9780306406157",
+		Title:    "First",
+		Authors:  []string{"Author"},
+		Language: "en",
+		Sources:  []Source{{Provider: "a", ID: "1"}, {Provider: "b", ID: "3"}, {Provider: "b", ID: "5"}},
+	}
+	if !reflect.DeepEqual(results[0].Sources, want.Sources) || results[0].Title != want.Title ||
+		results[0].Language != want.Language || !reflect.DeepEqual(results[0].Authors, want.Authors) {
+		t.Errorf("merged result = %+v, want %+v", results[0], want)
+	}
+
+	// Results without an ISBN cannot be told apart and are all kept
+	if results[1].Title != "No ISBN" || results[2].Title != "No ISBN" {
+		t.Errorf("results = %+v, want both results without an isbn", results[1:])
+	}
+}
+
+func TestSearcherProviderTimeout(t *testing.T) {
+	slowServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer slowServer.Close()
+
+	slow := NewOpenLibraryProvider(slowServer.Client())
+	slow.BaseURL = slowServer.URL
+
+	googleServer := newGoogleBooksServer(t, "", "10")
+	searcher := NewSearcher().
+		Add(newGoogleBooksTestProvider(googleServer.URL), DefaultTimeout).
+		Add(slow, 50*time.Millisecond)
+
+	start := time.Now()
+	results, errs := searcher.Search(context.Background(), Query{Title: "go"})
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Search() took %s, want it to give up on the slow provider", elapsed)
+	}
+
+	if len(errs) != 1 || errs[0].Provider != "open_library" || !errors.Is(errs[0].Err, context.DeadlineExceeded) {
+		t.Fatalf("Search() errors = %v, want open_library to time out", errs)
+	}
+	if len(results) != 2 {
+		t.Errorf("Search() returned %d results, want those of google_books", len(results))
+	}
+}
+
+func TestSearcherCircuitOpen(t *testing.T) {
+	var requests atomic.Int32
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unavailable", http.StatusNotFound)
+	}))
+	defer failingServer.Close()
+
+	failing := NewOpenLibraryProvider(failingServer.Client())
+	failing.BaseURL = failingServer.URL
+	searcher := NewSearcher().Add(failing, DefaultTimeout)
+
+	for i := 0; i < DefaultFailureThreshold; i++ {
+		_, errs := searcher.Search(context.Background(), Query{Title: "go"})
+		if len(errs) != 1 || errors.Is(errs[0].Err, circuitbreaker.ErrOpen) {
+			t.Fatalf("search %d errors = %v, want the provider to fail", i+1, errs)
+		}
+	}
+
+	_, errs := searcher.Search(context.Background(), Query{Title: "go"})
+	if len(errs) != 1 || !errors.Is(errs[0].Err, circuitbreaker.ErrOpen) {
+		t.Fatalf("Search() errors = %v, want the circuit to be open", errs)
+	}
+	if got := requests.Load(); got != DefaultFailureThreshold {
+		t.Errorf("provider was called %d times, want %d", got, DefaultFailureThreshold)
+	}
+	if state := searcher.providers[0].breaker.State(); state != circuitbreaker.StateOpen {
+		t.Errorf("breaker state = %v, want open", state)
+	}
+}
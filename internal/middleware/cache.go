@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"lms-backend/internal/database"
+	"lms-backend/internal/i18n"
 	"sort"
 	"strings"
 	"time"
@@ -22,8 +23,9 @@ var (
 	VVVLongExp = 30 * 24 * time.Hour // 30 Days
 )
 
+// KeyGenerator keys the cache by the language of the messages, the path and the sorted query.
 func KeyGenerator(c *fiber.Ctx) string {
-	path := c.Path()
+	path := i18n.For(c).Language + ":" + c.Path()
 	queryParams := c.Queries()
 
 	if len(queryParams) == 0 {
@@ -15,6 +15,21 @@ var (
 		"Content-Type",
 		"Accept",
 		csrf.HeaderName,
+		IdempotencyKeyHeader,
+		fiber.HeaderIfMatch,
+		fiber.HeaderXRequestID,
+	}
+	// Response headers that the frontend may read
+	ExposeHeaders = []string{
+		fiber.HeaderETag,
+		fiber.HeaderContentLanguage,
+		IdempotentReplayedHeader,
+		RateLimitLimitHeader,
+		RateLimitRemainingHeader,
+		RateLimitResetHeader,
+		RateLimitPolicyHeader,
+		fiber.HeaderRetryAfter,
+		fiber.HeaderXRequestID,
 	}
 )
 
@@ -23,6 +38,7 @@ func SetupCors(app *fiber.App, cfg *config.Config) {
 		AllowCredentials: true,
 		AllowOriginsFunc: allowedOrigins(cfg),
 		AllowHeaders:     strings.Join(AllowHeaders, ", "),
+		ExposeHeaders:    strings.Join(ExposeHeaders, ", "),
 	}))
 }
 
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"lms-backend/internal/database"
+	"lms-backend/internal/session"
+	"lms-backend/pkg/error/externalerrors"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// Set on responses replayed for a retry
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	MaxIdempotencyKeyLength = 255
+	IdempotencyExp          = 24 * time.Hour
+	// Longest a request may hold its key, in case it never releases it
+	idempotencyLockExp = 1 * time.Minute
+)
+
+// idempotentResponse is the first successful response to an idempotency key.
+type idempotentResponse struct {
+	// Hash of the body of the request, which retries must repeat
+	BodyHash    string `json:"body_hash"`
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// Idempotency replays the response of the first request with the same Idempotency-Key
+// header, user, method and path, so that retries of an unsafe request take effect once.
+//
+// Only successful responses are kept, as failed requests are rolled back and can be
+// retried with the same key. Requests without the header are handled as usual.
+func Idempotency(c *fiber.Ctx) error {
+	key := c.Get(IdempotencyKeyHeader)
+	if key == "" {
+		return c.Next()
+	}
+
+	if len(key) > MaxIdempotencyKeyLength {
+		return externalerrors.BadRequest(fmt.Sprintf(
+			"%s must be at most %d characters", IdempotencyKeyHeader, MaxIdempotencyKeyLength,
+		))
+	}
+
+	userID, err := session.GetLoginSession(c)
+	if err != nil {
+		return err
+	}
+
+	storageKey := fmt.Sprintf("idempotency:%d:%s %s:%s", userID, c.Method(), c.Path(), key)
+	bodyHash := sha256.Sum256(c.Body())
+	hash := hex.EncodeToString(bodyHash[:])
+
+	// Held until the first request is done, so that concurrent retries are not handled twice
+	conn := database.GetRedisStore().Conn()
+	locked, err := conn.SetNX(c.UserContext(), storageKey+":lock", 1, idempotencyLockExp).Result()
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return externalerrors.Conflict("A request with this idempotency key is still being processed").
+			WithCode(externalerrors.IdempotencyKeyInUse)
+	}
+	defer func() {
+		if err := conn.Del(c.UserContext(), storageKey+":lock").Err(); err != nil {
+			log.Printf("failed to release idempotency key: %s\n", err)
+		}
+	}()
+
+	stored, err := database.GetRedisStore().Get(storageKey)
+	if err != nil {
+		return err
+	}
+
+	if stored != nil {
+		var res idempotentResponse
+		if err := json.Unmarshal(stored, &res); err != nil {
+			return err
+		}
+
+		if res.BodyHash != hash {
+			return externalerrors.UnprocessableEntity("This idempotency key was used for a different request").
+				WithCode(externalerrors.IdempotencyKeyReused)
+		}
+
+		c.Set(IdempotentReplayedHeader, "true")
+		c.Set(fiber.HeaderContentType, res.ContentType)
+		return c.Status(res.StatusCode).Send(res.Body)
+	}
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+
+	status := c.Response().StatusCode()
+	if status < fiber.StatusOK || status >= fiber.StatusMultipleChoices {
+		return nil
+	}
+
+	data, err := json.Marshal(idempotentResponse{
+		BodyHash:    hash,
+		StatusCode:  status,
+		ContentType: string(c.Response().Header.ContentType()),
+		Body:        c.Response().Body(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The request already took effect, so it is not failed if it cannot be replayed
+	if err := database.GetRedisStore().Set(storageKey, data, IdempotencyExp); err != nil {
+		log.Printf("failed to store idempotent response: %s\n", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,359 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"lms-backend/internal/config"
+	"lms-backend/internal/database"
+	"lms-backend/internal/session"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeRedis is a Redis server with the few commands the middleware uses. Keys do not expire.
+type fakeRedis struct {
+	mu   sync.Mutex
+	keys map[string]string
+}
+
+// useFakeRedis points the Redis store at a fake server for the duration of the test.
+func useFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	r := &fakeRedis{keys: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatalf("unexpected address %v", listener.Addr())
+	}
+	database.SetupRedis(&config.Config{REDISHost: addr.IP.String(), REDISPort: addr.Port})
+	t.Cleanup(func() { database.GetRedisStore().Close() })
+
+	return r
+}
+
+func (r *fakeRedis) has(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.keys[key]
+	return ok
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		if _, err := io.WriteString(conn, r.do(args)); err != nil {
+			return
+		}
+	}
+}
+
+// do runs the command and returns its reply.
+func (r *fakeRedis) do(args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "FLUSHDB":
+		r.keys = map[string]string{}
+		return "+OK\r\n"
+	case "GET":
+		value, ok := r.keys[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		nx := false
+		for _, option := range args[3:] {
+			nx = nx || strings.EqualFold(option, "NX")
+		}
+		if _, ok := r.keys[args[1]]; ok && nx {
+			return "$-1\r\n"
+		}
+		r.keys[args[1]] = args[2]
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := r.keys[key]; ok {
+				delete(r.keys, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "CLIENT":
+		return "+OK\r\n"
+	default:
+		// Also makes clients fall back to RESP2 for HELLO
+		return fmt.Sprintf("-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+// readCommand reads a command sent as an array of bulk strings.
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return nil, fmt.Errorf("unexpected command %q", line)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "$")))
+		if err != nil {
+			return nil, err
+		}
+
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return nil, err
+		}
+		args[i] = string(arg[:length])
+	}
+
+	return args, nil
+}
+
+// newIdempotencyApp serves handler for a signed in user behind the middleware.
+func newIdempotencyApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(session.UserIDKey, uint(1))
+		return c.Next()
+	})
+	app.Post("/loan", Idempotency, handler)
+
+	return app
+}
+
+func idempotentRequest(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/loan", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	res, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res, string(data)
+}
+
+func TestIdempotency(t *testing.T) {
+	type request struct {
+		key  string
+		body string
+		// Response of the handler, if it is run
+		status int
+		err    error
+
+		wantStatus   int
+		wantReplayed bool
+	}
+
+	failure := errors.New("database is down")
+
+	tests := []struct {
+		name        string
+		requests    []request
+		wantHandled int
+	}{
+		{
+			name: "replayed key",
+			requests: []request{
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated, wantReplayed: true},
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated, wantReplayed: true},
+			},
+			wantHandled: 1,
+		},
+		{
+			name: "same key with a different body",
+			requests: []request{
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+				{key: "a", body: `{"book_id":2}`, status: fiber.StatusCreated, wantStatus: fiber.StatusUnprocessableEntity},
+			},
+			wantHandled: 1,
+		},
+		{
+			name: "different keys",
+			requests: []request{
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+				{key: "b", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+			},
+			wantHandled: 2,
+		},
+		{
+			name: "no key",
+			requests: []request{
+				{body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+				{body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+			},
+			wantHandled: 2,
+		},
+		{
+			name: "key too long",
+			requests: []request{
+				{key: strings.Repeat("a", MaxIdempotencyKeyLength+1), status: fiber.StatusCreated, wantStatus: fiber.StatusBadRequest},
+			},
+		},
+		{
+			name: "response that is not successful is not stored",
+			requests: []request{
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusConflict, wantStatus: fiber.StatusConflict},
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+			},
+			wantHandled: 2,
+		},
+		{
+			name: "key is released when the handler fails",
+			requests: []request{
+				{key: "a", body: `{"book_id":1}`, err: failure, wantStatus: fiber.StatusInternalServerError},
+				{key: "a", body: `{"book_id":1}`, status: fiber.StatusCreated, wantStatus: fiber.StatusCreated},
+			},
+			wantHandled: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			redis := useFakeRedis(t)
+
+			handled := 0
+			var next request
+			app := newIdempotencyApp(func(c *fiber.Ctx) error {
+				handled++
+				if next.err != nil {
+					return next.err
+				}
+				return c.Status(next.status).SendString(fmt.Sprintf("response %d", handled))
+			})
+
+			var first string
+			for i, req := range tt.requests {
+				next = req
+				res, body := idempotentRequest(t, app, req.key, req.body)
+
+				if res.StatusCode != req.wantStatus {
+					t.Errorf("request %d: status = %d, want %d", i, res.StatusCode, req.wantStatus)
+				}
+				if replayed := res.Header.Get(IdempotentReplayedHeader) == "true"; replayed != req.wantReplayed {
+					t.Errorf("request %d: replayed = %t, want %t", i, replayed, req.wantReplayed)
+				}
+				if i == 0 {
+					first = body
+				} else if req.wantReplayed && body != first {
+					t.Errorf("request %d: body = %q, want the first response %q", i, body, first)
+				}
+			}
+
+			if handled != tt.wantHandled {
+				t.Errorf("handler ran %d times, want %d", handled, tt.wantHandled)
+			}
+			if redis.has("idempotency:1:POST /loan:a:lock") {
+				t.Errorf("key is still locked")
+			}
+		})
+	}
+}
+
+func TestIdempotencyConcurrentRequest(t *testing.T) {
+	useFakeRedis(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	app := newIdempotencyApp(func(c *fiber.Ctx) error {
+		close(started)
+		<-release
+		return c.Status(fiber.StatusCreated).SendString("loaned")
+	})
+
+	// t.Fatal cannot be called from another goroutine, so the first request is sent as is
+	req := httptest.NewRequest(fiber.MethodPost, "/loan", strings.NewReader(`{"book_id":1}`))
+	req.Header.Set(IdempotencyKeyHeader, "a")
+	done := make(chan int)
+	go func() {
+		res, err := app.Test(req, -1)
+		if err != nil {
+			done <- 0
+			return
+		}
+		res.Body.Close()
+		done <- res.StatusCode
+	}()
+	<-started
+
+	res, _ := idempotentRequest(t, app, "a", `{"book_id":1}`)
+	if res.StatusCode != fiber.StatusConflict {
+		t.Errorf("status of the concurrent request = %d, want %d", res.StatusCode, fiber.StatusConflict)
+	}
+
+	close(release)
+	if status := <-done; status != fiber.StatusCreated {
+		t.Errorf("status of the first request = %d, want %d", status, fiber.StatusCreated)
+	}
+
+	// Retries once the first request is done are replayed
+	res, body := idempotentRequest(t, app, "a", `{"book_id":1}`)
+	if res.StatusCode != fiber.StatusCreated || body != "loaned" {
+		t.Errorf("retry = %d %q, want %d %q", res.StatusCode, body, fiber.StatusCreated, "loaned")
+	}
+	if res.Header.Get(IdempotentReplayedHeader) != "true" {
+		t.Errorf("retry was not replayed")
+	}
+}
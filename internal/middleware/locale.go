@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupLocale negotiates the language and time zone of each request, preferring those
+// the signed in user chose over the Accept-Language header.
+func SetupLocale(app *fiber.App) {
+	app.Use(func(c *fiber.Ctx) error {
+		var locale, timeZone string
+		if c.Cookies(session.CookieKey) != "" {
+			sess, err := session.Store.Get(c)
+			if err != nil {
+				return err
+			}
+
+			//nolint:errcheck // empty if not set
+			locale, _ = sess.Get(session.LocaleKey).(string)
+			//nolint:errcheck // empty if not set
+			timeZone, _ = sess.Get(session.TimeZoneKey).(string)
+		}
+
+		l := i18n.Negotiate(c, locale, timeZone)
+		i18n.Set(c, l)
+
+		c.Set(fiber.HeaderContentLanguage, l.Language)
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return c.Next()
+	})
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"fmt"
+	"lms-backend/internal/config"
+	"lms-backend/internal/dataaccess/user"
+	"lms-backend/internal/database"
+	"lms-backend/internal/session"
+	"lms-backend/pkg/error/externalerrors"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	// Seconds until the budget is renewed
+	RateLimitResetHeader = "RateLimit-Reset"
+	// Requests allowed per window in seconds, e.g. 10;w=60
+	RateLimitPolicyHeader = "RateLimit-Policy"
+
+	rateLimitCallerKey = "rate_limit_caller"
+)
+
+// Counts the request in the window it is in, and returns that count along with the count
+// of the previous window. The count expires once it is no longer the previous window.
+//
+// KEYS: count of the window, count of the previous window
+// ARGV: milliseconds to keep the count of the window
+const rateLimitScript = `
+local current = redis.call('INCR', KEYS[1])
+if current == 1 then
+  redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+local previous = tonumber(redis.call('GET', KEYS[2]) or 0)
+return {current, previous}
+`
+
+// rateLimitCaller is who the budget of a request is counted against.
+type rateLimitCaller struct {
+	// user:<id> for signed in users, and ip:<ip> otherwise
+	identity      string
+	authenticated bool
+	staff         bool
+}
+
+// RateLimit limits the requests of each caller to the routes of the class, as configured
+// for anonymous, authenticated and staff callers in config.RateLimitClasses.
+//
+// Requests are counted in Redis in a sliding window, estimated from the counts of the
+// current and previous fixed windows. Requests over the limit are refused with 429 until
+// the RateLimit-Reset header. If Redis is unavailable, requests are not limited.
+func RateLimit(class string) fiber.Handler {
+	limits, ok := config.RateLimitClasses[class]
+	if !ok {
+		panic(fmt.Sprintf("rate limit class %s is not configured", class))
+	}
+
+	return func(c *fiber.Ctx) error {
+		caller, err := getRateLimitCaller(c)
+		if err != nil {
+			return err
+		}
+
+		limit := caller.limit(limits)
+		if limit.Requests == 0 {
+			return c.Next()
+		}
+
+		count, reset, err := countRequest(c, class, caller.identity, limit)
+		if err != nil {
+			log.Printf("failed to count request for rate limit: %s\n", err)
+			return c.Next()
+		}
+
+		setRateLimitHeaders(c, limit, limit.Requests-count, reset)
+		if count > limit.Requests {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(reset))
+			return externalerrors.TooManyRequests(fmt.Sprintf(
+				"At most %d requests are allowed every %s, retry in %d seconds", limit.Requests, limit.Window, reset,
+			)).WithCode(externalerrors.RateLimitExceeded)
+		}
+
+		return c.Next()
+	}
+}
+
+func (r *rateLimitCaller) limit(limits *config.RateLimits) config.RateLimit {
+	switch {
+	case r.staff:
+		return limits.Staff
+	case r.authenticated:
+		return limits.Authenticated
+	default:
+		return limits.Anonymous
+	}
+}
+
+// getRateLimitCaller reads the user of the session if there is one, as the session
+// middleware may not have run yet. Whether the user is staff is read from their roles on
+// each request, so that users who are no longer staff lose the staff budget at once.
+func getRateLimitCaller(c *fiber.Ctx) (*rateLimitCaller, error) {
+	if caller, ok := c.Locals(rateLimitCallerKey).(*rateLimitCaller); ok {
+		return caller, nil
+	}
+
+	caller := &rateLimitCaller{identity: "ip:" + c.IP()}
+	if c.Cookies(session.CookieKey) != "" {
+		sess, err := session.Store.Get(c)
+		if err != nil {
+			return nil, err
+		}
+
+		if userID, ok := sess.Get(session.CookieKey).(uint); ok && userID != 0 {
+			caller.identity = fmt.Sprintf("user:%d", userID)
+			caller.authenticated = true
+			caller.staff, err = user.IsStaff(database.GetDB(), int64(userID))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	c.Locals(rateLimitCallerKey, caller)
+	return caller, nil
+}
+
+// countRequest counts the request and returns the number of requests in the window
+// ending now, with the seconds until the current fixed window ends.
+func countRequest(c *fiber.Ctx, class, identity string, limit config.RateLimit) (count, reset int, err error) {
+	now := time.Now()
+	start := now.Truncate(limit.Window)
+
+	key := fmt.Sprintf("ratelimit:%s:%s:%d", class, identity, start.UnixMilli())
+	previousKey := fmt.Sprintf("ratelimit:%s:%s:%d", class, identity, start.Add(-limit.Window).UnixMilli())
+
+	counts, err := database.GetRedisStore().Conn().
+		Eval(c.UserContext(), rateLimitScript, []string{key, previousKey}, (2 * limit.Window).Milliseconds()).
+		Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(counts) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rate limit counts %v", counts)
+	}
+
+	count, reset = slidingWindowCount(now.Sub(start), limit.Window, counts[0], counts[1])
+	return count, reset, nil
+}
+
+// slidingWindowCount estimates the requests in the window ending now from the counts of
+// the current and previous fixed windows, elapsed into the current one. It also returns
+// the seconds until the current fixed window ends.
+func slidingWindowCount(elapsed, window time.Duration, current, previous int64) (count, reset int) {
+	// The previous window is weighted by how much of it is still in the sliding window
+	weight := float64(window-elapsed) / float64(window)
+	count = int(current) + int(float64(previous)*weight)
+	reset = int(math.Ceil((window - elapsed).Seconds()))
+
+	return count, reset
+}
+
+// setRateLimitHeaders sets the headers of the budget with the fewest requests remaining,
+// as requests may be counted in several classes.
+func setRateLimitHeaders(c *fiber.Ctx, limit config.RateLimit, remaining, reset int) {
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	if current := c.GetRespHeader(RateLimitRemainingHeader); current != "" {
+		if n, err := strconv.Atoi(current); err == nil && n <= remaining {
+			return
+		}
+	}
+
+	c.Set(RateLimitLimitHeader, strconv.Itoa(limit.Requests))
+	c.Set(RateLimitRemainingHeader, strconv.Itoa(remaining))
+	c.Set(RateLimitResetHeader, strconv.Itoa(reset))
+	c.Set(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Window.Seconds())))
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"lms-backend/internal/config"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSlidingWindowCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		elapsed   time.Duration
+		window    time.Duration
+		current   int64
+		previous  int64
+		wantCount int
+		wantReset int
+	}{
+		{
+			name:      "start of the window counts all of the previous one",
+			elapsed:   0,
+			window:    time.Minute,
+			current:   1,
+			previous:  10,
+			wantCount: 11,
+			wantReset: 60,
+		},
+		{
+			name:      "halfway counts half of the previous window",
+			elapsed:   30 * time.Second,
+			window:    time.Minute,
+			current:   4,
+			previous:  10,
+			wantCount: 9,
+			wantReset: 30,
+		},
+		{
+			name:      "requests of the previous window are rounded down",
+			elapsed:   45 * time.Second,
+			window:    time.Minute,
+			current:   2,
+			previous:  5,
+			wantCount: 3,
+			wantReset: 15,
+		},
+		{
+			name:      "reset is rounded up to the second",
+			elapsed:   59*time.Second + 500*time.Millisecond,
+			window:    time.Minute,
+			current:   7,
+			previous:  0,
+			wantCount: 7,
+			wantReset: 1,
+		},
+		{
+			name:      "no previous requests",
+			elapsed:   10 * time.Second,
+			window:    20 * time.Second,
+			current:   3,
+			previous:  0,
+			wantCount: 3,
+			wantReset: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, reset := slidingWindowCount(tt.elapsed, tt.window, tt.current, tt.previous)
+			if count != tt.wantCount || reset != tt.wantReset {
+				t.Errorf("slidingWindowCount() = %d, %d, want %d, %d", count, reset, tt.wantCount, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestRateLimitCallerLimit(t *testing.T) {
+	limits := &config.RateLimits{
+		Anonymous:     config.RateLimit{Requests: 1, Window: time.Minute},
+		Authenticated: config.RateLimit{Requests: 2, Window: time.Minute},
+		Staff:         config.RateLimit{Requests: 3, Window: time.Minute},
+	}
+
+	tests := []struct {
+		name   string
+		caller rateLimitCaller
+		want   int
+	}{
+		{name: "anonymous", caller: rateLimitCaller{identity: "ip:127.0.0.1"}, want: 1},
+		{name: "authenticated", caller: rateLimitCaller{identity: "user:1", authenticated: true}, want: 2},
+		{name: "staff", caller: rateLimitCaller{identity: "user:1", authenticated: true, staff: true}, want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caller.limit(limits); got.Requests != tt.want {
+				t.Errorf("limit() = %d requests, want %d", got.Requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	type budget struct {
+		limit     config.RateLimit
+		remaining int
+		reset     int
+	}
+
+	tests := []struct {
+		name          string
+		budgets       []budget
+		wantLimit     string
+		wantRemaining string
+		wantPolicy    string
+	}{
+		{
+			name:          "one class",
+			budgets:       []budget{{config.RateLimit{Requests: 10, Window: time.Minute}, 9, 30}},
+			wantLimit:     "10",
+			wantRemaining: "9",
+			wantPolicy:    "10;w=60",
+		},
+		{
+			name:          "over the limit",
+			budgets:       []budget{{config.RateLimit{Requests: 10, Window: time.Minute}, -3, 30}},
+			wantLimit:     "10",
+			wantRemaining: "0",
+			wantPolicy:    "10;w=60",
+		},
+		{
+			name: "class with fewer remaining requests",
+			budgets: []budget{
+				{config.RateLimit{Requests: 300, Window: time.Minute}, 250, 30},
+				{config.RateLimit{Requests: 10, Window: 30 * time.Second}, 4, 10},
+			},
+			wantLimit:     "10",
+			wantRemaining: "4",
+			wantPolicy:    "10;w=30",
+		},
+		{
+			name: "class with more remaining requests",
+			budgets: []budget{
+				{config.RateLimit{Requests: 10, Window: 30 * time.Second}, 4, 10},
+				{config.RateLimit{Requests: 300, Window: time.Minute}, 250, 30},
+			},
+			wantLimit:     "10",
+			wantRemaining: "4",
+			wantPolicy:    "10;w=30",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				for _, b := range tt.budgets {
+					setRateLimitHeaders(c, b.limit, b.remaining, b.reset)
+				}
+				return nil
+			})
+
+			res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+
+			for header, want := range map[string]string{
+				RateLimitLimitHeader:     tt.wantLimit,
+				RateLimitRemainingHeader: tt.wantRemaining,
+				RateLimitPolicyHeader:    tt.wantPolicy,
+			} {
+				if got := res.Header.Get(header); got != want {
+					t.Errorf("%s = %q, want %q", header, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const (
+	requestIDKey = "request_id"
+)
+
+// SetupRequestID gives each request an ID, sent back in the X-Request-ID header and recorded
+// in the audit log. An ID sent by the client or a proxy is kept so that their logs can be matched.
+func SetupRequestID(app *fiber.App) {
+	app.Use(requestid.New(requestid.Config{
+		Generator:  utils.UUIDv4,
+		ContextKey: requestIDKey,
+	}))
+}
+
+// GetRequestID returns the ID of the request, or an empty string if it has none.
+func GetRequestID(c *fiber.Ctx) string {
+	//nolint:errcheck // empty if not set
+	id, _ := c.Locals(requestIDKey).(string)
+	return id
+}
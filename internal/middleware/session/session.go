@@ -2,6 +2,7 @@ package sessionmiddleware
 
 import (
 	"lms-backend/internal/api"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/session"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,7 +17,7 @@ func SessionMiddleware(c *fiber.Ctx) error {
 	token := sess.Get(session.CookieKey)
 	if token == nil {
 		err := c.JSON(api.Response{
-			Messages: api.Messages(api.InfoMessage("User is not logged in")),
+			Messages: api.Messages(api.InfoMessage(i18n.T(c, i18n.NotLoggedIn))),
 		})
 		if err != nil {
 			return err
@@ -24,7 +25,7 @@ func SessionMiddleware(c *fiber.Ctx) error {
 		if err := sess.Destroy(); err != nil {
 			return err
 		}
-		return fiber.NewError(fiber.StatusUnauthorized, "User is not logged in")
+		return fiber.NewError(fiber.StatusUnauthorized, i18n.T(c, i18n.NotLoggedIn))
 	}
 
 	err = sess.Save()
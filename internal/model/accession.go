@@ -0,0 +1,28 @@
+package model
+
+import (
+	"fmt"
+	"lms-backend/internal/config"
+
+	"gorm.io/gorm"
+)
+
+// AccessionNumberGenerator returns the accession number for a new book copy.
+type AccessionNumberGenerator func(db *gorm.DB) (string, error)
+
+// GenerateAccessionNumber is used for copies created without an accession number.
+//
+// It can be replaced to follow a different numbering scheme.
+var GenerateAccessionNumber AccessionNumberGenerator = SequentialAccessionNumber
+
+// SequentialAccessionNumber formats the next value of the accession sequence
+// with the configured prefix and number of digits, e.g. LMS00000042.
+func SequentialAccessionNumber(db *gorm.DB) (string, error) {
+	var seq int64
+	result := db.Raw("SELECT NEXTVAL('book_copy_accession_seq')").Scan(&seq)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	return fmt.Sprintf("%s%0*d", config.AccessionNumberPrefix, config.AccessionNumberDigits, seq), nil
+}
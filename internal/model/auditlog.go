@@ -1,27 +1,126 @@
 package model
 
 import (
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+type AuditLogActionType = string
+
+// AuditLog is an entry for an entity changed by a request, or for the request itself if it
+// changed no entity. The entries of a request share its action and request ID.
 type AuditLog struct {
 	ID        uint `gorm:"primarykey"`
 	CreatedAt time.Time
 
-	UserID uint      `gorm:"not null"`
-	User   *User     `gorm:"->"`
-	Action string    `gorm:"not null"`
-	Date   time.Time `gorm:"not null"`
+	UserID     uint               `gorm:"not null"` // User who made the request
+	User       *User              `gorm:"->"`
+	Action     string             `gorm:"not null"` // Description of the request, e.g. alice loaning "Dune"
+	ActionType AuditLogActionType `gorm:"not null"`
+	EntityType string             `gorm:"not null"` // Table of the entity, empty if none
+	EntityID   uint               `gorm:"not null"` // 0 if there is no entity
+	RequestID  string             `gorm:"not null"`
+	IPAddress  string             `gorm:"not null"` // Empty for jobs
+	Changes    AuditLogChanges    `gorm:"not null"`
+	Date       time.Time          `gorm:"not null"`
+
+	// Entries are chained by hash, so that changing or removing one breaks the chain.
+	// Entries from before the chain have neither.
+	PreviousHash string `gorm:"not null"` // Hash of the entry before, empty for the first
+	Hash         string `gorm:"not null"` // SHA-256 of PreviousHash and the contents of the entry
 }
 
+// AuditLogChange is the value of a column before and after a request.
+// Old is null for created entities and New is null for deleted ones.
+type AuditLogChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// AuditLogChanges are the changed columns of an entity, by column name.
+type AuditLogChanges map[string]AuditLogChange
+
 const (
 	AuditLogModelName = "audit_log"
 	AuditLogTableName = "audit_logs"
 )
 
+const (
+	AuditLogActionCreate AuditLogActionType = "create"
+	AuditLogActionUpdate AuditLogActionType = "update"
+	AuditLogActionDelete AuditLogActionType = "delete"
+	// Requests that changed no entity
+	AuditLogActionOther AuditLogActionType = "other"
+	// Entries created by users
+	AuditLogActionNote AuditLogActionType = "note"
+)
+
+var AuditLogActionTypes = []AuditLogActionType{
+	AuditLogActionCreate,
+	AuditLogActionUpdate,
+	AuditLogActionDelete,
+	AuditLogActionOther,
+	AuditLogActionNote,
+}
+
+const (
+	// Key of the advisory lock held while appending to the chain, so that transactions
+	// append one after another
+	auditLogChainLockKey = 1705001000
+	// Dates are stored without time zone, to the microsecond
+	auditLogHashDateLayout = "2006-01-02T15:04:05.000000"
+)
+
+// auditLogContents are the fields covered by the hash of an entry, in a fixed order.
+type auditLogContents struct {
+	PreviousHash string          `json:"previous_hash"`
+	UserID       uint            `json:"user_id"`
+	Action       string          `json:"action"`
+	ActionType   string          `json:"action_type"`
+	EntityType   string          `json:"entity_type"`
+	EntityID     uint            `json:"entity_id"`
+	RequestID    string          `json:"request_id"`
+	IPAddress    string          `json:"ip_address"`
+	Changes      AuditLogChanges `json:"changes"`
+	Date         string          `json:"date"`
+}
+
+func (a AuditLogChanges) Value() (driver.Value, error) {
+	if a == nil {
+		return "{}", nil
+	}
+
+	changes, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(changes), nil
+}
+
+func (a *AuditLogChanges) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*a = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into audit log changes", value)
+	}
+}
+
 func (a *AuditLog) Create(db *gorm.DB) error {
 	return db.Create(a).Error
 }
@@ -46,6 +145,13 @@ func (a *AuditLog) Validate(db *gorm.DB) error {
 		return externalerrors.BadRequest("action is required")
 	}
 
+	if !sliceutil.Contains(AuditLogActionTypes, a.ActionType) {
+		return externalerrors.BadRequest(fmt.Sprintf(
+			"%s is not a valid action type, expected one of %s",
+			a.ActionType, strings.Join(AuditLogActionTypes, ", "),
+		)).WithCode(externalerrors.InvalidValue)
+	}
+
 	return a.ensureUserExists(db)
 }
 
@@ -54,5 +160,112 @@ func (a *AuditLog) BeforeCreate(db *gorm.DB) error {
 		a.Date = time.Now()
 	}
 
-	return a.Validate(db)
+	if a.ActionType == "" {
+		a.ActionType = AuditLogActionOther
+	}
+
+	if err := a.Validate(db); err != nil {
+		return err
+	}
+
+	return a.chain(db)
+}
+
+func (a *AuditLog) BeforeUpdate(*gorm.DB) error {
+	return externalerrors.BadRequest("audit log entries cannot be changed")
+}
+
+func (a *AuditLog) BeforeDelete(*gorm.DB) error {
+	return externalerrors.BadRequest("audit log entries cannot be deleted")
+}
+
+// chain links the entry to the last one in the log. The lock is held until the end of the
+// transaction, so that no other entry is appended in between.
+func (a *AuditLog) chain(db *gorm.DB) error {
+	if err := db.Exec("SELECT pg_advisory_xact_lock(?)", auditLogChainLockKey).Error; err != nil {
+		return err
+	}
+
+	var previousHashes []string
+	result := db.Model(&AuditLog{}).
+		Order("id DESC").
+		Limit(1).
+		Pluck("hash", &previousHashes)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	a.PreviousHash = ""
+	if len(previousHashes) > 0 {
+		a.PreviousHash = previousHashes[0]
+	}
+
+	// Stored as they are read back, so that the hash can be computed again from the database
+	a.Date = a.Date.Truncate(time.Microsecond)
+	changes, err := json.Marshal(a.Changes)
+	if err != nil {
+		return err
+	}
+	a.Changes = nil
+	if err := json.Unmarshal(changes, &a.Changes); err != nil {
+		return err
+	}
+
+	hash, err := a.ComputeHash()
+	if err != nil {
+		return err
+	}
+	a.Hash = hash
+
+	return nil
+}
+
+// ComputeHash returns the hash of the contents of the entry and its previous hash.
+func (a *AuditLog) ComputeHash() (string, error) {
+	changes := a.Changes
+	if changes == nil {
+		changes = AuditLogChanges{}
+	}
+
+	contents, err := json.Marshal(auditLogContents{
+		PreviousHash: a.PreviousHash,
+		UserID:       a.UserID,
+		Action:       a.Action,
+		ActionType:   a.ActionType,
+		EntityType:   a.EntityType,
+		EntityID:     a.EntityID,
+		RequestID:    a.RequestID,
+		IPAddress:    a.IPAddress,
+		Changes:      changes,
+		Date:         a.Date.Format(auditLogHashDateLayout),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(contents)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyChain checks that the entry follows the entry with the previous hash, and that its
+// contents have not changed since. It returns why not, or an empty string if it does.
+func (a *AuditLog) VerifyChain(previousHash string) (string, error) {
+	if a.Hash == "" {
+		return "entry is not chained", nil
+	}
+
+	if a.PreviousHash != previousHash {
+		return "previous hash does not match the entry before, which was changed or removed", nil
+	}
+
+	hash, err := a.ComputeHash()
+	if err != nil {
+		return "", err
+	}
+
+	if hash != a.Hash {
+		return "hash does not match the contents of the entry, which were changed", nil
+	}
+
+	return "", nil
 }
@@ -0,0 +1,214 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// chainedLogs returns entries chained as they are appended to the log.
+func chainedLogs(t *testing.T, n int) []AuditLog {
+	t.Helper()
+
+	logs := make([]AuditLog, n)
+	previousHash := ""
+	for i := range logs {
+		logs[i] = AuditLog{
+			ID:         uint(i + 1),
+			UserID:     1,
+			Action:     "alice updating book",
+			ActionType: AuditLogActionUpdate,
+			EntityType: "books",
+			EntityID:   uint(i + 1),
+			RequestID:  "request",
+			IPAddress:  "127.0.0.1",
+			Changes: AuditLogChanges{
+				"title": {Old: "Dune", New: "Dune Messiah"},
+			},
+			Date:         time.Date(2024, 1, 15, 10, 30, i, 0, time.UTC),
+			PreviousHash: previousHash,
+		}
+
+		hash, err := logs[i].ComputeHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		logs[i].Hash = hash
+		previousHash = hash
+	}
+
+	return logs
+}
+
+func TestAuditLogComputeHash(t *testing.T) {
+	date := time.Date(2024, 1, 15, 10, 30, 0, 123456789, time.FixedZone("MYT", 8*60*60))
+
+	tests := []struct {
+		name     string
+		modify   func(a *AuditLog)
+		wantSame bool
+	}{
+		{
+			name:     "same contents",
+			modify:   func(a *AuditLog) {},
+			wantSame: true,
+		},
+		{
+			name: "date read back to the microsecond without time zone",
+			modify: func(a *AuditLog) {
+				a.Date = time.Date(2024, 1, 15, 10, 30, 0, 123456000, time.UTC)
+			},
+			wantSame: true,
+		},
+		{
+			name: "changes read back from JSON",
+			modify: func(a *AuditLog) {
+				a.Changes = AuditLogChanges{"copies": {Old: float64(1), New: float64(2)}}
+			},
+			wantSame: true,
+		},
+		{
+			name: "no changes",
+			modify: func(a *AuditLog) {
+				a.Changes = AuditLogChanges{}
+			},
+		},
+		{
+			name: "changed action",
+			modify: func(a *AuditLog) {
+				a.Action = "mallory updating book"
+			},
+		},
+		{
+			name: "changed value",
+			modify: func(a *AuditLog) {
+				a.Changes = AuditLogChanges{"copies": {Old: 1, New: 3}}
+			},
+		},
+		{
+			name: "another microsecond",
+			modify: func(a *AuditLog) {
+				a.Date = time.Date(2024, 1, 15, 10, 30, 0, 123457000, time.UTC)
+			},
+		},
+		{
+			name: "another previous hash",
+			modify: func(a *AuditLog) {
+				a.PreviousHash = "0000"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := AuditLog{
+				UserID:     1,
+				Action:     "alice updating book",
+				ActionType: AuditLogActionUpdate,
+				EntityType: "books",
+				EntityID:   7,
+				RequestID:  "request",
+				IPAddress:  "127.0.0.1",
+				Changes:    AuditLogChanges{"copies": {Old: 1, New: 2}},
+				Date:       date.Truncate(time.Microsecond),
+			}
+			want, err := a.ComputeHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.modify(&a)
+			got, err := a.ComputeHash()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (got == want) != tt.wantSame {
+				t.Errorf("ComputeHash() = %s, want same as %s: %t", got, want, tt.wantSame)
+			}
+		})
+	}
+}
+
+func TestAuditLogVerifyChain(t *testing.T) {
+	tests := []struct {
+		name       string
+		modify     func(logs []AuditLog) []AuditLog
+		wantBroken int // Index of the first entry that does not verify, -1 if none
+	}{
+		{
+			name:       "valid chain",
+			modify:     func(logs []AuditLog) []AuditLog { return logs },
+			wantBroken: -1,
+		},
+		{
+			name: "changed contents",
+			modify: func(logs []AuditLog) []AuditLog {
+				logs[1].Changes["title"] = AuditLogChange{Old: "Dune", New: "Children of Dune"}
+				return logs
+			},
+			wantBroken: 1,
+		},
+		{
+			name: "changed hash",
+			modify: func(logs []AuditLog) []AuditLog {
+				logs[1].Hash = logs[0].Hash
+				return logs
+			},
+			wantBroken: 1,
+		},
+		{
+			name: "removed entry",
+			modify: func(logs []AuditLog) []AuditLog {
+				return append(logs[:1], logs[2:]...)
+			},
+			wantBroken: 1,
+		},
+		{
+			name: "reordered entries",
+			modify: func(logs []AuditLog) []AuditLog {
+				logs[1], logs[2] = logs[2], logs[1]
+				return logs
+			},
+			wantBroken: 1,
+		},
+		{
+			name: "unchained entry",
+			modify: func(logs []AuditLog) []AuditLog {
+				logs[2].PreviousHash = ""
+				logs[2].Hash = ""
+				return logs
+			},
+			wantBroken: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := tt.modify(chainedLogs(t, 3))
+
+			previousHash := ""
+			for i, log := range logs {
+				//nolint:gosec // loop does not modify struct
+				reason, err := log.VerifyChain(previousHash)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if i == tt.wantBroken {
+					if reason == "" {
+						t.Errorf("VerifyChain() of entry %d = \"\", want a reason", i)
+					}
+					return
+				}
+				if reason != "" {
+					t.Fatalf("VerifyChain() of entry %d = %q, want \"\"", i, reason)
+				}
+				previousHash = log.Hash
+			}
+
+			if tt.wantBroken >= 0 {
+				t.Errorf("chain verified, want entry %d broken", tt.wantBroken)
+			}
+		})
+	}
+}
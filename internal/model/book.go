@@ -1,7 +1,12 @@
 package model
 
 import (
+	"fmt"
+	"lms-backend/internal/orm"
 	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/isbn"
+	"lms-backend/util/sliceutil"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,19 +14,25 @@ import (
 
 type UserStatus = string
 
+// Author, Publisher and Genre are display strings summarising the book's
+// contributors, publishers and subjects. Search against the entities instead.
 type Book struct {
 	gorm.Model
 
-	Title           string               `gorm:"not null"`
-	Author          string               `gorm:"not null"`
-	ISBN            string               `gorm:"not null"`
-	Publisher       string               `gorm:"not null"`
-	PublicationDate time.Time            `gorm:"not null"`
-	Genre           string               `gorm:"not null"`
-	Language        string               `gorm:"not null"`
-	BookCopies      []BookCopy           `gorm:"->;<-:create"`
-	Bookmarks       []Bookmark           `gorm:"->"`
-	Thumbnail       *FileUploadReference `gorm:"->;polymorphic:Attachable;polymorphicValue:book_thumbnail"`
+	Title            string               `gorm:"not null"`
+	Author           string               `gorm:"not null"`
+	ISBN             string               `gorm:"not null"`
+	Publisher        string               `gorm:"not null"`
+	PublicationDate  time.Time            `gorm:"not null"`
+	Genre            string               `gorm:"not null"`
+	Language         string               `gorm:"not null"`
+	BookCopies       []BookCopy           `gorm:"->;<-:create"`
+	Bookmarks        []Bookmark           `gorm:"->"`
+	Thumbnail        *FileUploadReference `gorm:"->;polymorphic:Attachable;polymorphicValue:book_thumbnail"`
+	BookContributors []BookContributor    `gorm:"->"`
+	Subjects         []Subject            `gorm:"many2many:book_subjects;->"`
+	Publishers       []Publisher          `gorm:"many2many:book_publishers;->"`
+	Version          uint                 `gorm:"not null;default:1"` // Incremented on every update
 }
 
 const (
@@ -30,53 +41,277 @@ const (
 )
 
 func (b *Book) Create(db *gorm.DB) error {
-	return db.Create(b).Error
+	return b.translateISBNConflict(db, db.Create(b).Error)
 }
 
 func (b *Book) Update(db *gorm.DB) error {
-	return db.Updates(b).Error
+	if err := b.translateISBNConflict(db, orm.UpdateVersioned(db, b, &b.Version, BookModelName)); err != nil {
+		return err
+	}
+
+	return b.unmarkISBNDuplicate(db)
+}
+
+// translateISBNConflict reports a book with the same ISBN saved concurrently as the check
+// of ensureISBNIsUnique does, as the database only allows one book per ISBN.
+func (b *Book) translateISBNConflict(db *gorm.DB, err error) error {
+	if orm.IsDuplicatedKey(orm.TranslateError(db, err)) {
+		return externalerrors.Conflict(fmt.Sprintf("isbn %s is already used by another book", b.ISBN)).
+			WithField("isbn", externalerrors.AlreadyExists)
+	}
+
+	return err
 }
 
 func (b *Book) Delete(db *gorm.DB) error {
-	return db.Delete(b).Error
+	return orm.DeleteVersioned(db, b, b.Version, BookModelName)
+}
+
+// normalizeISBN converts the ISBN to its hyphenless ISBN-13 form.
+func (b *Book) normalizeISBN() error {
+	normalized, err := isbn.Normalize(b.ISBN)
+	if err != nil {
+		return fmt.Errorf("%s is not a valid isbn: %w", b.ISBN, err)
+	}
+
+	b.ISBN = normalized
+	return nil
 }
 
-func (b *Book) Validate(_ *gorm.DB) error {
+// ensureISBNIsUnique fails if another book has the ISBN, leaving out books marked as
+// sharing their ISBN with an earlier book, as the unique index of the ISBN does. Marked
+// books keep their ISBN until they are merged.
+func (b *Book) ensureISBNIsUnique(db *gorm.DB) error {
+	if b.ID != 0 {
+		var marked int64
+		result := db.Model(&Book{}).
+			Where("id = ?", b.ID).
+			Where("isbn = ?", b.ISBN).
+			Where("isbn_duplicate").
+			Count(&marked)
+		if result.Error != nil {
+			return result.Error
+		}
+		if marked > 0 {
+			return nil
+		}
+	}
+
+	var existing Book
+	result := db.Model(&Book{}).
+		Select("id", "title").
+		Where("isbn = ?", b.ISBN).
+		Where("id <> ?", b.ID).
+		Where("NOT isbn_duplicate").
+		Limit(1).
+		Find(&existing)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return externalerrors.Conflict(fmt.Sprintf(
+			"isbn %s is already used by \"%s\" (book id %d)", b.ISBN, existing.Title, existing.ID,
+		)).WithField("isbn", externalerrors.AlreadyExists)
+	}
+
+	return nil
+}
+
+// unmarkISBNDuplicate unmarks the book as sharing its ISBN with an earlier book once it
+// no longer does, e.g. after its ISBN is corrected, so that the database checks that it
+// stays unique.
+func (b *Book) unmarkISBNDuplicate(db *gorm.DB) error {
+	return db.Model(&Book{}).
+		Where("id = ?", b.ID).
+		Where("isbn_duplicate").
+		Where("NOT EXISTS (?)", db.Model(&Book{}).
+			Select("1").
+			Where("isbn = ?", b.ISBN).
+			Where("id <> ?", b.ID).
+			Where("NOT isbn_duplicate"),
+		).
+		UpdateColumn("isbn_duplicate", false).
+		Error
+}
+
+// Validate returns the errors of the fields of the book keyed by their column name,
+// e.g. publication_date, or a conflict if the ISBN is used by another book.
+func (b *Book) Validate(db *gorm.DB) error {
+	b.summarize()
+
+	v := externalerrors.Validation{}
+
 	if b.Title == "" {
-		return externalerrors.BadRequest("title is required")
+		v.Add("title", externalerrors.Required, "title is required")
 	}
 
 	if b.Author == "" {
-		return externalerrors.BadRequest("author is required")
+		v.Add("author", externalerrors.Required, "author is required")
 	}
 
 	if b.ISBN == "" {
-		return externalerrors.BadRequest("isbn is required")
+		v.Add("isbn", externalerrors.Required, "isbn is required")
+	} else if err := b.normalizeISBN(); err != nil {
+		v.Add("isbn", externalerrors.InvalidValue, err.Error())
 	}
 
 	if b.Publisher == "" {
-		return externalerrors.BadRequest("publisher is required")
+		v.Add("publisher", externalerrors.Required, "publisher is required")
 	}
 
 	if (time.Time{}).Equal(b.PublicationDate) {
-		return externalerrors.BadRequest("publication date is required")
+		v.Add("publication_date", externalerrors.Required, "publication date is required")
 	}
 
 	if b.Genre == "" {
-		return externalerrors.BadRequest("genre is required")
+		v.Add("genre", externalerrors.Required, "genre is required")
 	}
 
 	if b.Language == "" {
-		return externalerrors.BadRequest("language is required")
+		v.Add("language", externalerrors.Required, "language is required")
+	}
+
+	if err := v.Err(); err != nil {
+		return err
+	}
+
+	return b.ensureISBNIsUnique(db)
+}
+
+// summarize refreshes the display strings from the loaded contributors, publishers and subjects.
+// Associations that are not loaded leave their display string untouched.
+func (b *Book) summarize() {
+	if len(b.BookContributors) > 0 {
+		named := sliceutil.Filter(b.BookContributors, func(bc BookContributor) bool {
+			return bc.Contributor != nil
+		})
+		authors := sliceutil.Filter(named, func(bc BookContributor) bool {
+			return bc.Role == ContributorRoleAuthor
+		})
+		if len(authors) == 0 {
+			authors = named
+		}
+
+		b.Author = strings.Join(sliceutil.Map(authors, func(bc BookContributor) string {
+			return bc.Contributor.Name
+		}), ", ")
+	}
+
+	if len(b.Publishers) > 0 {
+		b.Publisher = strings.Join(sliceutil.Map(b.Publishers, func(p Publisher) string {
+			return p.Name
+		}), "; ")
+	}
+
+	if len(b.Subjects) > 0 {
+		b.Genre = strings.Join(sliceutil.Map(b.Subjects, func(s Subject) string {
+			return s.Name
+		}), ", ")
+	}
+}
+
+func (b *Book) BeforeCreate(db *gorm.DB) error {
+	return b.Validate(db)
+}
+
+func (b *Book) BeforeUpdate(db *gorm.DB) error {
+	return b.Validate(db)
+}
+
+// UpdateContributors replaces the contributors of the book.
+// Contributors are looked up by name and created if they do not exist yet.
+func (b *Book) UpdateContributors(db *gorm.DB, contributors []BookContributor) error {
+	// Remove all existing contributors
+	result := db.
+		Where("book_id = ?", b.ID).
+		Delete(&BookContributor{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	b.BookContributors = []BookContributor{}
+	for i, bc := range contributors {
+		if err := ValidateContributorRole(bc.Role); err != nil {
+			return err
+		}
+
+		contributor, err := FindOrCreateContributor(db, bc.Contributor.Name)
+		if err != nil {
+			return err
+		}
+
+		link := BookContributor{
+			BookID:        b.ID,
+			ContributorID: contributor.ID,
+			Contributor:   contributor,
+			Role:          bc.Role,
+			Position:      i,
+		}
+		if err := db.Create(&link).Error; err != nil {
+			return err
+		}
+
+		b.BookContributors = append(b.BookContributors, link)
 	}
 
 	return nil
 }
 
-func (b *Book) BeforeCreate(_ *gorm.DB) error {
-	return b.Validate(nil)
+// UpdateSubjects replaces the subjects of the book, creating subjects that do not exist yet.
+func (b *Book) UpdateSubjects(db *gorm.DB, names []string) error {
+	// Remove all existing subjects
+	err := db.Exec("DELETE FROM book_subjects WHERE book_id = ?", b.ID).Error
+	if err != nil {
+		return err
+	}
+
+	b.Subjects = []Subject{}
+	for _, name := range names {
+		subject, err := FindOrCreateSubject(db, name)
+		if err != nil {
+			return err
+		}
+
+		err = db.Exec(
+			"INSERT INTO book_subjects (book_id, subject_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			b.ID, subject.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		b.Subjects = append(b.Subjects, *subject)
+	}
+
+	return nil
 }
 
-func (b *Book) BeforeUpdate(_ *gorm.DB) error {
-	return b.Validate(nil)
+// UpdatePublishers replaces the publishers of the book, creating publishers that do not exist yet.
+func (b *Book) UpdatePublishers(db *gorm.DB, names []string) error {
+	// Remove all existing publishers
+	err := db.Exec("DELETE FROM book_publishers WHERE book_id = ?", b.ID).Error
+	if err != nil {
+		return err
+	}
+
+	b.Publishers = []Publisher{}
+	for _, name := range names {
+		publisher, err := FindOrCreatePublisher(db, name)
+		if err != nil {
+			return err
+		}
+
+		err = db.Exec(
+			"INSERT INTO book_publishers (book_id, publisher_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+			b.ID, publisher.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+
+		b.Publishers = append(b.Publishers, *publisher)
+	}
+
+	return nil
 }
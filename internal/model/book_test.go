@@ -0,0 +1,137 @@
+package model
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"lms-backend/pkg/error/externalerrors"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeBooks is a database in which counts return marked, and other queries return the
+// books. The queries are kept to be checked.
+type fakeBooks struct {
+	marked  int64
+	books   [][]driver.Value
+	queries []string
+}
+
+func (db *fakeBooks) Connect(context.Context) (driver.Conn, error) {
+	return &fakeBooksConn{db: db}, nil
+}
+func (db *fakeBooks) Driver() driver.Driver { return nil }
+
+type fakeBooksConn struct {
+	db *fakeBooks
+}
+
+func (c *fakeBooksConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeBooksConn) Close() error                        { return nil }
+func (c *fakeBooksConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *fakeBooksConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.queries = append(c.db.queries, query)
+	if strings.HasPrefix(query, "SELECT count(*)") {
+		return &fakeBooksRows{columns: []string{"count"}, rows: [][]driver.Value{{c.db.marked}}}, nil
+	}
+
+	return &fakeBooksRows{columns: []string{"id", "title"}, rows: c.db.books}, nil
+}
+
+type fakeBooksRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fakeBooksRows) Columns() []string { return r.columns }
+func (r *fakeBooksRows) Close() error      { return nil }
+
+func (r *fakeBooksRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestBookEnsureISBNIsUnique(t *testing.T) {
+	const (
+		markedQuery   = `SELECT count(*) FROM "books" WHERE id = $1 AND isbn = $2 AND isbn_duplicate AND "books"."deleted_at" IS NULL`
+		conflictQuery = `SELECT "id","title" FROM "books" WHERE isbn = $1 AND id <> $2 AND NOT isbn_duplicate AND "books"."deleted_at" IS NULL LIMIT 1`
+	)
+
+	tests := []struct {
+		name         string
+		id           uint
+		marked       int64
+		books        [][]driver.Value
+		wantConflict bool
+		wantQueries  []string
+	}{
+		{
+			name:        "new book",
+			wantQueries: []string{conflictQuery},
+		},
+		{
+			name:         "new book with the ISBN of another",
+			books:        [][]driver.Value{{int64(7), "Dune"}},
+			wantConflict: true,
+			wantQueries:  []string{conflictQuery},
+		},
+		{
+			name:        "book with its own ISBN",
+			id:          3,
+			wantQueries: []string{markedQuery, conflictQuery},
+		},
+		{
+			name:         "book with the ISBN of another",
+			id:           3,
+			books:        [][]driver.Value{{int64(7), "Dune"}},
+			wantConflict: true,
+			wantQueries:  []string{markedQuery, conflictQuery},
+		},
+		{
+			name:        "book marked as sharing its ISBN keeps it until merged",
+			id:          3,
+			marked:      1,
+			wantQueries: []string{markedQuery},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeBooks{marked: tt.marked, books: tt.books}
+			db, err := gorm.Open(
+				postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}),
+				&gorm.Config{Logger: logger.Discard},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			b := Book{ISBN: "9780441172719"}
+			b.ID = tt.id
+			err = b.ensureISBNIsUnique(db)
+
+			var externalErr *externalerrors.Error
+			conflict := errors.As(err, &externalErr) && externalErr.Status == fiber.StatusConflict
+			if conflict != tt.wantConflict || (err != nil && !conflict) {
+				t.Errorf("ensureISBNIsUnique() error = %v, want conflict %t", err, tt.wantConflict)
+			}
+			if got := strings.Join(fake.queries, "\n"); got != strings.Join(tt.wantQueries, "\n") {
+				t.Errorf("queries =\n%s\nwant\n%s", got, strings.Join(tt.wantQueries, "\n"))
+			}
+		})
+	}
+}
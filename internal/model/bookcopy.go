@@ -1,9 +1,13 @@
 package model
 
 import (
+	"database/sql"
 	"fmt"
+	"lms-backend/internal/orm"
 	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/money"
 	"lms-backend/util/sliceutil"
+	"regexp"
 
 	"gorm.io/gorm"
 )
@@ -13,13 +17,33 @@ type BookStatus = string
 type BookCopy struct {
 	gorm.Model
 
-	BookID       uint          `gorm:"not null"`
-	Book         *Book         `gorm:"->"`
-	Status       BookStatus    `gorm:"not null"`
-	Loans        []Loan        `gorm:"->"`
-	Reservations []Reservation `gorm:"->"`
+	BookID            uint       `gorm:"not null"`
+	Book              *Book      `gorm:"->"`
+	Status            BookStatus `gorm:"not null"`
+	HomeBranchID      uint       `gorm:"not null"` // Branch the copy belongs to
+	HomeBranch        *Branch    `gorm:"->"`
+	CurrentBranchID   uint       `gorm:"not null"` // Branch the copy is currently at
+	CurrentBranch     *Branch    `gorm:"->"`
+	AccessionNumber   string     `gorm:"unique;not null"` // Printed on the copy's barcode label
+	CallNumber        string     `gorm:"not null"`
+	ShelfLocation     string     `gorm:"not null"`
+	AcquisitionDate   sql.NullTime
+	AcquisitionSource string        `gorm:"not null"`
+	AcquisitionPrice  money.Amount  `gorm:"not null"`
+	Loans             []Loan        `gorm:"->"`
+	Reservations      []Reservation `gorm:"->"`
+	Transfers         []Transfer    `gorm:"->"`
+	Version           uint          `gorm:"not null;default:1"` // Incremented on every update
 }
 
+var (
+	accessionNumberReg = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+)
+
+const (
+	MaximumAccessionNumberLength = 32
+)
+
 const (
 	BookCopyModelName = "bookcopy"
 	BookCopyTableName = "bookcopies"
@@ -29,6 +53,7 @@ const (
 	BookStatusAvailable BookStatus = "available"
 	BookStatusOnLoan    BookStatus = "loaned"
 	BookStatusOnReserve BookStatus = "reserved"
+	BookStatusInTransit BookStatus = "in_transit"
 )
 
 func (b *BookCopy) Create(db *gorm.DB) error {
@@ -37,7 +62,21 @@ func (b *BookCopy) Create(db *gorm.DB) error {
 
 // Loans and reservation should not be updated/created here.
 func (b *BookCopy) Update(db *gorm.DB) error {
-	return db.Updates(b).Error
+	return orm.UpdateVersioned(db, b, &b.Version, BookCopyModelName)
+}
+
+// UpdateDetails updates the cataloguing and acquisition details of the copy.
+//
+// Unlike Update, empty values are written so that details can be cleared.
+func (b *BookCopy) UpdateDetails(db *gorm.DB) error {
+	return orm.UpdateVersioned(db, b, &b.Version, BookCopyModelName,
+		"accession_number",
+		"call_number",
+		"shelf_location",
+		"acquisition_date",
+		"acquisition_source",
+		"acquisition_price",
+	)
 }
 
 // All loans associated with this book will be deleted.
@@ -56,7 +95,13 @@ func (b *BookCopy) Delete(db *gorm.DB) error {
 		}
 	}
 
-	return db.Delete(b).Error
+	for _, transfer := range b.Transfers {
+		if err := transfer.Delete(db); err != nil {
+			return err
+		}
+	}
+
+	return orm.DeleteVersioned(db, b, b.Version, BookCopyModelName)
 }
 
 func (b *BookCopy) ensureBookExistOrNew(db *gorm.DB) error {
@@ -94,7 +139,8 @@ func (b *BookCopy) ensureCopyIsNotOnLoan(db *gorm.DB) error {
 	}
 
 	if exists > 0 {
-		return externalerrors.BadRequest(fmt.Sprintf("book copy with id %d is on loan", b.ID))
+		return externalerrors.BadRequest(fmt.Sprintf("book copy with id %d is on loan", b.ID)).
+			WithCode(externalerrors.CopyOnLoan)
 	}
 
 	return nil
@@ -115,7 +161,62 @@ func (b *BookCopy) ensureCopyIsNotOnReserve(db *gorm.DB) error {
 	}
 
 	if exists > 0 {
-		return externalerrors.BadRequest(fmt.Sprintf("book copy with id %d is on reserve", b.ID))
+		return externalerrors.BadRequest(fmt.Sprintf("book copy with id %d is on reserve", b.ID)).
+			WithCode(externalerrors.CopyOnReserve)
+	}
+
+	return nil
+}
+
+func (b *BookCopy) ensureCopyIsNotInTransit() error {
+	if b.Status != BookStatusInTransit {
+		return nil
+	}
+
+	return externalerrors.BadRequest(fmt.Sprintf("book copy with id %d is in transit", b.ID)).
+		WithCode(externalerrors.CopyInTransit)
+}
+
+func (b *BookCopy) ensureAccessionNumberIsUnique(db *gorm.DB) error {
+	var exists int64
+	result := db.Model(&BookCopy{}).
+		Unscoped(). // Accession numbers of deleted copies are not reused
+		Where("accession_number = ?", b.AccessionNumber).
+		Where("id <> ?", b.ID).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists > 0 {
+		return externalerrors.BadRequest(fmt.Sprintf("accession number %s is already in use", b.AccessionNumber)).
+			WithCode(externalerrors.AlreadyExists)
+	}
+
+	return nil
+}
+
+func (b *BookCopy) ValidateAccessionNumber(db *gorm.DB) error {
+	if b.AccessionNumber == "" {
+		return externalerrors.BadRequest("accession number is required")
+	}
+
+	if len(b.AccessionNumber) > MaximumAccessionNumberLength {
+		return externalerrors.BadRequest(fmt.Sprintf(
+			"accession number must be at most %d characters long", MaximumAccessionNumberLength,
+		))
+	}
+
+	if !accessionNumberReg.MatchString(b.AccessionNumber) {
+		return externalerrors.BadRequest("accession number may only contain letters, digits and hyphens")
+	}
+
+	return b.ensureAccessionNumberIsUnique(db)
+}
+
+func (b *BookCopy) ValidateAcquisition() error {
+	if b.AcquisitionPrice < 0 {
+		return externalerrors.BadRequest("acquisition price cannot be negative")
 	}
 
 	return nil
@@ -130,6 +231,7 @@ func (b *BookCopy) ValidateStatus() error {
 		BookStatusAvailable,
 		BookStatusOnLoan,
 		BookStatusOnReserve,
+		BookStatusInTransit,
 	}, b.Status) {
 		return externalerrors.BadRequest("invalid status")
 	}
@@ -142,6 +244,29 @@ func (b *BookCopy) Validate(db *gorm.DB) error {
 		return err
 	}
 
+	if b.HomeBranchID != 0 {
+		if err := EnsureBranchExists(db, b.HomeBranchID); err != nil {
+			return err
+		}
+	}
+
+	if b.CurrentBranchID != 0 && b.CurrentBranchID != b.HomeBranchID {
+		if err := EnsureBranchExists(db, b.CurrentBranchID); err != nil {
+			return err
+		}
+	}
+
+	// Updates may leave the accession number out
+	if b.ID == 0 || b.AccessionNumber != "" {
+		if err := b.ValidateAccessionNumber(db); err != nil {
+			return err
+		}
+	}
+
+	if err := b.ValidateAcquisition(); err != nil {
+		return err
+	}
+
 	return b.ValidateStatus()
 }
 
@@ -150,6 +275,22 @@ func (b *BookCopy) BeforeCreate(db *gorm.DB) error {
 		b.Status = BookStatusAvailable
 	}
 
+	if b.HomeBranchID == 0 {
+		b.HomeBranchID = DefaultBranchID
+	}
+
+	if b.CurrentBranchID == 0 {
+		b.CurrentBranchID = b.HomeBranchID
+	}
+
+	if b.AccessionNumber == "" {
+		accessionNumber, err := GenerateAccessionNumber(db)
+		if err != nil {
+			return err
+		}
+		b.AccessionNumber = accessionNumber
+	}
+
 	return b.Validate(db)
 }
 
@@ -162,5 +303,9 @@ func (b *BookCopy) BeforeDelete(db *gorm.DB) error {
 		return err
 	}
 
+	if err := b.ensureCopyIsNotInTransit(); err != nil {
+		return err
+	}
+
 	return b.ensureCopyIsNotOnReserve(db)
 }
@@ -0,0 +1,111 @@
+package model
+
+import (
+	"database/sql"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BookImportStatus = string
+
+// BookImport tracks a bulk import of books from a spreadsheet.
+// Rows are processed in the background, the counters report the progress.
+type BookImport struct {
+	gorm.Model
+
+	UserID        uint             `gorm:"not null"` // Staff who uploaded the file
+	User          *User            `gorm:"->"`
+	FileName      string           `gorm:"not null"`
+	Status        BookImportStatus `gorm:"not null"`
+	DryRun        bool             `gorm:"not null"`
+	TotalRows     int              `gorm:"not null"`
+	ProcessedRows int              `gorm:"not null"`
+	AcceptedRows  int              `gorm:"not null"`
+	RejectedRows  int              `gorm:"not null"`
+	FailureReason string           `gorm:"not null"`
+	FinishedAt    sql.NullTime
+	Errors        []BookImportError `gorm:"->"`
+}
+
+// BookImportError is a problem with one row of an import. Row 1 is the header row.
+type BookImportError struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	BookImportID uint   `gorm:"not null"`
+	Row          int    `gorm:"not null"`
+	ColumnName   string `gorm:"not null"`
+	Message      string `gorm:"not null"`
+}
+
+const (
+	BookImportModelName = "book_import"
+	BookImportTableName = "book_imports"
+)
+
+const (
+	BookImportStatusRunning   BookImportStatus = "running"
+	BookImportStatusCompleted BookImportStatus = "completed"
+	BookImportStatusFailed    BookImportStatus = "failed"
+)
+
+var BookImportStatuses = []BookImportStatus{
+	BookImportStatusRunning,
+	BookImportStatusCompleted,
+	BookImportStatusFailed,
+}
+
+func (b *BookImport) Create(db *gorm.DB) error {
+	return db.Create(b).Error
+}
+
+// UpdateProgress saves the counters and status, including zero values.
+func (b *BookImport) UpdateProgress(db *gorm.DB) error {
+	return db.Model(b).
+		Select("status", "processed_rows", "accepted_rows", "rejected_rows", "failure_reason", "finished_at").
+		Updates(b).
+		Error
+}
+
+func (b *BookImport) ValidateStatus() error {
+	if b.Status == "" {
+		return externalerrors.BadRequest("status is required")
+	}
+
+	if !sliceutil.Contains([]BookImportStatus{
+		BookImportStatusRunning,
+		BookImportStatusCompleted,
+		BookImportStatusFailed,
+	}, b.Status) {
+		return externalerrors.BadRequest("invalid status")
+	}
+
+	return nil
+}
+
+func (b *BookImport) Validate(_ *gorm.DB) error {
+	if b.UserID == 0 {
+		return externalerrors.BadRequest("user id is required")
+	}
+
+	if b.FileName == "" {
+		return externalerrors.BadRequest("file name is required")
+	}
+
+	return b.ValidateStatus()
+}
+
+func (b *BookImport) BeforeCreate(db *gorm.DB) error {
+	return b.Validate(db)
+}
+
+func (b *BookImport) BeforeUpdate(_ *gorm.DB) error {
+	return b.ValidateStatus()
+}
+
+func (e *BookImportError) Create(db *gorm.DB) error {
+	return db.Create(e).Error
+}
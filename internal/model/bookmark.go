@@ -80,7 +80,7 @@ func (b *Bookmark) ensureUserBookIsUnique(db *gorm.DB) error {
 	}
 
 	if exists > 0 {
-		return externalerrors.BadRequest("this bookmark already exists")
+		return externalerrors.BadRequest("this bookmark already exists").WithCode(externalerrors.AlreadyExists)
 	}
 
 	return nil
@@ -96,7 +96,8 @@ func (b *Bookmark) ensureMaxBookmarkPerUser(db *gorm.DB) error {
 	}
 
 	if count >= MaximumBookmarkPerUser {
-		return externalerrors.BadRequest(fmt.Sprintf("maximum bookmark per user is %d", MaximumBookmarkPerUser))
+		return externalerrors.BadRequest(fmt.Sprintf("maximum bookmark per user is %d", MaximumBookmarkPerUser)).
+			WithCode(externalerrors.BookmarkLimitReached)
 	}
 
 	return nil
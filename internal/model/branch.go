@@ -0,0 +1,157 @@
+package model
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+
+	"gorm.io/gorm"
+)
+
+type Branch struct {
+	gorm.Model
+
+	Name    string `gorm:"unique;not null"`
+	Address string `gorm:"not null"`
+}
+
+const (
+	BranchModelName = "branch"
+	BranchTableName = "branches"
+)
+
+const (
+	// DefaultBranchID is the branch created by the migration that introduced branches.
+	// Copies created without a branch are assigned to it.
+	DefaultBranchID = 1
+)
+
+func (b *Branch) Create(db *gorm.DB) error {
+	return db.Create(b).Error
+}
+
+func (b *Branch) Update(db *gorm.DB) error {
+	return db.Updates(b).Error
+}
+
+func (b *Branch) Delete(db *gorm.DB) error {
+	return db.Delete(b).Error
+}
+
+func (b *Branch) ensureNameIsUnique(db *gorm.DB) error {
+	var exists int64
+	result := db.Model(&Branch{}).
+		Where("name = ?", b.Name).
+		Where("id <> ?", b.ID).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists > 0 {
+		return externalerrors.BadRequest(fmt.Sprintf("branch with name %s already exists", b.Name)).
+			WithCode(externalerrors.AlreadyExists)
+	}
+
+	return nil
+}
+
+func (b *Branch) ensureBranchHasNoCopies(db *gorm.DB) error {
+	var exists int64
+	result := db.Model(&BookCopy{}).
+		Where("home_branch_id = ? OR current_branch_id = ?", b.ID, b.ID).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists > 0 {
+		return externalerrors.Conflict(fmt.Sprintf("branch with id %d still has book copies", b.ID)).
+			WithCode(externalerrors.BranchHasCopies)
+	}
+
+	return nil
+}
+
+func (b *Branch) ensureBranchHasNoPendingReservations(db *gorm.DB) error {
+	var exists int64
+	result := db.Model(&Reservation{}).
+		Where("pickup_branch_id = ?", b.ID).
+		Where("status = ?", ReservationStatusPending).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists > 0 {
+		return externalerrors.Conflict(fmt.Sprintf("branch with id %d is the pickup branch of pending reservations", b.ID)).
+			WithCode(externalerrors.BranchHasReservations)
+	}
+
+	return nil
+}
+
+func (b *Branch) ensureBranchHasNoStaff(db *gorm.DB) error {
+	var exists int64
+	result := db.Model(&UserBranch{}).
+		Where("branch_id = ?", b.ID).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists > 0 {
+		return externalerrors.Conflict(fmt.Sprintf("branch with id %d still has staff assigned to it", b.ID)).
+			WithCode(externalerrors.BranchHasStaff)
+	}
+
+	return nil
+}
+
+func (b *Branch) Validate(db *gorm.DB) error {
+	if b.Name == "" {
+		return externalerrors.BadRequest("name is required")
+	}
+
+	return b.ensureNameIsUnique(db)
+}
+
+func (b *Branch) BeforeCreate(db *gorm.DB) error {
+	return b.Validate(db)
+}
+
+func (b *Branch) BeforeUpdate(db *gorm.DB) error {
+	return b.Validate(db)
+}
+
+func (b *Branch) BeforeDelete(db *gorm.DB) error {
+	if b.ID == DefaultBranchID {
+		return externalerrors.BadRequest("the default branch cannot be deleted").WithCode(externalerrors.DefaultBranch)
+	}
+
+	if err := b.ensureBranchHasNoCopies(db); err != nil {
+		return err
+	}
+
+	if err := b.ensureBranchHasNoPendingReservations(db); err != nil {
+		return err
+	}
+
+	return b.ensureBranchHasNoStaff(db)
+}
+
+// EnsureBranchExists returns a bad request error if no branch with the given ID exists.
+func EnsureBranchExists(db *gorm.DB, branchID uint) error {
+	var exists int64
+	result := db.Model(&Branch{}).
+		Where("id = ?", branchID).
+		Count(&exists)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if exists == 0 {
+		return externalerrors.BadRequest(fmt.Sprintf("branch with id %d does not exist", branchID))
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ContributorRole = string
+
+type Contributor struct {
+	gorm.Model
+
+	Name             string            `gorm:"unique;not null"`
+	BookContributors []BookContributor `gorm:"->"`
+}
+
+// BookContributor links a contributor to a book in a given role.
+// Position orders the contributors of a book as they appear on the title page.
+type BookContributor struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	BookID        uint            `gorm:"not null"`
+	ContributorID uint            `gorm:"not null"`
+	Contributor   *Contributor    `gorm:"->"`
+	Role          ContributorRole `gorm:"not null"`
+	Position      int             `gorm:"not null"`
+}
+
+const (
+	ContributorModelName = "contributor"
+	ContributorTableName = "contributors"
+)
+
+const (
+	ContributorRoleAuthor      ContributorRole = "author"
+	ContributorRoleEditor      ContributorRole = "editor"
+	ContributorRoleTranslator  ContributorRole = "translator"
+	ContributorRoleIllustrator ContributorRole = "illustrator"
+)
+
+func ContributorRoles() []ContributorRole {
+	return []ContributorRole{
+		ContributorRoleAuthor,
+		ContributorRoleEditor,
+		ContributorRoleTranslator,
+		ContributorRoleIllustrator,
+	}
+}
+
+func ValidateContributorRole(role ContributorRole) error {
+	if !sliceutil.Contains(ContributorRoles(), role) {
+		return externalerrors.BadRequest(fmt.Sprintf(
+			"%s is not a valid contributor role, expected one of %s",
+			role, strings.Join(ContributorRoles(), ", "),
+		)).WithCode(externalerrors.InvalidValue)
+	}
+
+	return nil
+}
+
+func (c *Contributor) Create(db *gorm.DB) error {
+	return db.Create(c).Error
+}
+
+func (c *Contributor) Validate(_ *gorm.DB) error {
+	if c.Name == "" {
+		return externalerrors.BadRequest("contributor name is required")
+	}
+
+	return nil
+}
+
+func (c *Contributor) BeforeCreate(db *gorm.DB) error {
+	return c.Validate(db)
+}
+
+// FindOrCreateContributor returns the contributor with the given name, creating it if necessary.
+func FindOrCreateContributor(db *gorm.DB, name string) (*Contributor, error) {
+	contributor := Contributor{Name: strings.TrimSpace(name)}
+	if err := contributor.Validate(db); err != nil {
+		return nil, err
+	}
+
+	result := db.Where("name = ?", contributor.Name).
+		FirstOrCreate(&contributor)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &contributor, nil
+}
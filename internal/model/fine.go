@@ -30,6 +30,11 @@ const (
 	FineStatusPaid        FineStatus = "paid"
 )
 
+var FineStatuses = []FineStatus{
+	FineStatusOutstanding,
+	FineStatusPaid,
+}
+
 const (
 	OverdueFine = 1000
 )
@@ -2,6 +2,7 @@ package model
 
 import (
 	"database/sql"
+	"lms-backend/internal/orm"
 	"lms-backend/pkg/error/externalerrors"
 	"lms-backend/util/sliceutil"
 	"time"
@@ -24,6 +25,7 @@ type Loan struct {
 	ReturnDate    sql.NullTime  // Date when the book is returned
 	LoanHistories []LoanHistory `gorm:"->;<-:create"`
 	Fines         []Fine        `gorm:"->;<-:create"`
+	Version       uint          `gorm:"not null;default:1"` // Incremented on every update
 }
 
 const (
@@ -36,6 +38,11 @@ const (
 	LoanStatusReturned LoanStatus = "returned"
 )
 
+var LoanStatuses = []LoanStatus{
+	LoanStatusBorrowed,
+	LoanStatusReturned,
+}
+
 const (
 	LoanDuration        = 7 * 24 * time.Hour
 	MaximumLoanDuration = 30 * 24 * time.Hour
@@ -43,7 +50,13 @@ const (
 )
 
 func (l *Loan) Create(db *gorm.DB) error {
-	return db.Create(l).Error
+	err := db.Create(l).Error
+	// Only one active loan of a copy is allowed by the database, in case of a concurrent loan
+	if orm.IsDuplicatedKey(orm.TranslateError(db, err)) {
+		return externalerrors.BadRequest("Book is already on loan").WithCode(externalerrors.CopyOnLoan)
+	}
+
+	return err
 }
 
 func (l *Loan) Update(db *gorm.DB) error {
@@ -55,7 +68,7 @@ func (l *Loan) Update(db *gorm.DB) error {
 		}
 	}
 
-	return db.Updates(l).Error
+	return orm.UpdateVersioned(db, l, &l.Version, LoanModelName)
 }
 
 // Need to call preloadAssociations	before calling this method.
@@ -72,7 +85,7 @@ func (l *Loan) Delete(db *gorm.DB) error {
 		}
 	}
 
-	return db.Delete(l).Error
+	return orm.DeleteVersioned(db, l, l.Version, LoanModelName)
 }
 
 func (l *Loan) ensureUserExistsAndPresent(db *gorm.DB) error {
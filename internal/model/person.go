@@ -1,6 +1,7 @@
 package model
 
 import (
+	"lms-backend/internal/orm"
 	"lms-backend/pkg/error/externalerrors"
 	"unicode/utf8"
 
@@ -12,9 +13,11 @@ type Person struct {
 
 	FullName      string `gorm:"not null"`
 	PreferredName string
+	Version       uint `gorm:"not null;default:1"` // Incremented on every update
 }
 
 const (
+	PersonModelName = "person"
 	PersonTableName = "people"
 )
 
@@ -32,11 +35,11 @@ func (p *Person) Create(db *gorm.DB) error {
 }
 
 func (p *Person) Update(db *gorm.DB) error {
-	return db.Updates(p).Error
+	return orm.UpdateVersioned(db, p, &p.Version, PersonModelName)
 }
 
 func (p *Person) Delete(db *gorm.DB) error {
-	return db.Delete(p).Error
+	return orm.DeleteVersioned(db, p, p.Version, PersonModelName)
 }
 
 func (p *Person) ValidateName() error {
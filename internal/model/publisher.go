@@ -0,0 +1,52 @@
+package model
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Publisher struct {
+	gorm.Model
+
+	Name  string `gorm:"unique;not null"`
+	Books []Book `gorm:"many2many:book_publishers;->"`
+}
+
+const (
+	PublisherModelName = "publisher"
+	PublisherTableName = "publishers"
+)
+
+func (p *Publisher) Create(db *gorm.DB) error {
+	return db.Create(p).Error
+}
+
+func (p *Publisher) Validate(_ *gorm.DB) error {
+	if p.Name == "" {
+		return externalerrors.BadRequest("publisher name is required")
+	}
+
+	return nil
+}
+
+func (p *Publisher) BeforeCreate(db *gorm.DB) error {
+	return p.Validate(db)
+}
+
+// FindOrCreatePublisher returns the publisher with the given name, creating it if necessary.
+func FindOrCreatePublisher(db *gorm.DB, name string) (*Publisher, error) {
+	publisher := Publisher{Name: strings.TrimSpace(name)}
+	if err := publisher.Validate(db); err != nil {
+		return nil, err
+	}
+
+	result := db.Where("name = ?", publisher.Name).
+		FirstOrCreate(&publisher)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &publisher, nil
+}
@@ -19,6 +19,8 @@ type Reservation struct {
 	BookCopy        *BookCopy         `gorm:"->"`
 	Status          ReservationStatus `gorm:"not null"`
 	ReservationDate time.Time         `gorm:"not null"` // Date before which the book is reserved
+	PickupBranchID  uint              `gorm:"not null"` // Branch at which the patron collects the book
+	PickupBranch    *Branch           `gorm:"->"`
 }
 
 const (
@@ -31,6 +33,11 @@ const (
 	ReservationStatusFulfilled ReservationStatus = "fulfilled"
 )
 
+var ReservationStatuses = []ReservationStatus{
+	ReservationStatusPending,
+	ReservationStatusFulfilled,
+}
+
 const (
 	MaximumReservations = 2
 	ReservationDuration = 7 * 24 * time.Hour
@@ -118,6 +125,14 @@ func (r *Reservation) Validate(db *gorm.DB) error {
 		return externalerrors.BadRequest("reservation date is required")
 	}
 
+	if r.PickupBranchID == 0 {
+		return externalerrors.BadRequest("pickup branch is required")
+	}
+
+	if err := EnsureBranchExists(db, r.PickupBranchID); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Subject struct {
+	gorm.Model
+
+	Name  string `gorm:"unique;not null"`
+	Books []Book `gorm:"many2many:book_subjects;->"`
+}
+
+const (
+	SubjectModelName = "subject"
+	SubjectTableName = "subjects"
+)
+
+func (s *Subject) Create(db *gorm.DB) error {
+	return db.Create(s).Error
+}
+
+func (s *Subject) Validate(_ *gorm.DB) error {
+	if s.Name == "" {
+		return externalerrors.BadRequest("subject name is required")
+	}
+
+	return nil
+}
+
+func (s *Subject) BeforeCreate(db *gorm.DB) error {
+	return s.Validate(db)
+}
+
+// FindOrCreateSubject returns the subject with the given name, creating it if necessary.
+func FindOrCreateSubject(db *gorm.DB, name string) (*Subject, error) {
+	subject := Subject{Name: strings.TrimSpace(name)}
+	if err := subject.Validate(db); err != nil {
+		return nil, err
+	}
+
+	result := db.Where("name = ?", subject.Name).
+		FirstOrCreate(&subject)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &subject, nil
+}
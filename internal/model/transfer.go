@@ -0,0 +1,104 @@
+package model
+
+import (
+	"database/sql"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferStatus = string
+
+// Transfer records a book copy being moved from one branch to another.
+type Transfer struct {
+	gorm.Model
+
+	BookCopyID   uint           `gorm:"not null"`
+	BookCopy     *BookCopy      `gorm:"->"`
+	FromBranchID uint           `gorm:"not null"`
+	FromBranch   *Branch        `gorm:"->"`
+	ToBranchID   uint           `gorm:"not null"`
+	ToBranch     *Branch        `gorm:"->"`
+	UserID       uint           `gorm:"not null"` // Staff who sent the copy
+	User         *User          `gorm:"->"`
+	Status       TransferStatus `gorm:"not null"`
+	SentAt       time.Time      `gorm:"not null"`
+	ReceivedAt   sql.NullTime
+}
+
+const (
+	TransferModelName = "transfer"
+	TransferTableName = "transfers"
+)
+
+const (
+	TransferStatusInTransit TransferStatus = "in_transit"
+	TransferStatusReceived  TransferStatus = "received"
+)
+
+var TransferStatuses = []TransferStatus{
+	TransferStatusInTransit,
+	TransferStatusReceived,
+}
+
+func (t *Transfer) Create(db *gorm.DB) error {
+	return db.Create(t).Error
+}
+
+func (t *Transfer) Update(db *gorm.DB) error {
+	return db.Updates(t).Error
+}
+
+func (t *Transfer) Delete(db *gorm.DB) error {
+	return db.Delete(t).Error
+}
+
+func (t *Transfer) ValidateStatus() error {
+	if t.Status == "" {
+		return externalerrors.BadRequest("status is required")
+	}
+
+	if !sliceutil.Contains([]TransferStatus{
+		TransferStatusInTransit,
+		TransferStatusReceived,
+	}, t.Status) {
+		return externalerrors.BadRequest("invalid status")
+	}
+
+	return nil
+}
+
+func (t *Transfer) Validate(db *gorm.DB) error {
+	if t.BookCopyID == 0 {
+		return externalerrors.BadRequest("book copy id is required")
+	}
+
+	if t.FromBranchID == t.ToBranchID {
+		return externalerrors.BadRequest("book copy is already at the destination branch").
+			WithCode(externalerrors.CopyAtDestination)
+	}
+
+	if err := EnsureBranchExists(db, t.FromBranchID); err != nil {
+		return err
+	}
+
+	if err := EnsureBranchExists(db, t.ToBranchID); err != nil {
+		return err
+	}
+
+	if t.SentAt.IsZero() {
+		return externalerrors.BadRequest("sent at is required")
+	}
+
+	return t.ValidateStatus()
+}
+
+func (t *Transfer) BeforeCreate(db *gorm.DB) error {
+	return t.Validate(db)
+}
+
+func (t *Transfer) BeforeUpdate(db *gorm.DB) error {
+	return t.Validate(db)
+}
@@ -20,13 +20,18 @@ type User struct {
 	SignInCount       int    `gorm:"not null;default:0"`
 	CurrentSignInAt   time.Time
 	LastSignInAt      time.Time
-	PersonID          uint          `gorm:"not null"`
-	Person            *Person       `gorm:"->;<-:create"`
-	Roles             []Role        `gorm:"many2many:user_roles;->"`
-	Bookmarks         []Bookmark    `gorm:"->"`
-	Loans             []Loan        `gorm:"->"`
-	Reservations      []Reservation `gorm:"->"`
-	Fines             []Fine        `gorm:"->"`
+	// Language and IANA time zone of the messages to the user, empty to negotiate them
+	Locale       string        `gorm:"not null;default:''"`
+	TimeZone     string        `gorm:"not null;default:''"`
+	PersonID     uint          `gorm:"not null"`
+	Person       *Person       `gorm:"->;<-:create"`
+	Roles        []Role        `gorm:"many2many:user_roles;->"`
+	Branches     []Branch      `gorm:"many2many:user_branches;->"`
+	Bookmarks    []Bookmark    `gorm:"->"`
+	Loans        []Loan        `gorm:"->"`
+	Reservations []Reservation `gorm:"->"`
+	Fines        []Fine        `gorm:"->"`
+	Version      uint          `gorm:"not null;default:1"` // Incremented on every update
 }
 
 var (
@@ -64,7 +69,7 @@ func (u *User) ensureUsernameIsNew(db *gorm.DB) error {
 	}
 
 	if exists > 0 {
-		return externalerrors.BadRequest("username already exists")
+		return externalerrors.BadRequest("username already exists").WithCode(externalerrors.AlreadyExists)
 	}
 
 	return nil
@@ -209,11 +214,11 @@ func (u *User) Create(db *gorm.DB) error {
 }
 
 func (u *User) Update(db *gorm.DB) error {
-	return db.Updates(u).Error
+	return orm.UpdateVersioned(db, u, &u.Version, UserModelName)
 }
 
 func (u *User) Delete(db *gorm.DB) error {
-	return db.Delete(u).Error
+	return orm.DeleteVersioned(db, u, u.Version, UserModelName)
 }
 
 func (u *User) BeforeCreate(db *gorm.DB) error {
@@ -285,3 +290,30 @@ func (u *User) UpdateRoles(db *gorm.DB, roleIDs []int64) error {
 
 	return nil
 }
+
+func (u *User) UpdateBranches(db *gorm.DB, branchIDs []int64) error {
+	// Remove all existing branches
+	result := db.
+		Where("user_id = ?", u.ID).
+		Delete(&UserBranch{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// Add new branches
+	for _, branchID := range branchIDs {
+		if err := EnsureBranchExists(db, uint(branchID)); err != nil {
+			return err
+		}
+
+		err := db.Exec(
+			"INSERT INTO user_branches (user_id, branch_id) VALUES (?, ?)",
+			u.ID, branchID,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+// UserBranch scopes a staff member to a branch.
+// Staff without any branch are not restricted to any branch.
+type UserBranch struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	UserID   uint `gorm:"not null"`
+	BranchID uint `gorm:"not null"`
+}
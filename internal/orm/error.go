@@ -3,8 +3,8 @@ package orm
 import (
 	"errors"
 	"fmt"
+	"lms-backend/pkg/error/externalerrors"
 
-	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
@@ -16,13 +16,21 @@ func IsDuplicatedKey(err error) bool {
 	return errors.Is(err, gorm.ErrDuplicatedKey)
 }
 
+// TranslateError converts an error of the database to those of gorm, e.g. gorm.ErrDuplicatedKey,
+// as the connection does not translate them.
+func TranslateError(db *gorm.DB, err error) error {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		return translator.Translate(err)
+	}
+
+	return err
+}
+
 func IsForeignKeyViolated(err error) bool {
 	return errors.Is(err, gorm.ErrForeignKeyViolated)
 }
 
 func ErrRecordNotFound(modelName string) error {
-	return fiber.NewError(
-		fiber.StatusBadRequest,
-		fmt.Sprintf("%s not found", modelName),
-	)
+	return externalerrors.BadRequest(fmt.Sprintf("%s not found", modelName)).
+		WithCode(externalerrors.RecordNotFound)
 }
@@ -0,0 +1,67 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// FuzzyMatchLimit is the number of suggestions returned for autocomplete.
+	FuzzyMatchLimit = 5
+	// prefixBoost is added to the score of fields starting with the value, so that completing
+	// what was typed ranks above fixing typos.
+	prefixBoost = 1
+)
+
+// FuzzyField is a column searched by FuzzyMatches. Name is reported as the matched field.
+type FuzzyField struct {
+	Name   string
+	Column string
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes the wildcards of a LIKE pattern.
+func EscapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
+
+// FuzzyMatches keeps the rows of which any field resembles value, ignoring case and accents.
+//
+// Fields are compared by trigram word similarity, with a boost for fields starting with value.
+// Each row is selected once with its best field as matched_field, matched_value and score,
+// followed by the given columns. idColumn must be the primary key of the rows.
+// The result is meant to be used as a subquery ordered by score.
+func FuzzyMatches(db *gorm.DB, idColumn, value string, fields []FuzzyField, columns ...string) *gorm.DB {
+	values := []string{}
+	conditions := []string{}
+	for _, field := range fields {
+		values = append(values, fmt.Sprintf("('%s', %s)", field.Name, field.Column))
+		conditions = append(conditions, fmt.Sprintf(
+			"(immutable_unaccent(@value) <%% immutable_unaccent(%[1]s) OR immutable_unaccent(%[1]s) ILIKE immutable_unaccent(@prefix))",
+			field.Column,
+		))
+	}
+
+	selects := append([]string{
+		fmt.Sprintf("DISTINCT ON (%s) fuzzy_fields.name AS matched_field", idColumn),
+		"fuzzy_fields.value AS matched_value",
+		fmt.Sprintf(
+			"word_similarity(immutable_unaccent(@value), immutable_unaccent(fuzzy_fields.value)) + "+
+				"CASE WHEN immutable_unaccent(fuzzy_fields.value) ILIKE immutable_unaccent(@prefix) THEN %d ELSE 0 END AS score",
+			prefixBoost,
+		),
+	}, columns...)
+
+	named := []interface{}{sql.Named("value", value), sql.Named("prefix", EscapeLike(value)+"%")}
+
+	return db.Select(strings.Join(selects, ", "), named...).
+		Joins(fmt.Sprintf("CROSS JOIN LATERAL (VALUES %s) AS fuzzy_fields(name, value)", strings.Join(values, ", "))).
+		Where(strings.Join(conditions, " OR "), named...).
+		Where("fuzzy_fields.value IS NOT NULL").
+		Order(idColumn).
+		Order("score DESC")
+}
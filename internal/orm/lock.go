@@ -0,0 +1,15 @@
+package orm
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ForUpdate locks the rows read until the end of the transaction, so that concurrent
+// requests cannot change them between them being checked and updated.
+//
+// Rows should be locked in the same order by every request, e.g. the user before their
+// book copies, so that requests do not deadlock.
+func ForUpdate(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"})
+}
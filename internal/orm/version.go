@@ -0,0 +1,87 @@
+package orm
+
+import (
+	"errors"
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Records with a version are changed only at the version they were read at, and their
+// version is incremented on every update. A version of 0 is unknown and not checked.
+
+func ErrVersionConflict(modelName string) error {
+	return externalerrors.PreconditionFailed(fmt.Sprintf("%s was changed since it was read", modelName)).
+		WithCode(externalerrors.VersionConflict)
+}
+
+func IsVersionConflict(err error) bool {
+	var externalErr *externalerrors.Error
+	return errors.As(err, &externalErr) && externalErr.Code == externalerrors.VersionConflict
+}
+
+// EnsureVersion fails if the record is no longer at the expected version.
+func EnsureVersion(modelName string, version, expected uint) error {
+	if expected != 0 && version != expected {
+		return ErrVersionConflict(modelName)
+	}
+
+	return nil
+}
+
+// UpdateVersioned updates the record as db.Updates does, where it is still at its version,
+// and increments the version. If columns are given, only those are updated, including
+// their zero values.
+func UpdateVersioned(db *gorm.DB, record interface{}, version *uint, modelName string, columns ...string) error {
+	if *version == 0 {
+		tx := db
+		if len(columns) > 0 {
+			tx = tx.Select(columns)
+		}
+		if err := tx.Updates(record).Error; err != nil {
+			return err
+		}
+
+		// Returned into the record, as its version is not known
+		return db.Model(record).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "version"}}}).
+			UpdateColumn("version", gorm.Expr("version + 1")).Error
+	}
+
+	expected := *version
+	*version = expected + 1
+
+	tx := db.Where("version = ?", expected)
+	if len(columns) > 0 {
+		tx = tx.Select(append(columns, "version"))
+	}
+
+	result := tx.Updates(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict(modelName)
+	}
+	if result.Error != nil {
+		*version = expected
+	}
+
+	return result.Error
+}
+
+// DeleteVersioned deletes the record where it is still at its version.
+func DeleteVersioned(db *gorm.DB, record interface{}, version uint, modelName string) error {
+	if version == 0 {
+		return db.Delete(record).Error
+	}
+
+	result := db.Where("version = ?", version).Delete(record)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict(modelName)
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type versionedRecord struct {
+	ID      uint
+	Title   string
+	Version uint
+}
+
+// fakeVersions is a database in which statements affect rowsAffected rows, and queries
+// return version 8. The statements are kept to be checked.
+type fakeVersions struct {
+	rowsAffected int64
+	statements   []string
+}
+
+func (db *fakeVersions) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeVersions) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct {
+	db *fakeVersions
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.db.statements = append(c.db.statements, query)
+	return driver.RowsAffected(c.db.rowsAffected), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.db.statements = append(c.db.statements, query)
+	return &fakeRows{}, nil
+}
+
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"version"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+
+	dest[0] = int64(8)
+	r.done = true
+	return nil
+}
+
+func openFakeVersions(t *testing.T, rowsAffected int64) (*gorm.DB, *fakeVersions) {
+	t.Helper()
+
+	fake := &fakeVersions{rowsAffected: rowsAffected}
+	db, err := gorm.Open(
+		postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}),
+		&gorm.Config{Logger: logger.Discard, SkipDefaultTransaction: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db, fake
+}
+
+func TestUpdateVersioned(t *testing.T) {
+	tests := []struct {
+		name           string
+		version        uint
+		rowsAffected   int64
+		wantVersion    uint
+		wantConflict   bool
+		wantStatements []string
+	}{
+		{
+			name:         "current version",
+			version:      3,
+			rowsAffected: 1,
+			wantVersion:  4,
+			wantStatements: []string{
+				`UPDATE "versioned_records" SET "title"=$1,"version"=$2 WHERE version = $3 AND "id" = $4`,
+			},
+		},
+		{
+			name:         "stale version",
+			version:      3,
+			rowsAffected: 0,
+			wantVersion:  3,
+			wantConflict: true,
+			wantStatements: []string{
+				`UPDATE "versioned_records" SET "title"=$1,"version"=$2 WHERE version = $3 AND "id" = $4`,
+			},
+		},
+		{
+			name:         "any version",
+			version:      0,
+			rowsAffected: 1,
+			wantVersion:  8,
+			wantStatements: []string{
+				`UPDATE "versioned_records" SET "title"=$1 WHERE "id" = $2`,
+				`UPDATE "versioned_records" SET "version"=version + 1 WHERE "id" = $1 RETURNING "version"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := openFakeVersions(t, tt.rowsAffected)
+
+			record := versionedRecord{ID: 1, Title: "Dune", Version: tt.version}
+			err := UpdateVersioned(db, &record, &record.Version, "record")
+
+			if IsVersionConflict(err) != tt.wantConflict {
+				t.Fatalf("UpdateVersioned() error = %v, want conflict %t", err, tt.wantConflict)
+			}
+			if !tt.wantConflict && err != nil {
+				t.Fatalf("UpdateVersioned() error = %v", err)
+			}
+			if record.Version != tt.wantVersion {
+				t.Errorf("version = %d, want %d", record.Version, tt.wantVersion)
+			}
+			if got := strings.Join(fake.statements, "\n"); got != strings.Join(tt.wantStatements, "\n") {
+				t.Errorf("statements = %s, want %s", got, strings.Join(tt.wantStatements, "\n"))
+			}
+		})
+	}
+}
+
+func TestUpdateVersionedColumns(t *testing.T) {
+	db, fake := openFakeVersions(t, 1)
+
+	record := versionedRecord{ID: 1, Version: 3}
+	if err := UpdateVersioned(db, &record, &record.Version, "record", "title"); err != nil {
+		t.Fatalf("UpdateVersioned() error = %v", err)
+	}
+
+	// Zero values of the columns are updated
+	want := `UPDATE "versioned_records" SET "title"=$1,"version"=$2 WHERE version = $3 AND "id" = $4`
+	if len(fake.statements) != 1 || fake.statements[0] != want {
+		t.Errorf("statements = %v, want %s", fake.statements, want)
+	}
+}
+
+func TestDeleteVersioned(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       uint
+		rowsAffected  int64
+		wantConflict  bool
+		wantStatement string
+	}{
+		{
+			name:          "current version",
+			version:       3,
+			rowsAffected:  1,
+			wantStatement: `DELETE FROM "versioned_records" WHERE version = $1 AND "versioned_records"."id" = $2`,
+		},
+		{
+			name:          "stale version",
+			version:       3,
+			rowsAffected:  0,
+			wantConflict:  true,
+			wantStatement: `DELETE FROM "versioned_records" WHERE version = $1 AND "versioned_records"."id" = $2`,
+		},
+		{
+			name:          "any version",
+			version:       0,
+			rowsAffected:  1,
+			wantStatement: `DELETE FROM "versioned_records" WHERE "versioned_records"."id" = $1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := openFakeVersions(t, tt.rowsAffected)
+
+			record := versionedRecord{ID: 1, Version: tt.version}
+			err := DeleteVersioned(db, &record, record.Version, "record")
+
+			if IsVersionConflict(err) != tt.wantConflict {
+				t.Fatalf("DeleteVersioned() error = %v, want conflict %t", err, tt.wantConflict)
+			}
+			if !tt.wantConflict && err != nil {
+				t.Fatalf("DeleteVersioned() error = %v", err)
+			}
+			if len(fake.statements) != 1 || fake.statements[0] != tt.wantStatement {
+				t.Errorf("statements = %v, want %s", fake.statements, tt.wantStatement)
+			}
+		})
+	}
+}
@@ -2,34 +2,29 @@ package auditlogparams
 
 import (
 	"lms-backend/internal/model"
-	"time"
-
-	"github.com/gofiber/fiber/v2"
+	"lms-backend/pkg/error/externalerrors"
 )
 
+// BaseParams is a note added to the audit log. It is dated when it is created, as entries
+// are chained in the order they are added.
 type BaseParams struct {
 	Action string `json:"action"`
-	Date   string `json:"date"`
 }
 
 func (b *BaseParams) Validate() error {
-	if b.Action == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "action is required")
-	}
+	v := externalerrors.Validation{}
 
-	if _, err := time.Parse(time.RFC3339, b.Date); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "date does not match RFC3339 format")
+	if b.Action == "" {
+		v.Add("action", externalerrors.Required, "action is required")
 	}
 
-	return nil
+	return v.Err()
 }
 
 func (b *BaseParams) ToModel(userID int64) *model.AuditLog {
-	//nolint // err is checked in Validate()
-	date, _ := time.Parse(time.RFC3339, b.Date)
 	return &model.AuditLog{
-		UserID: uint(userID),
-		Action: b.Action,
-		Date:   date,
+		UserID:     uint(userID),
+		Action:     b.Action,
+		ActionType: model.AuditLogActionNote,
 	}
 }
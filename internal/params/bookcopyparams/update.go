@@ -0,0 +1,77 @@
+package bookcopyparams
+
+import (
+	"database/sql"
+	"encoding/json"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/money"
+	"time"
+)
+
+type UpdateParams struct {
+	ID                uint        `json:"id"`
+	AccessionNumber   string      `json:"accession_number"`
+	CallNumber        string      `json:"call_number"`
+	ShelfLocation     string      `json:"shelf_location"`
+	AcquisitionDate   string      `json:"acquisition_date"`
+	AcquisitionSource string      `json:"acquisition_source"`
+	AcquisitionPrice  json.Number `json:"acquisition_price"` // Parsed in Validate, to reject fractions of cents
+}
+
+func (p *UpdateParams) Validate(bookcopyID int64) error {
+	v := externalerrors.Validation{}
+
+	if p.ID == 0 {
+		v.Add("id", externalerrors.Required, "id is required")
+	} else if p.ID != uint(bookcopyID) {
+		v.Add("id", externalerrors.MismatchedID, "book copy ID is inconsistent with url")
+	}
+
+	if p.AccessionNumber == "" {
+		v.Add("accession_number", externalerrors.Required, "accession_number is required")
+	}
+
+	if p.AcquisitionDate != "" {
+		if _, err := time.Parse(time.RFC3339, p.AcquisitionDate); err != nil {
+			v.Add("acquisition_date", externalerrors.InvalidFormat, "acquisition_date does not match RFC3339 format")
+		}
+	}
+
+	if price, err := p.acquisitionPrice(); err != nil {
+		v.Add("acquisition_price", externalerrors.InvalidValue, err.Error())
+	} else if price < 0 {
+		v.Add("acquisition_price", externalerrors.InvalidValue, "acquisition_price cannot be negative")
+	}
+
+	return v.Err()
+}
+
+// acquisitionPrice is 0 if no price is given.
+func (p *UpdateParams) acquisitionPrice() (money.Amount, error) {
+	if p.AcquisitionPrice == "" {
+		return 0, nil
+	}
+
+	return money.Parse(p.AcquisitionPrice.String())
+}
+
+func (p *UpdateParams) ToModel() *model.BookCopy {
+	//nolint // err is checked in Validate()
+	acquisitionDate, _ := time.Parse(time.RFC3339, p.AcquisitionDate)
+	//nolint // err is checked in Validate()
+	acquisitionPrice, _ := p.acquisitionPrice()
+	bookCopy := &model.BookCopy{
+		AccessionNumber: p.AccessionNumber,
+		CallNumber:      p.CallNumber,
+		ShelfLocation:   p.ShelfLocation,
+		AcquisitionDate: sql.NullTime{
+			Time:  acquisitionDate,
+			Valid: p.AcquisitionDate != "",
+		},
+		AcquisitionSource: p.AcquisitionSource,
+		AcquisitionPrice:  acquisitionPrice,
+	}
+	bookCopy.ID = p.ID
+	return bookCopy
+}
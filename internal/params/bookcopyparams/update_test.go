@@ -0,0 +1,54 @@
+package bookcopyparams
+
+import (
+	"encoding/json"
+	"errors"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/pkg/money"
+	"testing"
+)
+
+func TestUpdateParamsAcquisitionPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    money.Amount
+		wantErr bool
+	}{
+		{name: "no price", body: `{"id":1,"accession_number":"A1"}`, want: 0},
+		{name: "whole number", body: `{"id":1,"accession_number":"A1","acquisition_price":35}`, want: 3500},
+		{name: "cents", body: `{"id":1,"accession_number":"A1","acquisition_price":35.9}`, want: 3590},
+		{name: "string", body: `{"id":1,"accession_number":"A1","acquisition_price":"0.10"}`, want: 10},
+		{name: "fractions of cents", body: `{"id":1,"accession_number":"A1","acquisition_price":35.999}`, wantErr: true},
+		{name: "exponent", body: `{"id":1,"accession_number":"A1","acquisition_price":3e2}`, wantErr: true},
+		{name: "negative", body: `{"id":1,"accession_number":"A1","acquisition_price":-1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p UpdateParams
+			if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+				t.Fatal(err)
+			}
+
+			err := p.Validate(1)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				if got := p.ToModel().AcquisitionPrice; got != tt.want {
+					t.Errorf("ToModel().AcquisitionPrice = %s, want %s", got, tt.want)
+				}
+				return
+			}
+
+			var validationErr *externalerrors.Error
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a validation error", err)
+			}
+			if len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "acquisition_price" {
+				t.Errorf("Validate() fields = %v, want an acquisition_price error", validationErr.Fields)
+			}
+		})
+	}
+}
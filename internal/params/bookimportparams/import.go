@@ -0,0 +1,49 @@
+package bookimportparams
+
+import (
+	"encoding/json"
+	"lms-backend/internal/bookimporter"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+// ImportParams are sent as form fields next to the uploaded file.
+// Mapping is a JSON object of book fields to column headers.
+type ImportParams struct {
+	Mapping  string `form:"mapping"`
+	BranchID int64  `form:"branch_id"`
+}
+
+func (p *ImportParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.Mapping == "" {
+		v.Add("mapping", externalerrors.Required, "mapping is required")
+	} else if _, err := p.ToMapping(); err != nil {
+		v.Add("mapping", externalerrors.InvalidFormat, "mapping must be a JSON object of book fields to column headers")
+	}
+
+	if p.BranchID < 0 {
+		v.Add("branch_id", externalerrors.InvalidValue, "branch_id must be positive")
+	}
+
+	return v.Err()
+}
+
+func (p *ImportParams) ToMapping() (bookimporter.Mapping, error) {
+	var mapping bookimporter.Mapping
+	if err := json.Unmarshal([]byte(p.Mapping), &mapping); err != nil {
+		return nil, err
+	}
+
+	return mapping, nil
+}
+
+// DefaultBranchID is the branch of copies from rows that do not name one.
+func (p *ImportParams) DefaultBranchID() int64 {
+	if p.BranchID == 0 {
+		return model.DefaultBranchID
+	}
+
+	return p.BranchID
+}
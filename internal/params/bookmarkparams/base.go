@@ -11,15 +11,17 @@ type BaseParams struct {
 }
 
 func (b *BaseParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if b.UserID <= 0 {
-		return externalerrors.BadRequest("user id is required")
+		v.Add("user_id", externalerrors.Required, "user id is required")
 	}
 
 	if b.BookID <= 0 {
-		return externalerrors.BadRequest("book id is required")
+		v.Add("book_id", externalerrors.Required, "book id is required")
 	}
 
-	return nil
+	return v.Err()
 }
 
 func (b *BaseParams) ToModel() *model.Bookmark {
@@ -1,56 +1,100 @@
 package bookparams
 
 import (
+	"fmt"
 	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strings"
 	"time"
-
-	"github.com/gofiber/fiber/v2"
 )
 
+type ContributorParams struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
 type BaseParams struct {
-	Title           string `json:"title"`
-	Author          string `json:"author"`
-	ISBN            string `json:"isbn"`
-	Publisher       string `json:"publisher"`
-	PublicationDate string `json:"publication_date"`
-	Genre           string `json:"genre"`
-	Language        string `json:"language"`
+	Title           string              `json:"title"`
+	Contributors    []ContributorParams `json:"contributors"`
+	ISBN            string              `json:"isbn"`
+	Publishers      []string            `json:"publishers"`
+	PublicationDate string              `json:"publication_date"`
+	Subjects        []string            `json:"subjects"`
+	Language        string              `json:"language"`
 }
 
 func (p *BaseParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if p.Title == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "title is required")
+		v.Add("title", externalerrors.Required, "title is required")
 	}
 
-	if p.Author == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "author is required")
+	if len(p.Contributors) == 0 {
+		v.Add("contributors", externalerrors.Required, "contributors is required")
+	}
+
+	// A contributor can only have a role once for a book
+	seenContributors := map[ContributorParams]bool{}
+	for i, contributor := range p.Contributors {
+		name := strings.TrimSpace(contributor.Name)
+		if name == "" {
+			v.Add(fmt.Sprintf("contributors[%d].name", i), externalerrors.Required, "contributor name is required")
+		}
+
+		v.Nest(fmt.Sprintf("contributors[%d].role", i), model.ValidateContributorRole(contributor.Role))
+
+		key := ContributorParams{Name: name, Role: contributor.Role}
+		if name != "" && seenContributors[key] {
+			v.Add(fmt.Sprintf("contributors[%d]", i), externalerrors.Duplicated,
+				fmt.Sprintf("%s is already a contributor as %s", name, contributor.Role))
+		}
+		seenContributors[key] = true
 	}
 
 	if p.ISBN == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "isbn is required")
+		v.Add("isbn", externalerrors.Required, "isbn is required")
 	}
 
-	if p.Publisher == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "publisher is required")
+	if len(p.Publishers) == 0 {
+		v.Add("publishers", externalerrors.Required, "publishers is required")
 	}
 
+	validateNames(&v, "publishers", "publisher", p.Publishers)
+
 	if p.PublicationDate == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "publication_date is required")
+		v.Add("publication_date", externalerrors.Required, "publication_date is required")
+	} else if _, err := time.Parse(time.RFC3339, p.PublicationDate); err != nil {
+		v.Add("publication_date", externalerrors.InvalidFormat, "publication_date does not match RFC3339 format")
 	}
 
-	if _, err := time.Parse(time.RFC3339, p.PublicationDate); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "publication_date does not match RFC3339 format")
+	if len(p.Subjects) == 0 {
+		v.Add("subjects", externalerrors.Required, "subjects is required")
 	}
 
-	if p.Genre == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "genre is required")
-	}
+	validateNames(&v, "subjects", "subject", p.Subjects)
 
 	if p.Language == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "language is required")
+		v.Add("language", externalerrors.Required, "language is required")
 	}
 
-	return nil
+	return v.Err()
+}
+
+// validateNames adds an error for each name of the list that is blank or repeated.
+func validateNames(v *externalerrors.Validation, field, entity string, names []string) {
+	seen := map[string]bool{}
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			v.Add(fmt.Sprintf("%s[%d]", field, i), externalerrors.Required, fmt.Sprintf("%s name is required", entity))
+		case seen[name]:
+			v.Add(fmt.Sprintf("%s[%d]", field, i), externalerrors.Duplicated, fmt.Sprintf("%s %s is repeated", entity, name))
+		}
+		seen[name] = true
+	}
 }
 
 func (p *BaseParams) ToModel() *model.Book {
@@ -58,11 +102,20 @@ func (p *BaseParams) ToModel() *model.Book {
 	publicationDate, _ := time.Parse(time.RFC3339, p.PublicationDate)
 	return &model.Book{
 		Title:           p.Title,
-		Author:          p.Author,
 		ISBN:            p.ISBN,
-		Publisher:       p.Publisher,
 		PublicationDate: publicationDate,
-		Genre:           p.Genre,
 		Language:        p.Language,
+		BookContributors: sliceutil.Map(p.Contributors, func(c ContributorParams) model.BookContributor {
+			return model.BookContributor{
+				Contributor: &model.Contributor{Name: strings.TrimSpace(c.Name)},
+				Role:        c.Role,
+			}
+		}),
+		Subjects: sliceutil.Map(p.Subjects, func(name string) model.Subject {
+			return model.Subject{Name: strings.TrimSpace(name)}
+		}),
+		Publishers: sliceutil.Map(p.Publishers, func(name string) model.Publisher {
+			return model.Publisher{Name: strings.TrimSpace(name)}
+		}),
 	}
 }
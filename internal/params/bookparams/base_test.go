@@ -0,0 +1,118 @@
+package bookparams
+
+import (
+	"errors"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"reflect"
+	"testing"
+)
+
+func validParams() BaseParams {
+	return BaseParams{
+		Title: "Hikayat Hang Tuah",
+		Contributors: []ContributorParams{
+			{Name: "Abdullah, Siti", Role: model.ContributorRoleAuthor},
+			{Name: "Tan Ah Kow", Role: model.ContributorRoleTranslator},
+		},
+		ISBN:            "9789676530615",
+		Publishers:      []string{"Dewan Bahasa dan Pustaka"},
+		PublicationDate: "2020-01-01T00:00:00Z",
+		Subjects:        []string{"Malay literature", "Epics"},
+		Language:        "ms",
+	}
+}
+
+func TestBaseParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *BaseParams)
+		want   []externalerrors.FieldError
+	}{
+		{
+			name:   "valid",
+			modify: func(p *BaseParams) {},
+		},
+		{
+			name: "same contributor in another role",
+			modify: func(p *BaseParams) {
+				p.Contributors = append(p.Contributors, ContributorParams{
+					Name: "Abdullah, Siti", Role: model.ContributorRoleEditor,
+				})
+			},
+		},
+		{
+			name: "repeated contributor",
+			modify: func(p *BaseParams) {
+				p.Contributors = append(p.Contributors, ContributorParams{
+					Name: " Abdullah, Siti ", Role: model.ContributorRoleAuthor,
+				})
+			},
+			want: []externalerrors.FieldError{
+				{Field: "contributors[2]", Code: externalerrors.Duplicated},
+			},
+		},
+		{
+			name: "repeated publisher",
+			modify: func(p *BaseParams) {
+				p.Publishers = append(p.Publishers, "Dewan Bahasa dan Pustaka ")
+			},
+			want: []externalerrors.FieldError{
+				{Field: "publishers[1]", Code: externalerrors.Duplicated},
+			},
+		},
+		{
+			name: "repeated subject",
+			modify: func(p *BaseParams) {
+				p.Subjects = append(p.Subjects, "Epics")
+			},
+			want: []externalerrors.FieldError{
+				{Field: "subjects[2]", Code: externalerrors.Duplicated},
+			},
+		},
+		{
+			name: "blank names are required and not repeated",
+			modify: func(p *BaseParams) {
+				p.Contributors = append(p.Contributors,
+					ContributorParams{Name: " ", Role: model.ContributorRoleAuthor},
+					ContributorParams{Name: "", Role: model.ContributorRoleAuthor},
+				)
+				p.Subjects = append(p.Subjects, "", " ")
+			},
+			want: []externalerrors.FieldError{
+				{Field: "contributors[2].name", Code: externalerrors.Required},
+				{Field: "contributors[3].name", Code: externalerrors.Required},
+				{Field: "subjects[2]", Code: externalerrors.Required},
+				{Field: "subjects[3]", Code: externalerrors.Required},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validParams()
+			tt.modify(&p)
+
+			err := p.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *externalerrors.Error
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a validation error", err)
+			}
+
+			got := []externalerrors.FieldError{}
+			for _, field := range validationErr.Fields {
+				got = append(got, externalerrors.FieldError{Field: field.Field, Code: field.Code})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
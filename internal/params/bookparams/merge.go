@@ -0,0 +1,35 @@
+package bookparams
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+// Books listed in source_ids are merged into the book in the url and then deleted.
+type MergeParams struct {
+	SourceIDs []int64 `json:"source_ids"`
+}
+
+func (p *MergeParams) Validate(bookID int64) error {
+	v := externalerrors.Validation{}
+
+	if len(p.SourceIDs) == 0 {
+		v.Add("source_ids", externalerrors.Required, "source_ids is required")
+	}
+
+	seen := map[int64]bool{}
+	for i, id := range p.SourceIDs {
+		field := fmt.Sprintf("source_ids[%d]", i)
+		switch {
+		case id <= 0:
+			v.Add(field, externalerrors.InvalidValue, "Source IDs must be positive.")
+		case id == bookID:
+			v.Add(field, externalerrors.InvalidValue, "a book cannot be merged into itself")
+		case seen[id]:
+			v.Add(field, externalerrors.InvalidValue, fmt.Sprintf("book %d is listed more than once", id))
+		}
+		seen[id] = true
+	}
+
+	return v.Err()
+}
@@ -11,15 +11,17 @@ type UpdateParams struct {
 }
 
 func (p *UpdateParams) Validate(bookID int64) error {
+	v := externalerrors.Validation{}
+
 	if p.ID == 0 {
-		return externalerrors.BadRequest("id is required")
+		v.Add("id", externalerrors.Required, "id is required")
+	} else if p.ID != uint(bookID) {
+		v.Add("id", externalerrors.MismatchedID, "book ID is inconsistent with url")
 	}
 
-	if p.ID != uint(bookID) {
-		return externalerrors.BadRequest("book ID is inconsistent with url")
-	}
+	v.Nest("", p.BaseParams.Validate())
 
-	return p.BaseParams.Validate()
+	return v.Err()
 }
 
 func (p *UpdateParams) ToModel() *model.Book {
@@ -0,0 +1,28 @@
+package branchparams
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+type BaseParams struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+func (p *BaseParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.Name == "" {
+		v.Add("name", externalerrors.Required, "name is required")
+	}
+
+	return v.Err()
+}
+
+func (p *BaseParams) ToModel() *model.Branch {
+	return &model.Branch{
+		Name:    p.Name,
+		Address: p.Address,
+	}
+}
@@ -0,0 +1,17 @@
+package branchparams
+
+import (
+	"lms-backend/internal/model"
+)
+
+type CreateParams struct {
+	BaseParams
+}
+
+func (p *CreateParams) Validate() error {
+	return p.BaseParams.Validate()
+}
+
+func (p *CreateParams) ToModel() *model.Branch {
+	return p.BaseParams.ToModel()
+}
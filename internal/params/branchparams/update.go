@@ -0,0 +1,31 @@
+package branchparams
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+type UpdateParams struct {
+	ID uint `json:"id"`
+	BaseParams
+}
+
+func (p *UpdateParams) Validate(branchID int64) error {
+	v := externalerrors.Validation{}
+
+	if p.ID == 0 {
+		v.Add("id", externalerrors.Required, "id is required")
+	} else if p.ID != uint(branchID) {
+		v.Add("id", externalerrors.MismatchedID, "branch ID is inconsistent with url")
+	}
+
+	v.Nest("", p.BaseParams.Validate())
+
+	return v.Err()
+}
+
+func (p *UpdateParams) ToModel() *model.Branch {
+	branch := p.BaseParams.ToModel()
+	branch.ID = p.ID
+	return branch
+}
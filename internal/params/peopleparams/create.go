@@ -2,8 +2,7 @@ package peopleparams
 
 import (
 	"lms-backend/internal/model"
-
-	"github.com/gofiber/fiber/v2"
+	"lms-backend/pkg/error/externalerrors"
 )
 
 type CreateParams struct {
@@ -19,9 +18,11 @@ func (b *CreateParams) ToModel() *model.Person {
 }
 
 func (b *CreateParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if b.FullName == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "full_name is required")
+		v.Add("full_name", externalerrors.Required, "full_name is required")
 	}
 
-	return nil
+	return v.Err()
 }
@@ -23,13 +23,13 @@ func (p *UpdateParams) ToModel() *model.Person {
 }
 
 func (p *UpdateParams) Validate() error {
-	if err := p.BaseParams.Validate(); err != nil {
-		return err
-	}
+	v := externalerrors.Validation{}
+
+	v.Nest("", p.BaseParams.Validate())
 
 	if p.ID == 0 {
-		return externalerrors.BadRequest("Person ID is required")
+		v.Add("id", externalerrors.Required, "Person ID is required")
 	}
 
-	return nil
+	return v.Err()
 }
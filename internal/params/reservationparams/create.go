@@ -0,0 +1,42 @@
+package reservationparams
+
+import (
+	"lms-backend/internal/params/sharedparams"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+// PickupBranchID is optional. The branch the copy is at is used when omitted.
+type CreateParams struct {
+	sharedparams.UserBookcopyParams
+	PickupBranchID int64 `json:"pickup_branch_id"`
+}
+
+func (p *CreateParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.PickupBranchID < 0 {
+		v.Add("pickup_branch_id", externalerrors.InvalidValue, "pickup_branch_id is invalid")
+	}
+
+	v.Nest("", p.UserBookcopyParams.Validate())
+
+	return v.Err()
+}
+
+// PickupBranchID is optional. The branch the copy is at is used when omitted.
+type CreateByBookParams struct {
+	sharedparams.UserBookParams
+	PickupBranchID int64 `json:"pickup_branch_id"`
+}
+
+func (p *CreateByBookParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.PickupBranchID < 0 {
+		v.Add("pickup_branch_id", externalerrors.InvalidValue, "pickup_branch_id is invalid")
+	}
+
+	v.Nest("", p.UserBookParams.Validate())
+
+	return v.Err()
+}
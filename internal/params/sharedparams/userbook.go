@@ -1,7 +1,7 @@
 package sharedparams
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"lms-backend/pkg/error/externalerrors"
 )
 
 type UserBookParams struct {
@@ -10,13 +10,15 @@ type UserBookParams struct {
 }
 
 func (params *UserBookParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if params.UserID <= 0 {
-		return fiber.NewError(fiber.StatusBadRequest, "user_id is required")
+		v.Add("user_id", externalerrors.Required, "user_id is required")
 	}
 
 	if params.BookID <= 0 {
-		return fiber.NewError(fiber.StatusBadRequest, "book_id is required")
+		v.Add("book_id", externalerrors.Required, "book_id is required")
 	}
 
-	return nil
+	return v.Err()
 }
@@ -1,22 +1,26 @@
 package sharedparams
 
 import (
-	"github.com/gofiber/fiber/v2"
+	"lms-backend/pkg/error/externalerrors"
 )
 
+// The book copy is identified by either its ID or its barcode.
 type UserBookcopyParams struct {
-	UserID     int64 `json:"user_id"`
-	BookCopyID int64 `json:"book_copy_id"`
+	UserID     int64  `json:"user_id"`
+	BookCopyID int64  `json:"book_copy_id"`
+	Barcode    string `json:"barcode"`
 }
 
 func (params *UserBookcopyParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if params.UserID <= 0 {
-		return fiber.NewError(fiber.StatusBadRequest, "user_id is required")
+		v.Add("user_id", externalerrors.Required, "user_id is required")
 	}
 
-	if params.BookCopyID <= 0 {
-		return fiber.NewError(fiber.StatusBadRequest, "book_copy_id is required")
+	if params.BookCopyID <= 0 && params.Barcode == "" {
+		v.Add("book_copy_id", externalerrors.Required, "book_copy_id or barcode is required")
 	}
 
-	return nil
+	return v.Err()
 }
@@ -0,0 +1,19 @@
+package transferparams
+
+import (
+	"lms-backend/pkg/error/externalerrors"
+)
+
+type CreateParams struct {
+	ToBranchID int64 `json:"to_branch_id"`
+}
+
+func (p *CreateParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.ToBranchID <= 0 {
+		v.Add("to_branch_id", externalerrors.Required, "to_branch_id is required")
+	}
+
+	return v.Err()
+}
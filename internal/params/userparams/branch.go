@@ -0,0 +1,23 @@
+package userparams
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+)
+
+// An empty list of branch IDs removes the user's branch restriction.
+type UpdateBranchParams struct {
+	BranchIDs []int64 `json:"branch_ids"`
+}
+
+func (p *UpdateBranchParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	for i, id := range p.BranchIDs {
+		if id <= 0 {
+			v.Add(fmt.Sprintf("branch_ids[%d]", i), externalerrors.InvalidValue, "Branch IDs must be positive.")
+		}
+	}
+
+	return v.Err()
+}
@@ -3,23 +3,28 @@ package userparams
 import (
 	"lms-backend/internal/model"
 	"lms-backend/internal/params/peopleparams"
+	"lms-backend/pkg/error/externalerrors"
 )
 
 type CreateParams struct {
 	BaseUserParams
+	PreferenceParams
 	PersonParams peopleparams.CreateParams `json:"person_attributes"`
 }
 
 func (p *CreateParams) ToModel() *model.User {
 	usr := p.BaseUserParams.ToModel()
+	p.PreferenceParams.ApplyTo(usr)
 	usr.Person = p.PersonParams.ToModel()
 	return usr
 }
 
 func (p *CreateParams) Validate() error {
-	if err := p.BaseUserParams.Validate(); err != nil {
-		return err
-	}
+	v := externalerrors.Validation{}
 
-	return p.PersonParams.Validate()
+	v.Nest("", p.BaseUserParams.Validate())
+	v.Nest("", p.PreferenceParams.Validate())
+	v.Nest("person_attributes", p.PersonParams.Validate())
+
+	return v.Err()
 }
@@ -0,0 +1,40 @@
+package userparams
+
+import (
+	"fmt"
+	"lms-backend/internal/i18n"
+	"lms-backend/internal/model"
+	"lms-backend/pkg/error/externalerrors"
+	"strings"
+	"time"
+)
+
+// PreferenceParams are the language and time zone of the messages to the user.
+// Either is left as is when empty.
+type PreferenceParams struct {
+	Locale   string `json:"locale"`
+	TimeZone string `json:"time_zone"`
+}
+
+func (p *PreferenceParams) ApplyTo(usr *model.User) {
+	usr.Locale = p.Locale
+	usr.TimeZone = p.TimeZone
+}
+
+func (p *PreferenceParams) Validate() error {
+	v := externalerrors.Validation{}
+
+	if p.Locale != "" && !i18n.IsSupported(p.Locale) {
+		v.Add("locale", externalerrors.InvalidValue, fmt.Sprintf(
+			"%s is not a supported locale, expected one of %s", p.Locale, strings.Join(i18n.Languages, ", "),
+		))
+	}
+
+	if p.TimeZone != "" {
+		if _, err := time.LoadLocation(p.TimeZone); err != nil {
+			v.Add("time_zone", externalerrors.InvalidValue, fmt.Sprintf("%s is not a valid time zone", p.TimeZone))
+		}
+	}
+
+	return v.Err()
+}
@@ -9,9 +9,11 @@ type UpdateRoleParams struct {
 }
 
 func (p *UpdateRoleParams) Validate() error {
+	v := externalerrors.Validation{}
+
 	if p.RoleID == 0 {
-		return externalerrors.BadRequest("Role ID is required.")
+		v.Add("role_id", externalerrors.Required, "Role ID is required.")
 	}
 
-	return nil
+	return v.Err()
 }
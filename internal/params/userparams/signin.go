@@ -14,17 +14,17 @@ func (p *SignInParams) ToModel() *model.User {
 }
 
 func (p *SignInParams) Validate() error {
-	if err := p.BaseUserParams.Validate(); err != nil {
-		return err
-	}
+	v := externalerrors.Validation{}
+
+	v.Nest("", p.BaseUserParams.Validate())
 
 	if p.Password == "" {
-		return externalerrors.BadRequest("Password is required")
+		v.Add("password", externalerrors.Required, "Password is required")
 	}
 
 	// if p.Email == "" && p.Username == "" {
 	// 	return externalerrors.BadRequest("Email or Username is required")
 	// }
 
-	return nil
+	return v.Err()
 }
@@ -8,12 +8,14 @@ import (
 
 type UpdateParams struct {
 	BaseUserParams
+	PreferenceParams
 	ID           uint                      `json:"id"`
 	PersonParams peopleparams.UpdateParams `json:"person_attributes"`
 }
 
 func (p *UpdateParams) ToModel() *model.User {
 	usr := p.BaseUserParams.ToModel()
+	p.PreferenceParams.ApplyTo(usr)
 	usr.ID = p.ID
 	usr.Person = p.PersonParams.ToModel()
 	usr.PersonID = p.PersonParams.ID
@@ -21,17 +23,17 @@ func (p *UpdateParams) ToModel() *model.User {
 }
 
 func (p *UpdateParams) Validate(userID int64) error {
-	if p.ID == 0 {
-		return externalerrors.BadRequest("User ID is required")
-	}
+	v := externalerrors.Validation{}
 
-	if p.ID != uint(userID) {
-		return externalerrors.BadRequest("User ID does not match with the URL")
+	if p.ID == 0 {
+		v.Add("id", externalerrors.Required, "User ID is required")
+	} else if p.ID != uint(userID) {
+		v.Add("id", externalerrors.MismatchedID, "User ID does not match with the URL")
 	}
 
-	if err := p.BaseUserParams.Validate(); err != nil {
-		return err
-	}
+	v.Nest("", p.BaseUserParams.Validate())
+	v.Nest("", p.PreferenceParams.Validate())
+	v.Nest("person_attributes", p.PersonParams.Validate())
 
-	return p.PersonParams.Validate()
+	return v.Err()
 }
@@ -25,4 +25,16 @@ var (
 		Name:        "canManageBookRecords",
 		Description: "can manage book records",
 	}
+	CanMergeBook model.Ability = model.Ability{
+		Name:        "canMergeBook",
+		Description: "can review and merge duplicate books",
+	}
+	CanImportBook model.Ability = model.Ability{
+		Name:        "canImportBook",
+		Description: "can import books and copies from MARC records",
+	}
+	CanExportBook model.Ability = model.Ability{
+		Name:        "canExportBook",
+		Description: "can export the catalogue as MARC records",
+	}
 )
@@ -0,0 +1,20 @@
+package abilities
+
+import (
+	"lms-backend/internal/model"
+)
+
+var (
+	CanManageBranch model.Ability = model.Ability{
+		Name:        "canManageBranch",
+		Description: "can manage branch",
+	}
+	CanTransferBookCopy model.Ability = model.Ability{
+		Name:        "canTransferBookCopy",
+		Description: "can transfer book copy between branches",
+	}
+	CanUpdateUserBranch model.Ability = model.Ability{
+		Name:        "canUpdateUserBranch",
+		Description: "can update user branch",
+	}
+)
@@ -26,6 +26,13 @@ func GetAllAbilities() []model.Ability {
 		CanDeleteBook,
 
 		CanManageBookRecords,
+		CanMergeBook,
+		CanImportBook,
+		CanExportBook,
+
+		CanManageBranch,
+		CanTransferBookCopy,
+		CanUpdateUserBranch,
 
 		CanLoanBook,
 		CanReturnBook,
@@ -3,6 +3,7 @@ package bookpolicy
 import (
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/abilities"
+	"lms-backend/internal/policy/branchpolicy"
 	"lms-backend/internal/policy/commonpolicy"
 )
 
@@ -24,6 +25,17 @@ func CreatePolicy() policy.Policy {
 	)
 }
 
+// Create copies at a branch
+func CreateCopyPolicy(branchID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(abilities.CanCreateBook.Name),
+			branchpolicy.AllowIfBranchInScope(branchID),
+		),
+	)
+}
+
 func UpdatePolicy() policy.Policy {
 	return commonpolicy.Any(
 		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanUpdateBook.Name),
@@ -35,3 +47,51 @@ func DeletePolicy() policy.Policy {
 		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanDeleteBook.Name),
 	)
 }
+
+// Update copies belonging to a branch
+func UpdateCopyPolicy(branchID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(abilities.CanUpdateBook.Name),
+			branchpolicy.AllowIfBranchInScope(branchID),
+		),
+	)
+}
+
+// Delete copies belonging to a branch
+func DeleteCopyPolicy(branchID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(abilities.CanDeleteBook.Name),
+			branchpolicy.AllowIfBranchInScope(branchID),
+		),
+	)
+}
+
+// Review and merge duplicate books
+func MergePolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanMergeBook.Name),
+	)
+}
+
+func ImportPolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanImportBook.Name),
+	)
+}
+
+func ExportPolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanExportBook.Name),
+	)
+}
+
+// Include the current user's bookmarks of books
+func IncludeBookmarksPolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanCreateBookMark.Name),
+	)
+}
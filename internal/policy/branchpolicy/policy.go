@@ -0,0 +1,31 @@
+package branchpolicy
+
+import (
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/abilities"
+	"lms-backend/internal/policy/commonpolicy"
+)
+
+func ListPolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.AllowAll(),
+	)
+}
+
+func CreatePolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanManageBranch.Name),
+	)
+}
+
+func UpdatePolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanManageBranch.Name),
+	)
+}
+
+func DeletePolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name, abilities.CanManageBranch.Name),
+	)
+}
@@ -0,0 +1,54 @@
+package branchpolicy
+
+import (
+	"fmt"
+	"lms-backend/internal/dataaccess/branch"
+	"lms-backend/internal/database"
+	"lms-backend/internal/policy"
+	"lms-backend/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BranchInScope struct {
+	BranchID int64
+}
+
+// AllowIfBranchInScope allows users who are scoped to the branch.
+//
+// Users who are not scoped to any branch are allowed at every branch.
+func AllowIfBranchInScope(branchID int64) *BranchInScope {
+	return &BranchInScope{
+		BranchID: branchID,
+	}
+}
+
+func (p *BranchInScope) Validate(c *fiber.Ctx) (policy.Decision, error) {
+	userID, err := session.GetLoginSession(c)
+	if err != nil {
+		return policy.Deny, err
+	}
+
+	db := database.GetDB()
+
+	branches, err := branch.ListByUserID(db, userID)
+	if err != nil {
+		return policy.Deny, err
+	}
+
+	if len(branches) == 0 {
+		return policy.Allow, nil
+	}
+
+	for _, b := range branches {
+		if int64(b.ID) == p.BranchID {
+			return policy.Allow, nil
+		}
+	}
+
+	return policy.Deny, nil
+}
+
+func (p *BranchInScope) Reason() string {
+	return fmt.Sprintf("You are not assigned to the branch with ID %d.", p.BranchID)
+}
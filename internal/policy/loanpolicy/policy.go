@@ -3,6 +3,7 @@ package loanpolicy
 import (
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/abilities"
+	"lms-backend/internal/policy/branchpolicy"
 	"lms-backend/internal/policy/commonpolicy"
 )
 
@@ -42,23 +43,30 @@ func LoanPolicy() policy.Policy {
 	return commonpolicy.AllowAll()
 }
 
-// loan for others
-func CreatePolicy() policy.Policy {
+// loan for others a copy at a branch
+func CreatePolicy(branchID int64) policy.Policy {
 	return commonpolicy.Any(
-		commonpolicy.HasAnyAbility(
-			abilities.CanManageAll.Name,
-			abilities.CanManageBookRecords.Name,
-			abilities.CanLoanBook.Name,
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanLoanBook.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(branchID),
 		),
 	)
 }
 
-func ReturnPolicy() policy.Policy {
+// check in a copy at a branch
+func ReturnPolicy(branchID int64) policy.Policy {
 	return commonpolicy.Any(
-		commonpolicy.HasAnyAbility(
-			abilities.CanManageAll.Name,
-			abilities.CanManageBookRecords.Name,
-			abilities.CanReturnBook.Name,
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanReturnBook.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(branchID),
 		),
 	)
 }
@@ -3,6 +3,7 @@ package reservationpolicy
 import (
 	"lms-backend/internal/policy"
 	"lms-backend/internal/policy/abilities"
+	"lms-backend/internal/policy/branchpolicy"
 	"lms-backend/internal/policy/commonpolicy"
 )
 
@@ -32,23 +33,30 @@ func ReservePolicy() policy.Policy {
 	return commonpolicy.AllowAll()
 }
 
-// Reserve for others
-func CreatePolicy() policy.Policy {
+// Reserve for others a copy at a branch
+func CreatePolicy(branchID int64) policy.Policy {
 	return commonpolicy.Any(
-		commonpolicy.HasAnyAbility(
-			abilities.CanManageAll.Name,
-			abilities.CanManageBookRecords.Name,
-			abilities.CanCreateReservation.Name,
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanCreateReservation.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(branchID),
 		),
 	)
 }
 
-func CancelPolicy(resID int64) policy.Policy {
+// Cancel a reservation of a copy at a branch
+func CancelPolicy(resID, branchID int64) policy.Policy {
 	return commonpolicy.Any(
-		commonpolicy.HasAnyAbility(
-			abilities.CanManageAll.Name,
-			abilities.CanManageBookRecords.Name,
-			abilities.CanCancelReservation.Name,
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanCancelReservation.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(branchID),
 		),
 		AllowIfReservationBelongsToUser(resID),
 	)
@@ -0,0 +1,46 @@
+package transferpolicy
+
+import (
+	"lms-backend/internal/policy"
+	"lms-backend/internal/policy/abilities"
+	"lms-backend/internal/policy/branchpolicy"
+	"lms-backend/internal/policy/commonpolicy"
+)
+
+func ReadPolicy() policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(
+			abilities.CanManageAll.Name,
+			abilities.CanManageBookRecords.Name,
+			abilities.CanTransferBookCopy.Name,
+		),
+	)
+}
+
+// Send a copy away from the branch it is currently at
+func SendPolicy(fromBranchID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanTransferBookCopy.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(fromBranchID),
+		),
+	)
+}
+
+// Receive a copy at the destination branch
+func ReceivePolicy(toBranchID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(
+				abilities.CanManageBookRecords.Name,
+				abilities.CanTransferBookCopy.Name,
+			),
+			branchpolicy.AllowIfBranchInScope(toBranchID),
+		),
+	)
+}
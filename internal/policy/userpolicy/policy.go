@@ -51,3 +51,13 @@ func UpdateRolePolicy(userID, roleID int64) policy.Policy {
 		),
 	)
 }
+
+func UpdateBranchPolicy(userID int64) policy.Policy {
+	return commonpolicy.Any(
+		commonpolicy.HasAnyAbility(abilities.CanManageAll.Name),
+		commonpolicy.All(
+			commonpolicy.HasAnyAbility(abilities.CanUpdateUserBranch.Name),
+			AllowIfSubjectBelowOwnRank(userID),
+		),
+	)
+}
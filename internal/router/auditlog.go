@@ -8,5 +8,6 @@ import (
 
 func AuditLogRoutes(r fiber.Router) {
 	r.Get("/", auditloghandler.HandleList)
+	r.Get("/verify", auditloghandler.HandleVerify)
 	r.Post("/", auditloghandler.HandleCreate)
 }
@@ -1,11 +1,13 @@
 package router
 
 import (
+	"lms-backend/internal/config"
 	"lms-backend/internal/handler/auth"
+	"lms-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func AuthRoutes(r fiber.Router) {
-	r.Post("/signin", auth.HandleSignIn)
+	r.Post("/signin", middleware.RateLimit(config.AuthRateLimitClass), auth.HandleSignIn)
 }
@@ -11,10 +11,18 @@ import (
 
 func BookRoutes(r fiber.Router) {
 	r.Post("/", bookhandler.HandleCreate)
+	r.Get("/duplicates", bookhandler.HandleListDuplicates)
+	r.Get("/import", bookhandler.HandleListImports)
+	r.Post("/import", bookhandler.HandleImport)
+	r.Post("/import/columns", bookhandler.HandleReadImportColumns)
+	r.Get("/import/:import_id", bookhandler.HandleReadImport)
+	r.Post("/import/marc", bookhandler.HandleImportMARC)
+	r.Get("/export/marc", bookhandler.HandleExportMARC)
 
 	Route(r, "/:book_id", func(r fiber.Router) {
 		r.Patch("/", bookhandler.HandleUpdate)
 		r.Patch("/thumbnail", bookhandler.HandleUpdateThumbnail)
+		r.Post("/merge", bookhandler.HandleMerge)
 		r.Delete("/", bookhandler.HandleDelete)
 
 		Route(r, "/bookmark", BookBookmarkRoutes)
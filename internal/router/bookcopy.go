@@ -4,14 +4,22 @@ import (
 	bookcopyhandler "lms-backend/internal/handler/bookcopy"
 	loanhandler "lms-backend/internal/handler/loan"
 	reservationhandler "lms-backend/internal/handler/reservation"
+	transferhandler "lms-backend/internal/handler/transfer"
+	"lms-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func BookcopyRoutes(r fiber.Router) {
+	// Same endpoints as /:bookcopy_id, with the copy identified by its barcode
+	r.Use("/barcode/:barcode", bookcopyhandler.HandleResolveBarcode)
+
 	Route(r, "/:bookcopy_id", func(r fiber.Router) {
+		r.Get("/", bookcopyhandler.HandleRead)
+		r.Patch("/", bookcopyhandler.HandleUpdate)
 		r.Delete("/", bookcopyhandler.HandleDelete)
 		r.Get("/qrcode", bookcopyhandler.HandleGenerateQRCode)
+		r.Post("/transfer", transferhandler.HandleCreate)
 
 		Route(r, "/loan", BookLoanRoutes)
 		Route(r, "/reservation", BookReservationRoutes)
@@ -19,11 +27,11 @@ func BookcopyRoutes(r fiber.Router) {
 }
 
 func BookLoanRoutes(r fiber.Router) {
-	r.Post("/", loanhandler.HandleLoan)
-	r.Patch("/return", loanhandler.HandleReturnByBookcopy)
+	r.Post("/", middleware.Idempotency, loanhandler.HandleLoan)
+	r.Patch("/return", middleware.Idempotency, loanhandler.HandleReturnByBookcopy)
 }
 
 func BookReservationRoutes(r fiber.Router) {
-	r.Post("/", reservationhandler.HandleReserve)
-	r.Patch("/cancel", reservationhandler.HandleCancelByBookcopy)
+	r.Post("/", middleware.Idempotency, reservationhandler.HandleReserve)
+	r.Patch("/cancel", middleware.Idempotency, reservationhandler.HandleCancelByBookcopy)
 }
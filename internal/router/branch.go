@@ -0,0 +1,16 @@
+package router
+
+import (
+	branchhandler "lms-backend/internal/handler/branch"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func BranchRoutes(r fiber.Router) {
+	r.Post("/", branchhandler.HandleCreate)
+
+	Route(r, "/:branch_id", func(r fiber.Router) {
+		r.Patch("/", branchhandler.HandleUpdate)
+		r.Delete("/", branchhandler.HandleDelete)
+	})
+}
@@ -0,0 +1,12 @@
+package router
+
+import (
+	docshandler "lms-backend/internal/handler/docs"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func DocsRoutes(r fiber.Router) {
+	r.Get("/openapi.json", docshandler.HandleOpenAPI)
+	r.Get("/docs", docshandler.HandleDocs)
+}
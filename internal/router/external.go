@@ -10,4 +10,5 @@ import (
 func ExternalRoutes(r fiber.Router) {
 	// We cache the response for 30 days since the data is not likely to change and we don't want to spam google api
 	r.Get("/", middleware.CacheMiddleware(middleware.VVVLongExp), googlebook.HandleQuery)
+	r.Post("/volume/:volume_id/import", googlebook.HandleImport)
 }
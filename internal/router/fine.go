@@ -2,6 +2,7 @@ package router
 
 import (
 	finehandler "lms-backend/internal/handler/fine"
+	"lms-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -10,7 +11,7 @@ func FineRoutes(r fiber.Router) {
 	r.Get("/", finehandler.HandleList)
 
 	Route(r, "/:fine_id", func(r fiber.Router) {
-		r.Patch("/settle", finehandler.HandleSettle)
+		r.Patch("/settle", middleware.Idempotency, finehandler.HandleSettle)
 		r.Delete("/", finehandler.HandleDelete)
 	})
 }
@@ -2,18 +2,19 @@ package router
 
 import (
 	loanhandler "lms-backend/internal/handler/loan"
+	"lms-backend/internal/middleware"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 func LoanRoutes(r fiber.Router) {
 	r.Get("/", loanhandler.HandleList)
-	r.Post("/", loanhandler.HandleCreate)
-	r.Post("/book", loanhandler.HandleCreateByBook)
+	r.Post("/", middleware.Idempotency, loanhandler.HandleCreate)
+	r.Post("/book", middleware.Idempotency, loanhandler.HandleCreateByBook)
 
 	Route(r, "/:loan_id", func(r fiber.Router) {
 		r.Get("/", loanhandler.HandleRead)
-		r.Patch("/return", loanhandler.HandleReturn)
-		r.Patch("/renew", loanhandler.HandleRenew)
+		r.Patch("/return", middleware.Idempotency, loanhandler.HandleReturn)
+		r.Patch("/renew", middleware.Idempotency, loanhandler.HandleRenew)
 	})
 }
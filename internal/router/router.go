@@ -1,13 +1,21 @@
 package router
 
 import (
+	"lms-backend/internal/apidocs"
 	"lms-backend/internal/config"
 	"lms-backend/internal/handler/auth"
 	bookhandler "lms-backend/internal/handler/book"
+	branchhandler "lms-backend/internal/handler/branch"
+	contributorhandler "lms-backend/internal/handler/contributor"
+	publisherhandler "lms-backend/internal/handler/publisher"
+	subjecthandler "lms-backend/internal/handler/subject"
 	userhandler "lms-backend/internal/handler/user"
+	"lms-backend/internal/i18n"
 	"lms-backend/internal/middleware"
 	sessionmiddleware "lms-backend/internal/middleware/session"
 	"lms-backend/internal/session"
+	"log"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -17,11 +25,26 @@ func SetUpRoutes(app *fiber.App, cfg *config.Config) {
 	middleware.SetupCors(app, cfg)
 	middleware.SetupCSRF(app)
 	middleware.SetupRecover(app)
+	middleware.SetupRequestID(app)
 	middleware.SetupLogger(app)
+	middleware.SetupLocale(app)
 	middleware.SetupWebApp(app)
 	middleware.SetupStaticFile(app)
 
-	v1Routes := app.Group("/api/v1")
+	SetUpAPIRoutes(app)
+
+	if undocumented := apidocs.Undocumented(app.GetRoutes(true)); len(undocumented) > 0 {
+		log.Printf("routes missing from the OpenAPI document: %s\n", strings.Join(undocumented, ", "))
+	}
+
+	if problems := i18n.Check(); len(problems) > 0 {
+		log.Printf("messages missing from the catalogues: %s\n", strings.Join(problems, ", "))
+	}
+}
+
+// SetUpAPIRoutes sets up the routes of the API without the middleware of the app.
+func SetUpAPIRoutes(app *fiber.App) {
+	v1Routes := app.Group(apidocs.BasePath, middleware.RateLimit(config.APIRateLimitClass))
 
 	publicRoutes := v1Routes.Group("/")
 	Route(publicRoutes, "/", PublicRoutes)
@@ -32,15 +55,21 @@ func SetUpRoutes(app *fiber.App, cfg *config.Config) {
 
 func PublicRoutes(r fiber.Router) {
 	Route(r, "/health", HealthRoutes)
+	DocsRoutes(r)
 	Route(r, "/auth", AuthRoutes)
 	r.Get("/current", userhandler.HandleGetCurrentUser)
-	r.Post("/user", userhandler.HandleCreate)
+	r.Post("/user", middleware.RateLimit(config.AuthRateLimitClass), userhandler.HandleCreate)
 
 	Route(r, "book", func(r fiber.Router) {
 		r.Get("/", bookhandler.HandleList)
 		r.Get("/popular", middleware.CacheMiddleware(middleware.VLongExp), bookhandler.HandlePopular)
-		r.Get("/:book_id", bookhandler.HandleRead)
+		r.Get("/:book_id<int>", bookhandler.HandleRead) // leaves /book/duplicates to the private routes
 	})
+
+	r.Get("/branch", middleware.CacheMiddleware(middleware.ShortExp), branchhandler.HandleList)
+	r.Get("/contributor", contributorhandler.HandleList)
+	r.Get("/subject", subjecthandler.HandleList)
+	r.Get("/publisher", publisherhandler.HandleList)
 }
 
 func PrivateRoutes(r fiber.Router) {
@@ -48,11 +77,13 @@ func PrivateRoutes(r fiber.Router) {
 	Route(r, "/user", UserRoutes)
 	Route(r, "/book", BookRoutes)
 	Route(r, "/bookcopy", BookcopyRoutes)
+	Route(r, "/branch", BranchRoutes)
+	Route(r, "/transfer", TransferRoutes)
 	Route(r, "/bookmark", BookmarkRoutes)
 	Route(r, "/loan", LoanRoutes)
 	Route(r, "/reservation", ReservationRoutes)
 	Route(r, "/fine", FineRoutes)
 	Route(r, "/audit_log", AuditLogRoutes)
-	Route(r, "/external", ExternalRoutes)
+	Route(r, "/external", ExternalRoutes, middleware.RateLimit(config.ExternalRateLimitClass))
 	Route(r, "/file", PrivateFileRoutes)
 }
@@ -0,0 +1,15 @@
+package router
+
+import (
+	transferhandler "lms-backend/internal/handler/transfer"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TransferRoutes(r fiber.Router) {
+	r.Get("/", transferhandler.HandleList)
+
+	Route(r, "/:transfer_id", func(r fiber.Router) {
+		r.Patch("/receive", transferhandler.HandleReceive)
+	})
+}
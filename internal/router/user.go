@@ -16,6 +16,7 @@ func UserRoutes(r fiber.Router) {
 		r.Delete("/", userhandler.HandleDelete)
 
 		r.Patch("/role", userhandler.HandleChangeRole)
+		r.Patch("/branch", userhandler.HandleChangeBranch)
 	})
 
 	Route(r, "/autocomplete", func(r fiber.Router) {
@@ -2,6 +2,7 @@ package session
 
 import (
 	"lms-backend/internal/api"
+	"lms-backend/internal/i18n"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -35,9 +36,9 @@ func GetLoginSession(c *fiber.Ctx) (int64, error) {
 	if token == nil {
 		//nolint
 		c.Status(fiber.StatusUnauthorized).JSON(api.Response{
-			Messages: []api.Message{api.InfoMessage("User is not logged in")},
+			Messages: []api.Message{api.InfoMessage(i18n.T(c, i18n.NotLoggedIn))},
 		})
-		return 0, fiber.NewError(fiber.StatusUnauthorized, "User is not logged in")
+		return 0, fiber.NewError(fiber.StatusUnauthorized, i18n.T(c, i18n.NotLoggedIn))
 	}
 
 	userID, ok := token.(uint)
@@ -48,9 +49,9 @@ func GetLoginSession(c *fiber.Ctx) (int64, error) {
 	if userID == 0 {
 		//nolint
 		c.Status(fiber.StatusUnauthorized).JSON(api.Response{
-			Messages: []api.Message{api.InfoMessage("User is not logged in")},
+			Messages: []api.Message{api.InfoMessage(i18n.T(c, i18n.NotLoggedIn))},
 		})
-		return 0, fiber.NewError(fiber.StatusUnauthorized, "User is not logged in")
+		return 0, fiber.NewError(fiber.StatusUnauthorized, i18n.T(c, i18n.NotLoggedIn))
 	}
 
 	return int64(userID), nil
@@ -4,6 +4,7 @@ import (
 	"lms-backend/internal/database"
 	"time"
 
+	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
@@ -12,9 +13,11 @@ var (
 )
 
 const (
-	CookieKey = "token"
-	UserIDKey = "UserID"
-	MaxAge    = time.Hour * 24 * 7 // 7 days
+	CookieKey   = "token"
+	UserIDKey   = "UserID"
+	LocaleKey   = "locale"
+	TimeZoneKey = "time_zone"
+	MaxAge      = time.Hour * 24 * 7 // 7 days
 )
 
 func SetupStore() {
@@ -27,3 +30,16 @@ func SetupStore() {
 		Storage:        database.GetRedisStore(),
 	})
 }
+
+// SavePreference keeps the language and time zone of the signed in user in the session,
+// so that requests are localized without reading the user.
+func SavePreference(c *fiber.Ctx, locale, timeZone string) error {
+	sess, err := Store.Get(c)
+	if err != nil {
+		return err
+	}
+
+	sess.Set(LocaleKey, locale)
+	sess.Set(TimeZoneKey, timeZone)
+	return sess.Save()
+}
@@ -0,0 +1,39 @@
+package auditlogview
+
+import (
+	"encoding/json"
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/userview"
+)
+
+var ExportColumns = []export.Column[model.AuditLog]{
+	{Header: "ID", Value: func(a *model.AuditLog) string { return export.ID(a.ID) }},
+	{Header: "Date", Value: func(a *model.AuditLog) string { return export.Time(a.Date) }},
+	{Header: "Action", Value: func(a *model.AuditLog) string { return a.Action }},
+	{Header: "Action Type", Value: func(a *model.AuditLog) string { return a.ActionType }},
+	{Header: "Entity Type", Value: func(a *model.AuditLog) string { return a.EntityType }},
+	{Header: "Entity ID", Value: func(a *model.AuditLog) string {
+		if a.EntityID == 0 {
+			return ""
+		}
+		return export.ID(a.EntityID)
+	}},
+	{Header: "Changes", Value: func(a *model.AuditLog) string {
+		if len(a.Changes) == 0 {
+			return ""
+		}
+		//nolint:errcheck // changes were decoded from json
+		changes, _ := json.Marshal(a.Changes)
+		return string(changes)
+	}},
+	{Header: "Username", Value: func(a *model.AuditLog) string {
+		if a.User == nil {
+			return ""
+		}
+		return a.User.Username
+	}},
+	{Header: "Full Name", Value: func(a *model.AuditLog) string { return userview.FullName(a.User) }},
+	{Header: "Request ID", Value: func(a *model.AuditLog) string { return a.RequestID }},
+	{Header: "IP Address", Value: func(a *model.AuditLog) string { return a.IPAddress }},
+}
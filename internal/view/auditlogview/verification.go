@@ -0,0 +1,27 @@
+package auditlogview
+
+import (
+	"lms-backend/internal/viewmodel"
+)
+
+type VerificationView struct {
+	Valid     bool   `json:"valid"`
+	Checked   int64  `json:"checked"`
+	Unchained int64  `json:"unchained"`
+	LastID    uint   `json:"last_id"`
+	LastHash  string `json:"last_hash"`
+	BrokenID  uint   `json:"broken_id,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+func ToVerificationView(v *viewmodel.AuditLogVerificationViewModel) *VerificationView {
+	return &VerificationView{
+		Valid:     v.Valid(),
+		Checked:   v.Checked,
+		Unchained: v.Unchained,
+		LastID:    v.LastID,
+		LastHash:  v.LastHash,
+		BrokenID:  v.BrokenID,
+		Reason:    v.Reason,
+	}
+}
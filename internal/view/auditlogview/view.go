@@ -6,17 +6,49 @@ import (
 )
 
 type View struct {
-	ID     uint   `json:"id,omitempty"`
-	Action string `json:"action"`
-	UserID uint   `json:"user_id"`
-	Date   string `json:"date"`
+	ID         uint                  `json:"id,omitempty"`
+	Action     string                `json:"action"`
+	ActionType string                `json:"action_type"`
+	EntityType string                `json:"entity_type"`
+	EntityID   uint                  `json:"entity_id"`
+	UserID     uint                  `json:"user_id"`
+	RequestID  string                `json:"request_id"`
+	IPAddress  string                `json:"ip_address"`
+	Changes    map[string]ChangeView `json:"changes"`
+	Date       string                `json:"date"`
+	// Empty for entries from before the hash chain
+	PreviousHash string `json:"previous_hash"`
+	Hash         string `json:"hash"`
+}
+
+// ChangeView is the value of a column before and after the action.
+type ChangeView struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
 }
 
 func ToView(auditLog *model.AuditLog) *View {
+	changes := map[string]ChangeView{}
+	for column, change := range auditLog.Changes {
+		changes[column] = ChangeView{
+			Old: change.Old,
+			New: change.New,
+		}
+	}
+
 	return &View{
-		ID:     auditLog.ID,
-		Action: auditLog.Action,
-		UserID: auditLog.UserID,
-		Date:   auditLog.Date.Format(time.RFC3339),
+		ID:         auditLog.ID,
+		Action:     auditLog.Action,
+		ActionType: auditLog.ActionType,
+		EntityType: auditLog.EntityType,
+		EntityID:   auditLog.EntityID,
+		UserID:     auditLog.UserID,
+		RequestID:  auditLog.RequestID,
+		IPAddress:  auditLog.IPAddress,
+		Changes:    changes,
+		Date:       auditLog.Date.Format(time.RFC3339),
+
+		PreviousHash: auditLog.PreviousHash,
+		Hash:         auditLog.Hash,
 	}
 }
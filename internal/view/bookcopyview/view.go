@@ -3,16 +3,28 @@ package bookcopyview
 import (
 	"lms-backend/internal/model"
 	"lms-backend/internal/view/sharedview"
+	"lms-backend/pkg/money"
+	"time"
+
+	"github.com/ForAeons/ternary"
 )
 
 type DetailedView struct {
 	sharedview.BookCopyView
-	Book *sharedview.BookView `json:"book"`
+	AcquisitionDate   *time.Time           `json:"acquisition_date"`
+	AcquisitionSource string               `json:"acquisition_source"`
+	AcquisitionPrice  money.Amount         `json:"acquisition_price"`
+	Book              *sharedview.BookView `json:"book"`
 }
 
 func ToDetailedView(bookCopy *model.BookCopy) *DetailedView {
 	return &DetailedView{
 		BookCopyView: *sharedview.ToBookCopyView(bookCopy),
-		Book:         sharedview.ToBookView(bookCopy.Book),
+		AcquisitionDate: ternary.If[*time.Time](bookCopy.AcquisitionDate.Valid).
+			Then(&bookCopy.AcquisitionDate.Time).
+			Else(nil),
+		AcquisitionSource: bookCopy.AcquisitionSource,
+		AcquisitionPrice:  bookCopy.AcquisitionPrice,
+		Book:              sharedview.ToBookView(bookCopy.Book),
 	}
 }
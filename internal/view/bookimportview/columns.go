@@ -0,0 +1,28 @@
+package bookimportview
+
+import (
+	"lms-backend/internal/bookimporter"
+)
+
+// ColumnsView helps the client map the columns of a file before importing it.
+type ColumnsView struct {
+	Headers    []string             `json:"headers"`
+	Fields     []bookimporter.Field `json:"fields"`
+	Mapping    bookimporter.Mapping `json:"mapping"`
+	SampleRows [][]string           `json:"sample_rows"`
+}
+
+func ToColumnsView(rows [][]string, sampleSize int) *ColumnsView {
+	headers := rows[0]
+	samples := rows[1:]
+	if len(samples) > sampleSize {
+		samples = samples[:sampleSize]
+	}
+
+	return &ColumnsView{
+		Headers:    headers,
+		Fields:     bookimporter.Fields,
+		Mapping:    bookimporter.SuggestMapping(headers),
+		SampleRows: samples,
+	}
+}
@@ -0,0 +1,39 @@
+package bookimportview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type ErrorView struct {
+	Row        int    `json:"row"`
+	ColumnName string `json:"column_name,omitempty"`
+	Message    string `json:"message"`
+}
+
+type DetailedView struct {
+	View
+	User   *sharedview.UserView `json:"user,omitempty"`
+	Errors []ErrorView          `json:"errors"`
+}
+
+func ToDetailedView(bookImport *model.BookImport) *DetailedView {
+	view := &DetailedView{
+		View:   *ToView(bookImport),
+		Errors: []ErrorView{},
+	}
+
+	if bookImport.User != nil {
+		view.User = sharedview.ToUserView(bookImport.User)
+	}
+
+	for _, e := range bookImport.Errors {
+		view.Errors = append(view.Errors, ErrorView{
+			Row:        e.Row,
+			ColumnName: e.ColumnName,
+			Message:    e.Message,
+		})
+	}
+
+	return view
+}
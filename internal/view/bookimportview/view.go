@@ -0,0 +1,42 @@
+package bookimportview
+
+import (
+	"lms-backend/internal/model"
+	"time"
+
+	"github.com/ForAeons/ternary"
+)
+
+type View struct {
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	FileName      string     `json:"file_name"`
+	Status        string     `json:"status"`
+	DryRun        bool       `json:"dry_run"`
+	TotalRows     int        `json:"total_rows"`
+	ProcessedRows int        `json:"processed_rows"`
+	AcceptedRows  int        `json:"accepted_rows"`
+	RejectedRows  int        `json:"rejected_rows"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+}
+
+func ToView(bookImport *model.BookImport) *View {
+	return &View{
+		ID:            int64(bookImport.ID),
+		UserID:        int64(bookImport.UserID),
+		FileName:      bookImport.FileName,
+		Status:        bookImport.Status,
+		DryRun:        bookImport.DryRun,
+		TotalRows:     bookImport.TotalRows,
+		ProcessedRows: bookImport.ProcessedRows,
+		AcceptedRows:  bookImport.AcceptedRows,
+		RejectedRows:  bookImport.RejectedRows,
+		FailureReason: bookImport.FailureReason,
+		CreatedAt:     bookImport.CreatedAt,
+		FinishedAt: ternary.If[*time.Time](bookImport.FinishedAt.Valid).
+			Then(&bookImport.FinishedAt.Time).
+			Else(nil),
+	}
+}
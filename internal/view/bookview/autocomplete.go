@@ -2,6 +2,7 @@ package bookview
 
 import (
 	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
 )
 
 type SimpleView struct {
@@ -15,3 +16,21 @@ func ToSimpleView(book *model.Book) *SimpleView {
 		Title: book.Title,
 	}
 }
+
+// AutoCompleteView labels the suggestion with the field that matched.
+type AutoCompleteView struct {
+	SimpleView
+	MatchedField string `json:"matched_field"`
+	MatchedValue string `json:"matched_value"`
+}
+
+func ToAutoCompleteView(suggestion *viewmodel.BookAutoCompleteViewModel) *AutoCompleteView {
+	return &AutoCompleteView{
+		SimpleView: SimpleView{
+			ID:    suggestion.ID,
+			Title: suggestion.Title,
+		},
+		MatchedField: suggestion.MatchedField,
+		MatchedValue: suggestion.MatchedValue,
+	}
+}
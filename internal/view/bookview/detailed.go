@@ -8,6 +8,8 @@ import (
 type DetailedView struct {
 	View
 	BookCopies []sharedview.BookCopyView `json:"book_copies"`
+	// Only the bookmarks of the current user, when included
+	Bookmarks []sharedview.BookmarkView `json:"bookmarks,omitempty"`
 }
 
 func ToDetailedView(b *model.Book) *DetailedView {
@@ -20,5 +22,16 @@ func ToDetailedView(b *model.Book) *DetailedView {
 	return &DetailedView{
 		View:       *ToView(b),
 		BookCopies: copies,
+		Bookmarks:  toBookmarkViews(b.Bookmarks),
 	}
 }
+
+func toBookmarkViews(bookmarks []model.Bookmark) []sharedview.BookmarkView {
+	views := []sharedview.BookmarkView{}
+	for _, bookmark := range bookmarks {
+		//nolint:gosec // loop does not modify struct
+		views = append(views, *sharedview.ToBookmarkView(&bookmark))
+	}
+
+	return views
+}
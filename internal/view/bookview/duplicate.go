@@ -0,0 +1,36 @@
+package bookview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/util/sliceutil"
+)
+
+type DuplicateView struct {
+	ISBN  string                `json:"isbn"`
+	Books []sharedview.BookView `json:"books"`
+}
+
+type DuplicateReportView struct {
+	Duplicates   []DuplicateView       `json:"duplicates"`
+	InvalidBooks []sharedview.BookView `json:"invalid_books"`
+}
+
+func toBookViews(books []model.Book) []sharedview.BookView {
+	return sliceutil.Map(books, func(b model.Book) sharedview.BookView {
+		return *sharedview.ToBookView(&b)
+	})
+}
+
+func ToDuplicateReportView(report *viewmodel.BookDuplicateReportViewModel) *DuplicateReportView {
+	return &DuplicateReportView{
+		Duplicates: sliceutil.Map(report.Duplicates, func(d viewmodel.BookDuplicateViewModel) DuplicateView {
+			return DuplicateView{
+				ISBN:  d.ISBN,
+				Books: toBookViews(d.Books),
+			}
+		}),
+		InvalidBooks: toBookViews(report.InvalidBooks),
+	}
+}
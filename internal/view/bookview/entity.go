@@ -0,0 +1,23 @@
+package bookview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type ContributorView struct {
+	sharedview.ContributorView
+	Role string `json:"role"`
+}
+
+func ToContributorView(bc *model.BookContributor) *ContributorView {
+	view := &ContributorView{
+		ContributorView: sharedview.ContributorView{ID: bc.ContributorID},
+		Role:            bc.Role,
+	}
+	if bc.Contributor != nil {
+		view.ContributorView = *sharedview.ToContributorView(bc.Contributor)
+	}
+
+	return view
+}
@@ -0,0 +1,17 @@
+package bookview
+
+import (
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+)
+
+var ExportColumns = []export.Column[model.Book]{
+	{Header: "ID", Value: func(b *model.Book) string { return export.ID(b.ID) }},
+	{Header: "Title", Value: func(b *model.Book) string { return b.Title }},
+	{Header: "Author", Value: func(b *model.Book) string { return b.Author }},
+	{Header: "ISBN", Value: func(b *model.Book) string { return b.ISBN }},
+	{Header: "Publisher", Value: func(b *model.Book) string { return b.Publisher }},
+	{Header: "Publication Date", Value: func(b *model.Book) string { return export.Time(b.PublicationDate) }},
+	{Header: "Genre", Value: func(b *model.Book) string { return b.Genre }},
+	{Header: "Language", Value: func(b *model.Book) string { return b.Language }},
+}
@@ -0,0 +1,34 @@
+package bookview
+
+import (
+	"lms-backend/internal/viewmodel"
+)
+
+type FacetBucketView struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+type FacetView struct {
+	Name      string            `json:"name"`
+	FilterKey string            `json:"filter_key"`
+	Buckets   []FacetBucketView `json:"buckets"`
+}
+
+func ToFacetView(facet *viewmodel.FacetViewModel) *FacetView {
+	buckets := []FacetBucketView{}
+	for _, bucket := range facet.Buckets {
+		buckets = append(buckets, FacetBucketView{
+			Value: bucket.Value,
+			Label: bucket.Label,
+			Count: bucket.Count,
+		})
+	}
+
+	return &FacetView{
+		Name:      facet.Name,
+		FilterKey: facet.FilterKey,
+		Buckets:   buckets,
+	}
+}
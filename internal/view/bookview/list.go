@@ -0,0 +1,62 @@
+package bookview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+	"lms-backend/internal/viewmodel"
+	"time"
+)
+
+type AvailabilityView struct {
+	TotalCopies     int64 `json:"total_copies"`
+	AvailableCopies int64 `json:"available_copies"`
+	LoanedCopies    int64 `json:"loaned_copies"`
+	ReservedCopies  int64 `json:"reserved_copies"`
+	// Estimated from the earliest due date of the loaned copies, omitted while a copy is available
+	NextAvailableAt *time.Time `json:"next_available_at,omitempty"`
+}
+
+// ListView shows a book in the catalogue with its copies counted rather than listed.
+type ListView struct {
+	View
+	Availability AvailabilityView `json:"availability"`
+	// Copies and the bookmarks of the current user, when included
+	BookCopies []sharedview.BookCopyView `json:"book_copies,omitempty"`
+	Bookmarks  []sharedview.BookmarkView `json:"bookmarks,omitempty"`
+}
+
+func ToAvailabilityView(availability *viewmodel.BookAvailabilityViewModel) *AvailabilityView {
+	view := &AvailabilityView{
+		TotalCopies:     availability.TotalCopies,
+		AvailableCopies: availability.AvailableCopies,
+		LoanedCopies:    availability.LoanedCopies,
+		ReservedCopies:  availability.ReservedCopies,
+	}
+
+	if availability.AvailableCopies == 0 && availability.NextDueDate.Valid {
+		view.NextAvailableAt = &availability.NextDueDate.Time
+	}
+
+	return view
+}
+
+// ToListView shows the book with its availability, which is nil for books without copies.
+func ToListView(book *model.Book, availability *viewmodel.BookAvailabilityViewModel) *ListView {
+	copies := []sharedview.BookCopyView{}
+	for _, copy := range book.BookCopies {
+		//nolint:gosec // loop does not modify struct
+		copies = append(copies, *sharedview.ToBookCopyView(&copy))
+	}
+
+	view := &ListView{
+		View:       *ToView(book),
+		BookCopies: copies,
+		Bookmarks:  toBookmarkViews(book.Bookmarks),
+	}
+
+	if availability != nil {
+		view.Availability = *ToAvailabilityView(availability)
+	}
+
+	return view
+}
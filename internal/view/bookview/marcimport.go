@@ -0,0 +1,50 @@
+package bookview
+
+import (
+	"lms-backend/internal/marcmapping"
+	"lms-backend/internal/viewmodel"
+	"lms-backend/util/sliceutil"
+)
+
+type MARCImportRecordView struct {
+	Index         int                   `json:"index"`
+	ControlNumber string                `json:"control_number,omitempty"`
+	Title         string                `json:"title"`
+	ISBN          string                `json:"isbn"`
+	BookID        uint                  `json:"book_id,omitempty"`
+	CopyCount     int                   `json:"copy_count"`
+	Accepted      bool                  `json:"accepted"`
+	Problems      []marcmapping.Problem `json:"problems"`
+}
+
+type MARCImportReportView struct {
+	DryRun   bool                   `json:"dry_run"`
+	Accepted int                    `json:"accepted"`
+	Skipped  int                    `json:"skipped"`
+	Records  []MARCImportRecordView `json:"records"`
+}
+
+func ToMARCImportReportView(report *viewmodel.MARCImportReportViewModel) *MARCImportReportView {
+	return &MARCImportReportView{
+		DryRun:   report.DryRun,
+		Accepted: report.Accepted,
+		Skipped:  report.Skipped,
+		Records: sliceutil.Map(report.Records, func(r viewmodel.MARCImportRecordViewModel) MARCImportRecordView {
+			problems := r.Problems
+			if problems == nil {
+				problems = []marcmapping.Problem{}
+			}
+
+			return MARCImportRecordView{
+				Index:         r.Index,
+				ControlNumber: r.ControlNumber,
+				Title:         r.Title,
+				ISBN:          r.ISBN,
+				BookID:        r.BookID,
+				CopyCount:     r.CopyCount,
+				Accepted:      r.Accepted,
+				Problems:      problems,
+			}
+		}),
+	}
+}
@@ -0,0 +1,42 @@
+package bookview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
+)
+
+// HighlightView holds the fields with the words matching the search wrapped in <mark> tags.
+type HighlightView struct {
+	Title     string `json:"title"`
+	Author    string `json:"author"`
+	Publisher string `json:"publisher"`
+	Genre     string `json:"genre"`
+}
+
+type SearchResultView struct {
+	ListView
+	Rank      float64        `json:"rank"`
+	Highlight *HighlightView `json:"highlight,omitempty"`
+}
+
+func ToSearchResultView(
+	book *model.Book,
+	availability *viewmodel.BookAvailabilityViewModel,
+	highlight *viewmodel.BookSearchHighlightViewModel,
+) *SearchResultView {
+	view := &SearchResultView{
+		ListView: *ToListView(book, availability),
+	}
+
+	if highlight != nil {
+		view.Rank = highlight.Rank
+		view.Highlight = &HighlightView{
+			Title:     highlight.Title,
+			Author:    highlight.Author,
+			Publisher: highlight.Publisher,
+			Genre:     highlight.Genre,
+		}
+	}
+
+	return view
+}
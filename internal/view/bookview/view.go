@@ -7,7 +7,10 @@ import (
 
 type View struct {
 	sharedview.BookView
-	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	ThumbnailURL string                     `json:"thumbnail_url,omitempty"`
+	Contributors []ContributorView          `json:"contributors,omitempty"`
+	Subjects     []sharedview.SubjectView   `json:"subjects,omitempty"`
+	Publishers   []sharedview.PublisherView `json:"publishers,omitempty"`
 }
 
 func ToView(book *model.Book) *View {
@@ -16,8 +19,29 @@ func ToView(book *model.Book) *View {
 		thumbnailurl = book.Thumbnail.GetImageDownloadURL()
 	}
 
+	contributors := []ContributorView{}
+	for _, bc := range book.BookContributors {
+		//nolint:gosec // loop does not modify struct
+		contributors = append(contributors, *ToContributorView(&bc))
+	}
+
+	subjects := []sharedview.SubjectView{}
+	for _, subject := range book.Subjects {
+		//nolint:gosec // loop does not modify struct
+		subjects = append(subjects, *sharedview.ToSubjectView(&subject))
+	}
+
+	publishers := []sharedview.PublisherView{}
+	for _, publisher := range book.Publishers {
+		//nolint:gosec // loop does not modify struct
+		publishers = append(publishers, *sharedview.ToPublisherView(&publisher))
+	}
+
 	return &View{
 		BookView:     *sharedview.ToBookView(book),
 		ThumbnailURL: thumbnailurl,
+		Contributors: contributors,
+		Subjects:     subjects,
+		Publishers:   publishers,
 	}
 }
@@ -0,0 +1,16 @@
+package branchview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type View struct {
+	sharedview.BranchView
+}
+
+func ToView(branch *model.Branch) *View {
+	return &View{
+		BranchView: *sharedview.ToBranchView(branch),
+	}
+}
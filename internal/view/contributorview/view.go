@@ -0,0 +1,16 @@
+package contributorview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type View struct {
+	sharedview.ContributorView
+}
+
+func ToView(contributor *model.Contributor) *View {
+	return &View{
+		ContributorView: *sharedview.ToContributorView(contributor),
+	}
+}
@@ -0,0 +1,30 @@
+package fineview
+
+import (
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/loanview"
+	"lms-backend/internal/view/userview"
+	"strconv"
+)
+
+var ExportColumns = []export.Column[model.Fine]{
+	{Header: "ID", Value: func(f *model.Fine) string { return export.ID(f.ID) }},
+	{Header: "Status", Value: func(f *model.Fine) string { return f.Status }},
+	{Header: "Amount", Value: func(f *model.Fine) string { return strconv.FormatFloat(f.Amount, 'f', 2, 64) }},
+	{Header: "Title", Value: func(f *model.Fine) string {
+		if f.Loan == nil {
+			return ""
+		}
+		return loanview.BookTitle(f.Loan.BookCopy)
+	}},
+	{Header: "Username", Value: func(f *model.Fine) string {
+		if f.User == nil {
+			return ""
+		}
+		return f.User.Username
+	}},
+	{Header: "Full Name", Value: func(f *model.Fine) string { return userview.FullName(f.User) }},
+	{Header: "Loan ID", Value: func(f *model.Fine) string { return export.ID(f.LoanID) }},
+	{Header: "Created At", Value: func(f *model.Fine) string { return export.Time(f.CreatedAt) }},
+}
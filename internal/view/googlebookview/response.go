@@ -20,6 +20,7 @@ type ItemView struct {
 
 type VolumeInfoView struct {
 	Title               string                   `json:"title"`
+	Subtitle            string                   `json:"subtitle"`
 	Authors             []string                 `json:"authors"`
 	Publisher           string                   `json:"publisher"`
 	PublishedDate       string                   `json:"publishedDate"`
@@ -58,6 +59,11 @@ type PanelizationSummaryView struct {
 type ImageLinksView struct {
 	SmallThumbnail string `json:"smallThumbnail"`
 	Thumbnail      string `json:"thumbnail"`
+	// Only returned when reading a single volume
+	Small      string `json:"small"`
+	Medium     string `json:"medium"`
+	Large      string `json:"large"`
+	ExtraLarge string `json:"extraLarge"`
 }
 
 type SaleInfoView struct {
@@ -0,0 +1,38 @@
+package loanview
+
+import (
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/userview"
+)
+
+var ExportColumns = []export.Column[model.Loan]{
+	{Header: "ID", Value: func(l *model.Loan) string { return export.ID(l.ID) }},
+	{Header: "Status", Value: func(l *model.Loan) string { return l.Status }},
+	{Header: "Title", Value: func(l *model.Loan) string { return BookTitle(l.BookCopy) }},
+	{Header: "Accession Number", Value: func(l *model.Loan) string {
+		if l.BookCopy == nil {
+			return ""
+		}
+		return l.BookCopy.AccessionNumber
+	}},
+	{Header: "Username", Value: func(l *model.Loan) string {
+		if l.User == nil {
+			return ""
+		}
+		return l.User.Username
+	}},
+	{Header: "Full Name", Value: func(l *model.Loan) string { return userview.FullName(l.User) }},
+	{Header: "Borrow Date", Value: func(l *model.Loan) string { return export.Time(l.BorrowDate) }},
+	{Header: "Due Date", Value: func(l *model.Loan) string { return export.Time(l.DueDate) }},
+	{Header: "Return Date", Value: func(l *model.Loan) string { return export.NullTime(l.ReturnDate) }},
+}
+
+// BookTitle is the title of the copy's book, or empty if it is not loaded.
+func BookTitle(copy *model.BookCopy) string {
+	if copy == nil || copy.Book == nil {
+		return ""
+	}
+
+	return copy.Book.Title
+}
@@ -0,0 +1,51 @@
+package metadataview
+
+import (
+	"lms-backend/internal/metadata"
+	"time"
+)
+
+type SourceView struct {
+	Provider string `json:"provider"`
+	ID       string `json:"id"`
+}
+
+type ResultView struct {
+	ISBN            string       `json:"isbn"`
+	Title           string       `json:"title"`
+	Authors         []string     `json:"authors"`
+	Publishers      []string     `json:"publishers"`
+	PublicationDate string       `json:"publication_date,omitempty"`
+	Subjects        []string     `json:"subjects"`
+	Language        string       `json:"language"`
+	Description     string       `json:"description,omitempty"`
+	CoverURL        string       `json:"cover_url,omitempty"`
+	Sources         []SourceView `json:"sources"`
+}
+
+func ToResultView(result *metadata.Result) *ResultView {
+	view := &ResultView{
+		ISBN:        result.ISBN,
+		Title:       result.Title,
+		Authors:     result.Authors,
+		Publishers:  result.Publishers,
+		Subjects:    result.Subjects,
+		Language:    result.Language,
+		Description: result.Description,
+		CoverURL:    result.CoverURL,
+		Sources:     []SourceView{},
+	}
+
+	if !(time.Time{}).Equal(result.PublicationDate) {
+		view.PublicationDate = result.PublicationDate.Format(time.RFC3339)
+	}
+
+	for _, source := range result.Sources {
+		view.Sources = append(view.Sources, SourceView{
+			Provider: source.Provider,
+			ID:       source.ID,
+		})
+	}
+
+	return view
+}
@@ -0,0 +1,16 @@
+package publisherview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type View struct {
+	sharedview.PublisherView
+}
+
+func ToView(publisher *model.Publisher) *View {
+	return &View{
+		PublisherView: *sharedview.ToPublisherView(publisher),
+	}
+}
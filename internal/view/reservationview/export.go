@@ -0,0 +1,23 @@
+package reservationview
+
+import (
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/loanview"
+	"lms-backend/internal/view/userview"
+)
+
+var ExportColumns = []export.Column[model.Reservation]{
+	{Header: "ID", Value: func(r *model.Reservation) string { return export.ID(r.ID) }},
+	{Header: "Status", Value: func(r *model.Reservation) string { return r.Status }},
+	{Header: "Title", Value: func(r *model.Reservation) string { return loanview.BookTitle(r.BookCopy) }},
+	{Header: "Username", Value: func(r *model.Reservation) string {
+		if r.User == nil {
+			return ""
+		}
+		return r.User.Username
+	}},
+	{Header: "Full Name", Value: func(r *model.Reservation) string { return userview.FullName(r.User) }},
+	{Header: "Reserved Until", Value: func(r *model.Reservation) string { return export.Time(r.ReservationDate) }},
+	{Header: "Pickup Branch ID", Value: func(r *model.Reservation) string { return export.ID(r.PickupBranchID) }},
+}
@@ -5,15 +5,25 @@ import (
 )
 
 type BookCopyView struct {
-	ID     uint   `json:"id,omitempty"`
-	BookID uint   `json:"book_id"`
-	Status string `json:"status"`
+	ID              uint   `json:"id,omitempty"`
+	BookID          uint   `json:"book_id"`
+	Status          string `json:"status"`
+	HomeBranchID    uint   `json:"home_branch_id"`
+	CurrentBranchID uint   `json:"current_branch_id"`
+	AccessionNumber string `json:"accession_number"`
+	CallNumber      string `json:"call_number"`
+	ShelfLocation   string `json:"shelf_location"`
 }
 
 func ToBookCopyView(bookCopy *model.BookCopy) *BookCopyView {
 	return &BookCopyView{
-		ID:     bookCopy.ID,
-		BookID: bookCopy.BookID,
-		Status: bookCopy.Status,
+		ID:              bookCopy.ID,
+		BookID:          bookCopy.BookID,
+		Status:          bookCopy.Status,
+		HomeBranchID:    bookCopy.HomeBranchID,
+		CurrentBranchID: bookCopy.CurrentBranchID,
+		AccessionNumber: bookCopy.AccessionNumber,
+		CallNumber:      bookCopy.CallNumber,
+		ShelfLocation:   bookCopy.ShelfLocation,
 	}
 }
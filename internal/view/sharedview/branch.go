@@ -0,0 +1,19 @@
+package sharedview
+
+import (
+	"lms-backend/internal/model"
+)
+
+type BranchView struct {
+	ID      uint   `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+func ToBranchView(branch *model.Branch) *BranchView {
+	return &BranchView{
+		ID:      branch.ID,
+		Name:    branch.Name,
+		Address: branch.Address,
+	}
+}
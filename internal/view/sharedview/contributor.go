@@ -0,0 +1,17 @@
+package sharedview
+
+import (
+	"lms-backend/internal/model"
+)
+
+type ContributorView struct {
+	ID   uint   `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func ToContributorView(contributor *model.Contributor) *ContributorView {
+	return &ContributorView{
+		ID:   contributor.ID,
+		Name: contributor.Name,
+	}
+}
@@ -0,0 +1,17 @@
+package sharedview
+
+import (
+	"lms-backend/internal/model"
+)
+
+type PublisherView struct {
+	ID   uint   `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func ToPublisherView(publisher *model.Publisher) *PublisherView {
+	return &PublisherView{
+		ID:   publisher.ID,
+		Name: publisher.Name,
+	}
+}
@@ -11,6 +11,7 @@ type ResView struct {
 	BookCopyID      int64     `json:"book_copy_id"`
 	Status          string    `json:"status"`
 	ReservationDate time.Time `json:"reservation_date"`
+	PickupBranchID  int64     `json:"pickup_branch_id"`
 }
 
 func ToResView(reservation *model.Reservation) *ResView {
@@ -20,5 +21,6 @@ func ToResView(reservation *model.Reservation) *ResView {
 		BookCopyID:      int64(reservation.BookCopyID),
 		Status:          reservation.Status,
 		ReservationDate: reservation.ReservationDate,
+		PickupBranchID:  int64(reservation.PickupBranchID),
 	}
 }
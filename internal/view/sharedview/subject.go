@@ -0,0 +1,17 @@
+package sharedview
+
+import (
+	"lms-backend/internal/model"
+)
+
+type SubjectView struct {
+	ID   uint   `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func ToSubjectView(subject *model.Subject) *SubjectView {
+	return &SubjectView{
+		ID:   subject.ID,
+		Name: subject.Name,
+	}
+}
@@ -0,0 +1,34 @@
+package sharedview
+
+import (
+	"lms-backend/internal/model"
+	"time"
+
+	"github.com/ForAeons/ternary"
+)
+
+type TransferView struct {
+	ID           int64      `json:"id,omitempty"`
+	BookCopyID   int64      `json:"book_copy_id"`
+	FromBranchID int64      `json:"from_branch_id"`
+	ToBranchID   int64      `json:"to_branch_id"`
+	UserID       int64      `json:"user_id"`
+	Status       string     `json:"status"`
+	SentAt       *time.Time `json:"sent_at"`
+	ReceivedAt   *time.Time `json:"received_at"`
+}
+
+func ToTransferView(transfer *model.Transfer) *TransferView {
+	return &TransferView{
+		ID:           int64(transfer.ID),
+		BookCopyID:   int64(transfer.BookCopyID),
+		FromBranchID: int64(transfer.FromBranchID),
+		ToBranchID:   int64(transfer.ToBranchID),
+		UserID:       int64(transfer.UserID),
+		Status:       transfer.Status,
+		SentAt:       &transfer.SentAt,
+		ReceivedAt: ternary.If[*time.Time](transfer.ReceivedAt.Valid).
+			Then(&transfer.ReceivedAt.Time).
+			Else(nil),
+	}
+}
@@ -0,0 +1,16 @@
+package subjectview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type View struct {
+	sharedview.SubjectView
+}
+
+func ToView(subject *model.Subject) *View {
+	return &View{
+		SubjectView: *sharedview.ToSubjectView(subject),
+	}
+}
@@ -0,0 +1,24 @@
+package transferview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type DetailedView struct {
+	View
+	Book       *sharedview.BookView   `json:"book"`
+	FromBranch *sharedview.BranchView `json:"from_branch"`
+	ToBranch   *sharedview.BranchView `json:"to_branch"`
+	User       *sharedview.UserView   `json:"user"`
+}
+
+func ToDetailedView(transfer *model.Transfer) *DetailedView {
+	return &DetailedView{
+		View:       *ToView(transfer),
+		Book:       sharedview.ToBookView(transfer.BookCopy.Book),
+		FromBranch: sharedview.ToBranchView(transfer.FromBranch),
+		ToBranch:   sharedview.ToBranchView(transfer.ToBranch),
+		User:       sharedview.ToUserView(transfer.User),
+	}
+}
@@ -0,0 +1,16 @@
+package transferview
+
+import (
+	"lms-backend/internal/model"
+	"lms-backend/internal/view/sharedview"
+)
+
+type View struct {
+	sharedview.TransferView
+}
+
+func ToView(transfer *model.Transfer) *View {
+	return &View{
+		TransferView: *sharedview.ToTransferView(transfer),
+	}
+}
@@ -2,6 +2,7 @@ package userview
 
 import (
 	"lms-backend/internal/model"
+	"lms-backend/internal/viewmodel"
 )
 
 type SimpleView struct {
@@ -15,3 +16,21 @@ func ToSimpleView(user *model.User) *SimpleView {
 		Username: user.Username,
 	}
 }
+
+// AutoCompleteView labels the suggestion with the field that matched.
+type AutoCompleteView struct {
+	SimpleView
+	MatchedField string `json:"matched_field"`
+	MatchedValue string `json:"matched_value"`
+}
+
+func ToAutoCompleteView(suggestion *viewmodel.UserAutoCompleteViewModel) *AutoCompleteView {
+	return &AutoCompleteView{
+		SimpleView: SimpleView{
+			ID:       suggestion.ID,
+			Username: suggestion.Username,
+		},
+		MatchedField: suggestion.MatchedField,
+		MatchedValue: suggestion.MatchedValue,
+	}
+}
@@ -0,0 +1,29 @@
+package userview
+
+import (
+	"lms-backend/internal/export"
+	"lms-backend/internal/model"
+)
+
+var ExportColumns = []export.Column[model.User]{
+	{Header: "ID", Value: func(u *model.User) string { return export.ID(u.ID) }},
+	{Header: "Username", Value: func(u *model.User) string { return u.Username }},
+	{Header: "Full Name", Value: func(u *model.User) string { return FullName(u) }},
+	{Header: "Preferred Name", Value: func(u *model.User) string {
+		if u.Person == nil {
+			return ""
+		}
+		return u.Person.PreferredName
+	}},
+	{Header: "Last Sign In", Value: func(u *model.User) string { return export.Time(u.LastSignInAt) }},
+	{Header: "Created At", Value: func(u *model.User) string { return export.Time(u.CreatedAt) }},
+}
+
+// FullName is the full name of the user's person, or empty if it is not loaded.
+func FullName(u *model.User) string {
+	if u == nil || u.Person == nil {
+		return ""
+	}
+
+	return u.Person.FullName
+}
@@ -10,6 +10,8 @@ import (
 type LoginView struct {
 	User       *sharedview.UserView `json:"user"`
 	PersonView *personview.View     `json:"person_attributes"`
+	Locale     string               `json:"locale"`
+	TimeZone   string               `json:"time_zone"`
 	Abilities  []string             `json:"abilities"`
 	CsrfToken  string               `json:"csrf_token"`
 }
@@ -22,6 +24,8 @@ func ToLoginView(
 	return &LoginView{
 		User:       sharedview.ToUserView(user),
 		PersonView: personview.ToView(user.Person),
+		Locale:     user.Locale,
+		TimeZone:   user.TimeZone,
 		Abilities:  sliceutil.Map(abilities, func(a model.Ability) string { return a.Name }),
 		CsrfToken:  csrfToken,
 	}
@@ -10,6 +10,8 @@ import (
 type View struct {
 	sharedview.UserView
 	PersonView *personview.View `json:"person_attributes"`
+	Locale     string           `json:"locale"`
+	TimeZone   string           `json:"time_zone"`
 	Abilities  []string         `json:"abilities,omitempty"`
 }
 
@@ -17,6 +19,8 @@ func ToView(user *model.User, abilities ...model.Ability) *View {
 	return &View{
 		UserView:   *sharedview.ToUserView(user),
 		PersonView: personview.ToView(user.Person),
+		Locale:     user.Locale,
+		TimeZone:   user.TimeZone,
 		Abilities:  sliceutil.Map(abilities, func(a model.Ability) string { return a.Name }),
 	}
 }
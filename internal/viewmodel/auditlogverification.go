@@ -0,0 +1,18 @@
+package viewmodel
+
+// AuditLogVerificationViewModel is the result of checking the hash chain of the audit log.
+//
+// Only changes up to the last entry are detected. LastHash may be kept elsewhere to check
+// later that no entry was removed from the end.
+type AuditLogVerificationViewModel struct {
+	Checked   int64  // Chained entries checked
+	Unchained int64  // Entries from before the chain, which cannot be checked
+	LastID    uint   // Last entry checked
+	LastHash  string // Hash of the last entry checked
+	BrokenID  uint   // First entry not following the one before, 0 if there is none
+	Reason    string // Why the chain is broken at BrokenID
+}
+
+func (v *AuditLogVerificationViewModel) Valid() bool {
+	return v.BrokenID == 0
+}
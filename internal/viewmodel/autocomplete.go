@@ -0,0 +1,19 @@
+package viewmodel
+
+// BookAutoCompleteViewModel is a book suggested for what was typed, with the field that matched.
+type BookAutoCompleteViewModel struct {
+	ID           uint
+	Title        string
+	MatchedField string
+	MatchedValue string
+	Score        float64
+}
+
+// UserAutoCompleteViewModel is a user suggested for what was typed, with the field that matched.
+type UserAutoCompleteViewModel struct {
+	ID           uint
+	Username     string
+	MatchedField string
+	MatchedValue string
+	Score        float64
+}
@@ -0,0 +1,16 @@
+package viewmodel
+
+import (
+	"database/sql"
+)
+
+// BookAvailabilityViewModel counts the copies of a book by status.
+// NextDueDate is the earliest due date among the copies on loan.
+type BookAvailabilityViewModel struct {
+	BookID          uint
+	TotalCopies     int64
+	AvailableCopies int64
+	LoanedCopies    int64
+	ReservedCopies  int64
+	NextDueDate     sql.NullTime
+}
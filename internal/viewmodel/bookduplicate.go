@@ -0,0 +1,16 @@
+package viewmodel
+
+import (
+	"lms-backend/internal/model"
+)
+
+// BookDuplicateViewModel groups books whose ISBNs normalize to the same ISBN-13.
+type BookDuplicateViewModel struct {
+	ISBN  string
+	Books []model.Book
+}
+
+type BookDuplicateReportViewModel struct {
+	Duplicates   []BookDuplicateViewModel
+	InvalidBooks []model.Book
+}
@@ -0,0 +1,17 @@
+package viewmodel
+
+// FacetBucketViewModel is one value of a facet and the number of books having it.
+// Value is what goes into the facet's filter.
+type FacetBucketViewModel struct {
+	Value string
+	Label string
+	Count int64
+}
+
+// FacetViewModel counts the books of a result set by one of their properties.
+// Buckets are selected through filter[FilterKey]=<value>.
+type FacetViewModel struct {
+	Name      string
+	FilterKey string
+	Buckets   []FacetBucketViewModel
+}
@@ -0,0 +1,12 @@
+package viewmodel
+
+// BookSearchHighlightViewModel holds the fields of a book matching a full-text search,
+// with the matched words wrapped in <mark> tags.
+type BookSearchHighlightViewModel struct {
+	BookID    uint
+	Rank      float64
+	Title     string
+	Author    string
+	Publisher string
+	Genre     string
+}
@@ -0,0 +1,24 @@
+package viewmodel
+
+import (
+	"lms-backend/internal/marcmapping"
+)
+
+type MARCImportRecordViewModel struct {
+	Index         int
+	ControlNumber string
+	Title         string
+	ISBN          string
+	BookID        uint
+	CopyCount     int
+	Accepted      bool
+	Problems      []marcmapping.Problem
+}
+
+// Accepted records are imported, or would be imported on a dry run.
+type MARCImportReportViewModel struct {
+	DryRun   bool
+	Accepted int
+	Skipped  int
+	Records  []MARCImportRecordViewModel
+}
@@ -4,7 +4,7 @@ import (
 	"lms-backend/internal/app"
 )
 
-// @title Library Management System API
+// Serves the Library Management System API, documented at /api/v1/docs
 func main() {
 	// Setup and run the app
 	err := app.SetupAndRunApp()
@@ -0,0 +1,87 @@
+-- +migrate Up
+CREATE TABLE
+  branches (
+    id BIGSERIAL PRIMARY KEY,
+    NAME VARCHAR UNIQUE NOT NULL,
+    address VARCHAR NOT NULL DEFAULT '',
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_branches_deleted_at ON branches (deleted_at);
+
+-- Existing copies are assigned to the default branch
+INSERT INTO
+  branches (NAME, address)
+VALUES
+  ('Main Branch', '');
+
+ALTER TABLE book_copies
+ADD COLUMN home_branch_id BIGINT REFERENCES branches (id),
+ADD COLUMN current_branch_id BIGINT REFERENCES branches (id);
+
+UPDATE book_copies
+SET
+  home_branch_id = (SELECT MIN(id) FROM branches),
+  current_branch_id = (SELECT MIN(id) FROM branches);
+
+ALTER TABLE book_copies
+ALTER COLUMN home_branch_id SET NOT NULL,
+ALTER COLUMN current_branch_id SET NOT NULL;
+
+CREATE INDEX idx_book_copies_current_branch_id ON book_copies (current_branch_id);
+
+ALTER TABLE reservations
+ADD COLUMN pickup_branch_id BIGINT REFERENCES branches (id);
+
+UPDATE reservations
+SET
+  pickup_branch_id = book_copies.current_branch_id
+FROM
+  book_copies
+WHERE
+  reservations.book_copy_id = book_copies.id;
+
+ALTER TABLE reservations
+ALTER COLUMN pickup_branch_id SET NOT NULL;
+
+CREATE TABLE
+  transfers (
+    id BIGSERIAL PRIMARY KEY,
+    book_copy_id BIGINT NOT NULL REFERENCES book_copies (id),
+    from_branch_id BIGINT NOT NULL REFERENCES branches (id),
+    to_branch_id BIGINT NOT NULL REFERENCES branches (id),
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    status VARCHAR NOT NULL,
+    sent_at TIMESTAMP NOT NULL,
+    received_at TIMESTAMP,
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_transfers_deleted_at ON transfers (deleted_at);
+
+CREATE TABLE
+  user_branches (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    branch_id BIGINT NOT NULL REFERENCES branches (id),
+    created_at created_at,
+    UNIQUE (user_id, branch_id)
+  );
+
+-- +migrate Down
+DROP TABLE user_branches;
+
+DROP TABLE transfers;
+
+ALTER TABLE reservations
+DROP COLUMN pickup_branch_id;
+
+ALTER TABLE book_copies
+DROP COLUMN current_branch_id,
+DROP COLUMN home_branch_id;
+
+DROP TABLE branches;
\ No newline at end of file
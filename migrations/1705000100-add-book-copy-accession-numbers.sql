@@ -0,0 +1,51 @@
+-- +migrate Up
+CREATE SEQUENCE book_copy_accession_seq;
+
+ALTER TABLE book_copies
+ADD COLUMN accession_number VARCHAR(32),
+ADD COLUMN call_number VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN shelf_location VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN acquisition_date TIMESTAMP,
+ADD COLUMN acquisition_source VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN acquisition_price NUMERIC(12, 2) NOT NULL DEFAULT 0;
+
+-- Existing copies are numbered in the order they were created, using the default format
+UPDATE book_copies
+SET
+  accession_number = numbered.accession_number
+FROM
+  (
+    SELECT
+      id,
+      'LMS' || LPAD(NEXTVAL('book_copy_accession_seq')::TEXT, 8, '0') AS accession_number
+    FROM
+      (
+        SELECT
+          id
+        FROM
+          book_copies
+        ORDER BY
+          id
+      ) AS ordered
+  ) AS numbered
+WHERE
+  book_copies.id = numbered.id;
+
+ALTER TABLE book_copies
+ALTER COLUMN accession_number SET NOT NULL;
+
+-- Accession numbers are never reused, even after a copy is deleted
+CREATE UNIQUE INDEX idx_book_copies_accession_number ON book_copies (accession_number);
+
+-- +migrate Down
+DROP INDEX idx_book_copies_accession_number;
+
+ALTER TABLE book_copies
+DROP COLUMN acquisition_price,
+DROP COLUMN acquisition_source,
+DROP COLUMN acquisition_date,
+DROP COLUMN shelf_location,
+DROP COLUMN call_number,
+DROP COLUMN accession_number;
+
+DROP SEQUENCE book_copy_accession_seq;
\ No newline at end of file
@@ -0,0 +1,153 @@
+-- +migrate Up
+CREATE TABLE
+  contributors (
+    id BIGSERIAL PRIMARY KEY,
+    NAME VARCHAR UNIQUE NOT NULL,
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_contributors_deleted_at ON contributors (deleted_at);
+
+CREATE TABLE
+  book_contributors (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL REFERENCES books (id),
+    contributor_id BIGINT NOT NULL REFERENCES contributors (id),
+    ROLE VARCHAR NOT NULL,
+    POSITION INT NOT NULL DEFAULT 0,
+    created_at created_at,
+    UNIQUE (book_id, contributor_id, ROLE)
+  );
+
+CREATE INDEX idx_book_contributors_contributor_id ON book_contributors (contributor_id);
+
+CREATE TABLE
+  subjects (
+    id BIGSERIAL PRIMARY KEY,
+    NAME VARCHAR UNIQUE NOT NULL,
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_subjects_deleted_at ON subjects (deleted_at);
+
+CREATE TABLE
+  book_subjects (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL REFERENCES books (id),
+    subject_id BIGINT NOT NULL REFERENCES subjects (id),
+    created_at created_at,
+    UNIQUE (book_id, subject_id)
+  );
+
+CREATE INDEX idx_book_subjects_subject_id ON book_subjects (subject_id);
+
+CREATE TABLE
+  publishers (
+    id BIGSERIAL PRIMARY KEY,
+    NAME VARCHAR UNIQUE NOT NULL,
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_publishers_deleted_at ON publishers (deleted_at);
+
+CREATE TABLE
+  book_publishers (
+    id BIGSERIAL PRIMARY KEY,
+    book_id BIGINT NOT NULL REFERENCES books (id),
+    publisher_id BIGINT NOT NULL REFERENCES publishers (id),
+    created_at created_at,
+    UNIQUE (book_id, publisher_id)
+  );
+
+CREATE INDEX idx_book_publishers_publisher_id ON book_publishers (publisher_id);
+
+-- Split the existing free-text columns into entities.
+-- Authors are separated by semicolons, ampersands or "and", and not commas so that
+-- "Last, First" stays one name as in MARC records, subjects by commas or semicolons
+-- and publishers by semicolons.
+INSERT INTO
+  contributors (NAME)
+SELECT DISTINCT
+  TRIM(parts.name)
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.author, '\s*(;|&|\s+and\s+)\s*') AS parts (NAME)
+WHERE
+  TRIM(parts.name) <> '';
+
+INSERT INTO
+  book_contributors (book_id, contributor_id, ROLE, POSITION)
+SELECT DISTINCT
+  ON (books.id, contributors.id) books.id,
+  contributors.id,
+  'author',
+  parts.position - 1
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.author, '\s*(;|&|\s+and\s+)\s*')
+  WITH
+    ORDINALITY AS parts (NAME, POSITION)
+  INNER JOIN contributors ON contributors.name = TRIM(parts.name)
+ORDER BY
+  books.id,
+  contributors.id,
+  parts.position;
+
+INSERT INTO
+  subjects (NAME)
+SELECT DISTINCT
+  TRIM(parts.name)
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.genre, '\s*[,;]\s*') AS parts (NAME)
+WHERE
+  TRIM(parts.name) <> '';
+
+INSERT INTO
+  book_subjects (book_id, subject_id)
+SELECT DISTINCT
+  books.id,
+  subjects.id
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.genre, '\s*[,;]\s*') AS parts (NAME)
+  INNER JOIN subjects ON subjects.name = TRIM(parts.name);
+
+INSERT INTO
+  publishers (NAME)
+SELECT DISTINCT
+  TRIM(parts.name)
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.publisher, '\s*;\s*') AS parts (NAME)
+WHERE
+  TRIM(parts.name) <> '';
+
+INSERT INTO
+  book_publishers (book_id, publisher_id)
+SELECT DISTINCT
+  books.id,
+  publishers.id
+FROM
+  books
+  CROSS JOIN LATERAL REGEXP_SPLIT_TO_TABLE(books.publisher, '\s*;\s*') AS parts (NAME)
+  INNER JOIN publishers ON publishers.name = TRIM(parts.name);
+
+-- +migrate Down
+DROP TABLE book_publishers;
+
+DROP TABLE publishers;
+
+DROP TABLE book_subjects;
+
+DROP TABLE subjects;
+
+DROP TABLE book_contributors;
+
+DROP TABLE contributors;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE
+  book_imports (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    file_name VARCHAR NOT NULL,
+    status VARCHAR NOT NULL,
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    accepted_rows INT NOT NULL DEFAULT 0,
+    rejected_rows INT NOT NULL DEFAULT 0,
+    failure_reason VARCHAR NOT NULL DEFAULT '',
+    finished_at TIMESTAMP,
+    created_at created_at,
+    updated_at updated_at,
+    deleted_at deleted_at
+  );
+
+CREATE INDEX idx_book_imports_deleted_at ON book_imports (deleted_at);
+
+CREATE TABLE
+  book_import_errors (
+    id BIGSERIAL PRIMARY KEY,
+    book_import_id BIGINT NOT NULL REFERENCES book_imports (id),
+    ROW INT NOT NULL,
+    COLUMN_NAME VARCHAR NOT NULL DEFAULT '',
+    message VARCHAR NOT NULL,
+    created_at created_at
+  );
+
+CREATE INDEX idx_book_import_errors_book_import_id ON book_import_errors (book_import_id);
+
+-- +migrate Down
+DROP TABLE book_import_errors;
+
+DROP TABLE book_imports;
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- Kept up to date by Postgres whenever the display strings change. Weights rank
+-- title matches over author, genre and publisher matches.
+ALTER TABLE books
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+  SETWEIGHT(TO_TSVECTOR('english', COALESCE(title, '')), 'A') || SETWEIGHT(TO_TSVECTOR('english', COALESCE(author, '')), 'B') || SETWEIGHT(TO_TSVECTOR('english', COALESCE(genre, '')), 'C') || SETWEIGHT(TO_TSVECTOR('english', COALESCE(publisher, '')), 'D')
+) STORED;
+
+CREATE INDEX idx_books_search_vector ON books USING GIN (search_vector);
+
+-- +migrate Down
+DROP INDEX idx_books_search_vector;
+
+ALTER TABLE books
+DROP COLUMN search_vector;
\ No newline at end of file
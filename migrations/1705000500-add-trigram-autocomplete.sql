@@ -0,0 +1,39 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary can change, which keeps it out of indexes.
+-- Pinning the dictionary makes the result depend on the input alone.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION immutable_unaccent (TEXT) RETURNS TEXT AS $$
+  SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE SQL IMMUTABLE PARALLEL SAFE STRICT;
+-- +migrate StatementEnd
+
+CREATE INDEX idx_books_title_trgm ON books USING GIN (immutable_unaccent (title) gin_trgm_ops);
+
+CREATE INDEX idx_books_author_trgm ON books USING GIN (immutable_unaccent (author) gin_trgm_ops);
+
+CREATE INDEX idx_books_isbn_trgm ON books USING GIN (immutable_unaccent (isbn) gin_trgm_ops);
+
+CREATE INDEX idx_users_username_trgm ON users USING GIN (immutable_unaccent (username) gin_trgm_ops);
+
+CREATE INDEX idx_people_full_name_trgm ON people USING GIN (immutable_unaccent (full_name) gin_trgm_ops);
+
+CREATE INDEX idx_people_preferred_name_trgm ON people USING GIN (immutable_unaccent (preferred_name) gin_trgm_ops);
+
+-- +migrate Down
+DROP INDEX idx_people_preferred_name_trgm;
+
+DROP INDEX idx_people_full_name_trgm;
+
+DROP INDEX idx_users_username_trgm;
+
+DROP INDEX idx_books_isbn_trgm;
+
+DROP INDEX idx_books_author_trgm;
+
+DROP INDEX idx_books_title_trgm;
+
+DROP FUNCTION immutable_unaccent (TEXT);
\ No newline at end of file
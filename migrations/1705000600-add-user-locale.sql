@@ -0,0 +1,10 @@
+-- +migrate Up
+-- Empty when the user has no preference, so that it is negotiated per request
+ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE users ADD COLUMN time_zone TEXT NOT NULL DEFAULT '';
+
+-- +migrate Down
+ALTER TABLE users DROP COLUMN time_zone;
+
+ALTER TABLE users DROP COLUMN locale;
\ No newline at end of file
@@ -0,0 +1,22 @@
+-- +migrate Up
+-- Incremented on every update, and sent as the ETag of the record
+ALTER TABLE books ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE book_copies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE users ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE people ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE loans ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +migrate Down
+ALTER TABLE loans DROP COLUMN version;
+
+ALTER TABLE people DROP COLUMN version;
+
+ALTER TABLE users DROP COLUMN version;
+
+ALTER TABLE book_copies DROP COLUMN version;
+
+ALTER TABLE books DROP COLUMN version;
\ No newline at end of file
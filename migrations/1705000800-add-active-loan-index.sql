@@ -0,0 +1,57 @@
+-- +migrate Up
+-- Copies already on more than one active loan keep the earliest, and the others are
+-- returned so that the index can be created. An intervention is recorded for each.
+WITH
+  duplicate_loans AS (
+    SELECT
+      id
+    FROM
+      (
+        SELECT
+          id,
+          ROW_NUMBER() OVER (
+            PARTITION BY
+              book_copy_id
+            ORDER BY
+              borrow_date,
+              id
+          ) AS position
+        FROM
+          loans
+        WHERE
+          status = 'borrowed'
+          AND deleted_at IS NULL
+      ) active_loans
+    WHERE
+      position > 1
+  ),
+  returned_loans AS (
+    UPDATE loans
+    SET
+      status = 'returned',
+      return_date = CURRENT_DATE,
+      updated_at = CURRENT_TIMESTAMP,
+      version = version + 1
+    FROM
+      duplicate_loans
+    WHERE
+      loans.id = duplicate_loans.id
+    RETURNING
+      loans.id
+  )
+INSERT INTO
+  loan_histories (loan_id, action)
+SELECT
+  id,
+  'intervention'
+FROM
+  returned_loans;
+
+-- A copy can only be on one active loan, even if concurrent loans both see it available
+CREATE UNIQUE INDEX idx_loans_active_book_copy ON loans (book_copy_id)
+WHERE
+  status = 'borrowed'
+  AND deleted_at IS NULL;
+
+-- +migrate Down
+DROP INDEX idx_loans_active_book_copy;
\ No newline at end of file
@@ -0,0 +1,26 @@
+-- +migrate Up
+-- An entry for each entity changed by a request, or one without an entity if none was
+ALTER TABLE audit_logs
+ADD COLUMN action_type VARCHAR NOT NULL DEFAULT 'other',
+ADD COLUMN entity_type VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN entity_id BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN request_id VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN ip_address VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN changes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+
+CREATE INDEX idx_audit_logs_request_id ON audit_logs (request_id);
+
+-- +migrate Down
+DROP INDEX idx_audit_logs_request_id;
+
+DROP INDEX idx_audit_logs_entity;
+
+ALTER TABLE audit_logs
+DROP COLUMN changes,
+DROP COLUMN ip_address,
+DROP COLUMN request_id,
+DROP COLUMN entity_id,
+DROP COLUMN entity_type,
+DROP COLUMN action_type;
\ No newline at end of file
@@ -0,0 +1,48 @@
+-- +migrate Up
+-- Each entry holds the hash of the entry before it and of its own contents. Entries from
+-- before the chain have no hash.
+ALTER TABLE audit_logs
+ADD COLUMN previous_hash VARCHAR NOT NULL DEFAULT '',
+ADD COLUMN hash VARCHAR NOT NULL DEFAULT '';
+
+-- New entries must be chained, while those from before the chain are left as they are
+ALTER TABLE audit_logs
+ADD CONSTRAINT audit_logs_hash_present CHECK (hash <> '') NOT VALID;
+
+-- The chain cannot fork, even if entries are appended concurrently
+CREATE UNIQUE INDEX idx_audit_logs_previous_hash ON audit_logs (previous_hash)
+WHERE
+  hash <> '';
+
+-- Entries can only be appended, and not changed or removed
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION prevent_audit_log_changes () RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_logs is append-only, % is not allowed', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER audit_logs_append_only BEFORE
+UPDATE
+OR DELETE ON audit_logs FOR EACH ROW
+EXECUTE FUNCTION prevent_audit_log_changes ();
+
+CREATE TRIGGER audit_logs_no_truncate BEFORE TRUNCATE ON audit_logs FOR EACH STATEMENT
+EXECUTE FUNCTION prevent_audit_log_changes ();
+
+-- +migrate Down
+DROP TRIGGER audit_logs_no_truncate ON audit_logs;
+
+DROP TRIGGER audit_logs_append_only ON audit_logs;
+
+DROP FUNCTION prevent_audit_log_changes ();
+
+DROP INDEX idx_audit_logs_previous_hash;
+
+ALTER TABLE audit_logs
+DROP CONSTRAINT audit_logs_hash_present;
+
+ALTER TABLE audit_logs
+DROP COLUMN hash,
+DROP COLUMN previous_hash;
\ No newline at end of file
@@ -0,0 +1,83 @@
+-- +migrate Up
+-- Returns the ISBN-10 or ISBN-13 as a hyphenless ISBN-13, as pkg/isbn does, or NULL if it is not valid
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION normalize_isbn (isbn TEXT) RETURNS TEXT AS $$
+DECLARE
+  digits TEXT := upper(translate(btrim(isbn), '- ', ''));
+  total INTEGER := 0;
+  digit INTEGER;
+BEGIN
+  IF digits ~ '^[0-9]{9}[0-9X]$' THEN
+    FOR i IN 1..10 LOOP
+      digit := CASE WHEN substr(digits, i, 1) = 'X' THEN 10 ELSE substr(digits, i, 1)::INTEGER END;
+      total := total + (11 - i) * digit;
+    END LOOP;
+    IF total % 11 <> 0 THEN
+      RETURN NULL;
+    END IF;
+    digits := '978' || substr(digits, 1, 9);
+  ELSIF digits !~ '^97[89][0-9]{10}$' THEN
+    RETURN NULL;
+  END IF;
+
+  total := 0;
+  FOR i IN 1..12 LOOP
+    digit := substr(digits, i, 1)::INTEGER;
+    total := total + CASE WHEN i % 2 = 1 THEN digit ELSE 3 * digit END;
+  END LOOP;
+
+  IF length(digits) = 13 THEN
+    IF substr(digits, 13, 1)::INTEGER <> (10 - total % 10) % 10 THEN
+      RETURN NULL;
+    END IF;
+    RETURN digits;
+  END IF;
+
+  RETURN digits || ((10 - total % 10) % 10)::TEXT;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE PARALLEL SAFE STRICT;
+-- +migrate StatementEnd
+
+-- Books sharing an ISBN with an earlier book are marked until they are merged, as they
+-- cannot be told apart without a librarian
+ALTER TABLE books
+ADD COLUMN isbn_duplicate BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE books
+SET
+  isbn = COALESCE(normalize_isbn (isbn), isbn);
+
+UPDATE books
+SET
+  isbn_duplicate = TRUE
+FROM
+  (
+    SELECT
+      id,
+      ROW_NUMBER() OVER (
+        PARTITION BY
+          isbn
+        ORDER BY
+          id
+      ) AS position
+    FROM
+      books
+    WHERE
+      deleted_at IS NULL
+  ) AS ranked
+WHERE
+  books.id = ranked.id
+  AND ranked.position > 1;
+
+CREATE UNIQUE INDEX idx_books_isbn ON books (isbn)
+WHERE
+  deleted_at IS NULL
+  AND NOT isbn_duplicate;
+
+-- +migrate Down
+DROP INDEX idx_books_isbn;
+
+ALTER TABLE books
+DROP COLUMN isbn_duplicate;
+
+DROP FUNCTION normalize_isbn (TEXT);
\ No newline at end of file
@@ -0,0 +1,99 @@
+// Package circuitbreaker stops calling a failing dependency for a while.
+//
+// The breaker opens after a number of consecutive failures. While open, calls are rejected
+// right away. Once the cool down has passed a single trial call is let through: success
+// closes the breaker again, failure opens it for another cool down.
+package circuitbreaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type Breaker struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+	// CoolDown is how long the breaker stays open before a trial call.
+	CoolDown time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	now      func() time.Time
+}
+
+func New(failureThreshold int, coolDown time.Duration) *Breaker {
+	return &Breaker{
+		FailureThreshold: failureThreshold,
+		CoolDown:         coolDown,
+		now:              time.Now,
+	}
+}
+
+// State reports the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// allow reports whether a call may go ahead, moving an open breaker to half open after the cool down.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.CoolDown {
+			return false
+		}
+		b.state = StateHalfOpen
+		return true
+	case StateHalfOpen:
+		return false // A trial call is already in flight
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = StateClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.FailureThreshold {
+		b.state = StateOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Do calls fn unless the breaker is open, in which case ErrOpen is returned.
+func (b *Breaker) Do(fn func() error) error {
+	if !b.allow() {
+		return ErrOpen
+	}
+
+	err := fn()
+	b.record(err)
+
+	return err
+}
@@ -0,0 +1,122 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errFailed = errors.New("failed")
+
+// newTestBreaker returns a breaker with a clock that only moves when advanced.
+func newTestBreaker(failureThreshold int, coolDown time.Duration) (*Breaker, func(time.Duration)) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	b := New(failureThreshold, coolDown)
+	b.now = func() time.Time { return now }
+
+	return b, func(d time.Duration) { now = now.Add(d) }
+}
+
+func fail() error    { return errFailed }
+func succeed() error { return nil }
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if state := b.State(); state != StateClosed {
+			t.Fatalf("state after %d failures = %v, want closed", i, state)
+		}
+		if err := b.Do(fail); !errors.Is(err, errFailed) {
+			t.Fatalf("Do() error = %v, want the error of the call", err)
+		}
+	}
+
+	if state := b.State(); state != StateOpen {
+		t.Fatalf("state = %v, want open", state)
+	}
+
+	called := false
+	err := b.Do(func() error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, ErrOpen) || called {
+		t.Errorf("Do() error = %v, called = %t, want ErrOpen without calling", err, called)
+	}
+}
+
+func TestBreakerSuccessResetsFailures(t *testing.T) {
+	b, _ := newTestBreaker(3, time.Minute)
+
+	for _, fn := range []func() error{fail, fail, succeed, fail, fail} {
+		_ = b.Do(fn)
+	}
+
+	if state := b.State(); state != StateClosed {
+		t.Errorf("state = %v, want closed as the failures were not consecutive", state)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name  string
+		trial func() error
+		want  State
+	}{
+		{name: "trial succeeds", trial: succeed, want: StateClosed},
+		{name: "trial fails", trial: fail, want: StateOpen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, advance := newTestBreaker(1, time.Minute)
+			_ = b.Do(fail)
+
+			advance(time.Minute - time.Second)
+			if err := b.Do(succeed); !errors.Is(err, ErrOpen) {
+				t.Fatalf("Do() during the cool down error = %v, want ErrOpen", err)
+			}
+
+			advance(time.Second)
+			err := b.Do(func() error {
+				if state := b.State(); state != StateHalfOpen {
+					t.Errorf("state during the trial = %v, want half open", state)
+				}
+				// Only one trial call is let through at a time
+				if err := b.Do(succeed); !errors.Is(err, ErrOpen) {
+					t.Errorf("Do() during the trial error = %v, want ErrOpen", err)
+				}
+				return tt.trial()
+			})
+			if !errors.Is(err, tt.trial()) {
+				t.Errorf("Do() error = %v, want the error of the trial", err)
+			}
+
+			if state := b.State(); state != tt.want {
+				t.Errorf("state = %v, want %v", state, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakerReopensForAnotherCoolDown(t *testing.T) {
+	b, advance := newTestBreaker(1, time.Minute)
+	_ = b.Do(fail)
+
+	advance(time.Minute)
+	_ = b.Do(fail)
+
+	advance(time.Minute - time.Second)
+	if err := b.Do(succeed); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Do() error = %v, want ErrOpen until the new cool down has passed", err)
+	}
+
+	advance(time.Second)
+	if err := b.Do(succeed); err != nil {
+		t.Fatalf("Do() error = %v, want the trial to go ahead", err)
+	}
+	if state := b.State(); state != StateClosed {
+		t.Errorf("state = %v, want closed", state)
+	}
+}
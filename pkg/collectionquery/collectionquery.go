@@ -11,6 +11,13 @@ type Query struct {
 	sortBy  string
 	orderBy string
 	Queries map[string]string
+
+	// Set when the cursor parameter is sent, even if empty for the first page
+	cursorMode bool
+	cursor     *cursor
+	cursorErr  error
+	// Column of the applied sorter, compared to the cursor
+	sortColumn string
 }
 
 const (
@@ -18,16 +25,29 @@ const (
 	limitKey   = "limit"
 	sortByKey  = "sortBy"
 	orderByKey = "orderBy"
+	cursorKey  = "cursor"
 
 	ASC  Order = "asc"
 	DESC Order = "desc"
+
+	DefaultLimit = 10
+	MaxLimit     = 100
 )
 
 // Returns the collection query sent from the frontend.
 // Sets the default value if none is provided
+//
+// The limit is capped at MaxLimit. Pages are counted by offset unless the cursor
+// parameter is sent, see Paginate.
 func GetCollectionQueryFromParam(c *fiber.Ctx) *Query {
 	offset := c.QueryInt(offsetKey, 0)
-	limit := c.QueryInt(limitKey, 10)
+	limit := c.QueryInt(limitKey, DefaultLimit)
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
 
 	orderBy := c.Query(orderByKey, DESC)
 	if orderBy != ASC && orderBy != DESC {
@@ -36,11 +56,18 @@ func GetCollectionQueryFromParam(c *fiber.Ctx) *Query {
 	}
 	sortBy := c.Query(sortByKey, "")
 
-	return &Query{
+	q := &Query{
 		offset:  offset,
 		limit:   limit,
 		sortBy:  sortBy,
 		orderBy: orderBy,
 		Queries: c.Queries(),
 	}
+
+	if c.Context().QueryArgs().Has(cursorKey) {
+		q.cursorMode = true
+		q.setCursor(c.Query(cursorKey))
+	}
+
+	return q
 }
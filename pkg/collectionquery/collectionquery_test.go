@@ -0,0 +1,81 @@
+package collection
+
+import (
+	"errors"
+	"lms-backend/pkg/error/externalerrors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type testBook struct {
+	ID        uint
+	Title     string
+	PageCount int
+}
+
+// newQuery returns the collection query of a request with the raw query string.
+func newQuery(t *testing.T, rawQuery string) *Query {
+	t.Helper()
+
+	var q *Query
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		q = GetCollectionQueryFromParam(c)
+		return nil
+	})
+
+	res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/?"+rawQuery, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	return q
+}
+
+// newDryRunDB returns a database that builds SQL without running it.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// toSQL returns the SQL listing test books with the scope, or the error of building it.
+func toSQL(db *gorm.DB, scope func(*gorm.DB) (*gorm.DB, error)) (string, error) {
+	var scopeErr error
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		tx, scopeErr = scope(tx.Model(&testBook{}))
+		if scopeErr != nil {
+			return tx
+		}
+		tx = tx.Find(&[]testBook{})
+		scopeErr = tx.Error
+		return tx
+	})
+
+	return sql, scopeErr
+}
+
+// assertBadRequest fails the test unless err is a bad request shown to the client.
+func assertBadRequest(t *testing.T, err error) {
+	t.Helper()
+
+	var externalErr *externalerrors.Error
+	if !errors.As(err, &externalErr) || externalErr.Status != fiber.StatusBadRequest {
+		t.Errorf("error = %v, want a bad request", err)
+	}
+}
@@ -0,0 +1,140 @@
+package collection
+
+import (
+	"fmt"
+	"lms-backend/pkg/error/externalerrors"
+	"lms-backend/util/sliceutil"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Operator = string
+
+const (
+	Eq      Operator = "eq"
+	Ne      Operator = "ne"
+	Lt      Operator = "lt"
+	Lte     Operator = "lte"
+	Gt      Operator = "gt"
+	Gte     Operator = "gte"
+	Like    Operator = "like"
+	In      Operator = "in"
+	Between Operator = "between"
+	Null    Operator = "null"
+)
+
+type FieldType = string
+
+const (
+	StringField FieldType = "string"
+	IntField    FieldType = "int"
+	DateField   FieldType = "date"
+	EnumField   FieldType = "enum"
+	BoolField   FieldType = "bool"
+)
+
+const dateLayout = "2006-01-02"
+
+// Condition is a validated filter[<key>][<operator>]=<value>.
+//
+// Values holds one value parsed to the field type (string, int64, time.Time or bool),
+// one per comma-separated entry for In, and two for Between.
+// For Null it holds whether the column should be null.
+type Condition struct {
+	Key      string
+	Operator Operator
+	Values   []interface{}
+}
+
+// Value is the first value of the condition.
+func (c Condition) Value() interface{} {
+	return c.Values[0]
+}
+
+// StringValue is the first value of a string or enum condition.
+func (c Condition) StringValue() string {
+	s, _ := c.Value().(string)
+	return s
+}
+
+// BoolValue is the first value of a bool or null condition.
+func (c Condition) BoolValue() bool {
+	b, _ := c.Value().(bool)
+	return b
+}
+
+func parseCondition(key string, operator Operator, value string, filter Filter) (*Condition, error) {
+	if operator == "" {
+		operator = filter.Operators[0]
+	}
+	if !sliceutil.Contains(filter.Operators, operator) {
+		return nil, externalerrors.BadRequest(fmt.Sprintf(
+			"%s cannot be filtered with %s, only with %s",
+			key, operator, strings.Join(filter.Operators, ", "),
+		))
+	}
+
+	var rawValues []string
+	switch operator {
+	case Null:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("%s[%s] must be true or false", key, operator))
+		}
+		return &Condition{Key: key, Operator: operator, Values: []interface{}{isNull}}, nil
+	case In:
+		rawValues = sliceutil.Map(strings.Split(value, ","), strings.TrimSpace)
+	case Between:
+		rawValues = sliceutil.Map(strings.Split(value, ","), strings.TrimSpace)
+		if len(rawValues) != 2 {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("%s[%s] must be two comma-separated values", key, operator))
+		}
+	default:
+		rawValues = []string{value}
+	}
+
+	values := make([]interface{}, 0, len(rawValues))
+	for _, raw := range rawValues {
+		v, err := parseValue(raw, filter)
+		if err != nil {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("%s: %s", key, err))
+		}
+		values = append(values, v)
+	}
+
+	return &Condition{Key: key, Operator: operator, Values: values}, nil
+}
+
+func parseValue(value string, filter Filter) (interface{}, error) {
+	switch filter.Type {
+	case IntField:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", value)
+		}
+		return i, nil
+	case DateField:
+		if t, err := time.Parse(dateLayout, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date, use YYYY-MM-DD or RFC 3339", value)
+		}
+		return t, nil
+	case BoolField:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not true or false", value)
+		}
+		return b, nil
+	case EnumField:
+		if !sliceutil.Contains(filter.Values, value) {
+			return nil, fmt.Errorf("%q is not one of %s", value, strings.Join(filter.Values, ", "))
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
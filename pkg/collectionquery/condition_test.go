@@ -0,0 +1,131 @@
+package collection
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCondition(t *testing.T) {
+	status := EnumFilter("status", []string{"available", "on_loan"})
+
+	tests := []struct {
+		name     string
+		operator Operator
+		value    string
+		filter   Filter
+		want     Condition
+	}{
+		{
+			name:   "default operator",
+			value:  "dune",
+			filter: StringFilter("title"),
+			want:   Condition{Key: "key", Operator: Like, Values: []interface{}{"dune"}},
+		},
+		{
+			name:     "int",
+			operator: Gte,
+			value:    "-3",
+			filter:   IntFilter("page_count"),
+			want:     Condition{Key: "key", Operator: Gte, Values: []interface{}{int64(-3)}},
+		},
+		{
+			name:   "in",
+			value:  "1, 2,3",
+			filter: IntFilter("id"),
+			want:   Condition{Key: "key", Operator: In, Values: []interface{}{int64(1), int64(2), int64(3)}},
+		},
+		{
+			name:     "between dates",
+			operator: Between,
+			value:    "2024-01-01,2024-01-31T12:00:00+08:00",
+			filter:   DateFilter("due_date"),
+			want: Condition{Key: "key", Operator: Between, Values: []interface{}{
+				time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, time.January, 31, 12, 0, 0, 0, time.FixedZone("", 8*60*60)),
+			}},
+		},
+		{
+			name:   "bool",
+			value:  "true",
+			filter: BoolFilter("available"),
+			want:   Condition{Key: "key", Operator: Eq, Values: []interface{}{true}},
+		},
+		{
+			name:     "null",
+			operator: Null,
+			value:    "false",
+			filter:   IntFilter("branch_id"),
+			want:     Condition{Key: "key", Operator: Null, Values: []interface{}{false}},
+		},
+		{
+			name:   "enum",
+			value:  "available,on_loan",
+			filter: status,
+			want:   Condition{Key: "key", Operator: In, Values: []interface{}{"available", "on_loan"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCondition("key", tt.operator, tt.value, tt.filter)
+			if err != nil {
+				t.Fatalf("parseCondition() error = %v", err)
+			}
+			if got.Key != tt.want.Key || got.Operator != tt.want.Operator || len(got.Values) != len(tt.want.Values) {
+				t.Fatalf("parseCondition() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got.Values {
+				if !equalValue(got.Values[i], tt.want.Values[i]) {
+					t.Errorf("value %d = %#v, want %#v", i, got.Values[i], tt.want.Values[i])
+				}
+			}
+		})
+	}
+}
+
+// equalValue compares condition values, with times compared as instants.
+func equalValue(got, want interface{}) bool {
+	if gotTime, ok := got.(time.Time); ok {
+		wantTime, ok := want.(time.Time)
+		return ok && gotTime.Equal(wantTime)
+	}
+
+	return reflect.DeepEqual(got, want)
+}
+
+func TestParseConditionBadRequest(t *testing.T) {
+	status := EnumFilter("status", []string{"available", "on_loan"})
+
+	tests := []struct {
+		name     string
+		operator Operator
+		value    string
+		filter   Filter
+	}{
+		{name: "operator not allowed", operator: Gt, value: "dune", filter: StringFilter("title")},
+		{name: "unknown operator", operator: "regex", value: "dune", filter: StringFilter("title")},
+		{name: "operator narrowed", operator: Like, value: "en", filter: StringFilter("language").WithOperators(In, Eq)},
+		{name: "int not a number", value: "ten", filter: IntFilter("page_count")},
+		{name: "int decimal", operator: Eq, value: "1.5", filter: IntFilter("page_count")},
+		{name: "int in with one bad value", value: "1,x,3", filter: IntFilter("id")},
+		{name: "int between with one value", operator: Between, value: "1", filter: IntFilter("page_count")},
+		{name: "int between with three values", operator: Between, value: "1,2,3", filter: IntFilter("page_count")},
+		{name: "date not a date", value: "yesterday", filter: DateFilter("due_date")},
+		{name: "date wrong layout", operator: Lt, value: "31/01/2024", filter: DateFilter("due_date")},
+		{name: "date between with bad end", operator: Between, value: "2024-01-01,2024-02-30", filter: DateFilter("due_date")},
+		{name: "bool not a bool", value: "yes", filter: BoolFilter("available")},
+		{name: "null not a bool", operator: Null, value: "maybe", filter: IntFilter("branch_id")},
+		{name: "enum not a value", value: "lost", filter: status},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCondition("key", tt.operator, tt.value, tt.filter)
+			if got != nil {
+				t.Errorf("parseCondition() = %+v, want nil", got)
+			}
+			assertBadRequest(t, err)
+		})
+	}
+}
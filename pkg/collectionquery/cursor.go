@@ -0,0 +1,139 @@
+package collection
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"lms-backend/pkg/error/externalerrors"
+
+	"gorm.io/gorm"
+)
+
+// cursor marks the row a page starts after, or ends before.
+//
+// It keeps the sort of the page it came from, so that following pages are
+// sorted the same way. Value is the sort column of the row as text, nil if NULL.
+type cursor struct {
+	SortBy  string  `json:"s,omitempty"`
+	OrderBy Order   `json:"o"`
+	Value   *string `json:"v,omitempty"`
+	ID      uint    `json:"i"`
+	Before  bool    `json:"b,omitempty"`
+}
+
+func (q *Query) setCursor(value string) {
+	if value == "" {
+		return
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		q.cursorErr = externalerrors.BadRequest("cursor is not valid")
+		return
+	}
+
+	var cur cursor
+	if err := json.Unmarshal(data, &cur); err != nil || (cur.OrderBy != ASC && cur.OrderBy != DESC) {
+		q.cursorErr = externalerrors.BadRequest("cursor is not valid")
+		return
+	}
+
+	q.cursor = &cur
+	q.sortBy = cur.SortBy
+	q.orderBy = cur.OrderBy
+}
+
+func (q *Query) encodeCursor(value *string, id uint, before bool) string {
+	//nolint:errchkjson // cursor has no types that fail to marshal
+	data, _ := json.Marshal(cursor{
+		SortBy:  q.sortBy,
+		OrderBy: q.orderBy,
+		Value:   value,
+		ID:      id,
+		Before:  before,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// IsCursorMode reports whether pages are counted by cursor rather than offset.
+func (q *Query) IsCursorMode() bool {
+	return q.cursorMode
+}
+
+// beyondCursor keeps the rows past the cursor in the given direction of the sort.
+//
+// NULLs are sorted as the largest values, as PostgreSQL does by default.
+func (q *Query) beyondCursor(cur *cursor, larger bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		compare := "<"
+		if larger {
+			compare = ">"
+		}
+
+		if q.sortColumn == "" {
+			return db.Where("? "+compare+" ?", primaryKey, cur.ID)
+		}
+
+		column := q.sortColumn
+		switch {
+		case cur.Value == nil && larger:
+			return db.Where("("+column+" IS NULL AND ? > ?)", primaryKey, cur.ID)
+		case cur.Value == nil:
+			return db.Where("("+column+" IS NOT NULL OR ? < ?)", primaryKey, cur.ID)
+		case larger:
+			return db.Where(
+				"("+column+" > ? OR ("+column+" = ? AND ? > ?) OR "+column+" IS NULL)",
+				*cur.Value, *cur.Value, primaryKey, cur.ID,
+			)
+		default:
+			return db.Where(
+				"("+column+" < ? OR ("+column+" = ? AND ? < ?))",
+				*cur.Value, *cur.Value, primaryKey, cur.ID,
+			)
+		}
+	}
+}
+
+// Cursors returns the cursors of the pages after and before the listed rows, given
+// their IDs in order. They are empty in offset mode and at either end of the collection.
+//
+// db should be the sorted query passed to Paginate.
+func (q *Query) Cursors(db *gorm.DB, ids []uint) (next string, prev string, err error) {
+	if !q.cursorMode || len(ids) == 0 {
+		return "", "", nil
+	}
+
+	first, last := ids[0], ids[len(ids)-1]
+
+	values := map[uint]*string{}
+	if q.sortColumn != "" {
+		var rows []struct {
+			ID    uint
+			Value *string
+		}
+
+		result := db.Session(&gorm.Session{}).
+			Select("? AS id, ("+q.sortColumn+")::TEXT AS value", primaryKey).
+			Where("? IN ?", primaryKey, []uint{first, last}).
+			Scan(&rows)
+		if result.Error != nil {
+			return "", "", result.Error
+		}
+
+		for _, row := range rows {
+			values[row.ID] = row.Value
+		}
+	}
+
+	full := len(ids) == q.limit
+	before := q.cursor != nil && q.cursor.Before
+
+	if full || before {
+		next = q.encodeCursor(values[last], last, false)
+	}
+	if (full && before) || (q.cursor != nil && !before) {
+		prev = q.encodeCursor(values[first], first, true)
+	}
+
+	return next, prev, nil
+}
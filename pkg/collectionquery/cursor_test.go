@@ -0,0 +1,340 @@
+package collection
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func testSorters() SortMap {
+	return SortMap{
+		"title":      SortBy("title"),
+		"page_count": SortBy("page_count"),
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+// cursorFor encodes a cursor as a previous page would have.
+func cursorFor(cur cursor) string {
+	q := &Query{sortBy: cur.SortBy, orderBy: cur.OrderBy}
+	return q.encodeCursor(cur.Value, cur.ID, cur.Before)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		cur  cursor
+	}{
+		{name: "sorted ascending", cur: cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 5}},
+		{name: "sorted descending before", cur: cursor{SortBy: "page_count", OrderBy: DESC, Value: stringPtr("412"), ID: 9, Before: true}},
+		{name: "null value", cur: cursor{SortBy: "title", OrderBy: ASC, ID: 7}},
+		{name: "empty value", cur: cursor{SortBy: "title", OrderBy: DESC, Value: stringPtr(""), ID: 3}},
+		{name: "not sorted", cur: cursor{OrderBy: DESC, ID: 12}},
+		{name: "value with symbols", cur: cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr(`Ra's "al" Ghul & co, é`), ID: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := cursorFor(tt.cur)
+			if strings.ContainsAny(encoded, "+/=") {
+				t.Errorf("cursor %s is not url safe", encoded)
+			}
+
+			// The sort of the cursor replaces the sort of the request
+			q := newQuery(t, url.Values{"cursor": {encoded}, "sortBy": {"other"}, "orderBy": {"asc"}}.Encode())
+			if q.cursorErr != nil {
+				t.Fatalf("cursor error = %v", q.cursorErr)
+			}
+			if !reflect.DeepEqual(*q.cursor, tt.cur) {
+				t.Errorf("cursor = %+v, want %+v", *q.cursor, tt.cur)
+			}
+			if q.sortBy != tt.cur.SortBy || q.orderBy != tt.cur.OrderBy {
+				t.Errorf("sort = %s %s, want %s %s", q.sortBy, q.orderBy, tt.cur.SortBy, tt.cur.OrderBy)
+			}
+		})
+	}
+}
+
+func TestInvalidCursor(t *testing.T) {
+	valid := cursorFor(cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 5})
+	encode := func(s string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(s))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte(`{"o":"asc","i":5}`)) + "="},
+		{name: "truncated", cursor: valid[:len(valid)-4]},
+		{name: "tampered character", cursor: "X" + valid[1:]},
+		{name: "not json", cursor: encode("title:Dune:5")},
+		{name: "tampered order", cursor: encode(`{"s":"title","o":"sideways","v":"Dune","i":5}`)},
+		{name: "missing order", cursor: encode(`{"s":"title","v":"Dune","i":5}`)},
+		{name: "negative id", cursor: encode(`{"o":"asc","i":-1}`)},
+		{name: "id of the wrong type", cursor: encode(`{"o":"asc","i":"5 OR 1=1"}`)},
+		{name: "value of the wrong type", cursor: encode(`{"s":"title","o":"asc","v":5,"i":5}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, url.Values{"cursor": {tt.cursor}}.Encode())
+			_, err := toSQL(newDryRunDB(t), func(db *gorm.DB) (*gorm.DB, error) {
+				return q.Paginate(q.Sort(db, testSorters())), nil
+			})
+			assertBadRequest(t, err)
+		})
+	}
+}
+
+func TestPaginateBeyondCursor(t *testing.T) {
+	tests := []struct {
+		name string
+		cur  cursor
+		want string
+	}{
+		{
+			name: "ascending",
+			cur:  cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 5},
+			want: `WHERE (title > 'Dune' OR (title = 'Dune' AND "test_books"."id" > 5) OR title IS NULL) ` +
+				`ORDER BY title asc,"test_books"."id" LIMIT 10`,
+		},
+		{
+			name: "descending",
+			cur:  cursor{SortBy: "title", OrderBy: DESC, Value: stringPtr("Dune"), ID: 5},
+			want: `WHERE (title < 'Dune' OR (title = 'Dune' AND "test_books"."id" < 5)) ` +
+				`ORDER BY title desc,"test_books"."id" DESC LIMIT 10`,
+		},
+		{
+			name: "ascending from null",
+			cur:  cursor{SortBy: "title", OrderBy: ASC, ID: 5},
+			want: `WHERE (title IS NULL AND "test_books"."id" > 5) ORDER BY title asc,"test_books"."id" LIMIT 10`,
+		},
+		{
+			name: "descending from null",
+			cur:  cursor{SortBy: "title", OrderBy: DESC, ID: 5},
+			want: `WHERE (title IS NOT NULL OR "test_books"."id" < 5) ORDER BY title desc,"test_books"."id" DESC LIMIT 10`,
+		},
+		{
+			name: "by id only",
+			cur:  cursor{OrderBy: ASC, ID: 5},
+			want: `WHERE "test_books"."id" > 5 ORDER BY "test_books"."id" LIMIT 10`,
+		},
+		{
+			name: "unknown sort",
+			cur:  cursor{SortBy: "isbn", OrderBy: DESC, Value: stringPtr("978"), ID: 5},
+			want: `WHERE "test_books"."id" < 5 ORDER BY "test_books"."id" DESC LIMIT 10`,
+		},
+		{
+			name: "before, ascending",
+			cur:  cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 5, Before: true},
+			want: `WHERE "test_books"."id" IN (SELECT "test_books"."id" FROM "test_books" ` +
+				`WHERE (title < 'Dune' OR (title = 'Dune' AND "test_books"."id" < 5)) ` +
+				`ORDER BY title desc,"test_books"."id" DESC,title asc,"test_books"."id" LIMIT 10) ` +
+				`ORDER BY title asc,"test_books"."id"`,
+		},
+		{
+			name: "before, descending",
+			cur:  cursor{SortBy: "page_count", OrderBy: DESC, Value: stringPtr("300"), ID: 5, Before: true},
+			want: `WHERE "test_books"."id" IN (SELECT "test_books"."id" FROM "test_books" ` +
+				`WHERE (page_count > '300' OR (page_count = '300' AND "test_books"."id" > 5) OR page_count IS NULL) ` +
+				`ORDER BY page_count asc,"test_books"."id",page_count desc,"test_books"."id" DESC LIMIT 10) ` +
+				`ORDER BY page_count desc,"test_books"."id" DESC`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, url.Values{"cursor": {cursorFor(tt.cur)}}.Encode())
+			sql, err := toSQL(newDryRunDB(t), func(db *gorm.DB) (*gorm.DB, error) {
+				return q.Paginate(q.Sort(db, testSorters())), nil
+			})
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if !strings.HasSuffix(sql, tt.want) {
+				t.Errorf("SQL = %s\nwant it to end with %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginateFirstPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{name: "cursor mode", query: "cursor=&sortBy=title&orderBy=asc&limit=2", want: `ORDER BY title asc,"test_books"."id" LIMIT 2`},
+		{name: "cursor mode, not sorted", query: "cursor=", want: `ORDER BY "test_books"."id" DESC LIMIT 10`},
+		{name: "offset mode", query: "sortBy=title&offset=20&limit=5", want: `ORDER BY title desc LIMIT 5 OFFSET 20`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, tt.query)
+			sql, err := toSQL(newDryRunDB(t), func(db *gorm.DB) (*gorm.DB, error) {
+				return q.Paginate(q.Sort(db, testSorters())), nil
+			})
+			if err != nil {
+				t.Fatalf("Paginate() error = %v", err)
+			}
+			if !strings.HasSuffix(sql, tt.want) {
+				t.Errorf("SQL = %s, want it to end with %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+// sortValues is a database that returns the rows for any query, as read by Cursors.
+type sortValues [][]driver.Value
+
+func (v sortValues) Connect(context.Context) (driver.Conn, error) { return sortValuesConn{v}, nil }
+func (v sortValues) Driver() driver.Driver                        { return nil }
+
+type sortValuesConn struct {
+	rows [][]driver.Value
+}
+
+func (c sortValuesConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c sortValuesConn) Close() error                        { return nil }
+func (c sortValuesConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c sortValuesConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &sortValuesRows{rows: c.rows}, nil
+}
+
+type sortValuesRows struct {
+	rows [][]driver.Value
+}
+
+func (r *sortValuesRows) Columns() []string { return []string{"id", "value"} }
+func (r *sortValuesRows) Close() error      { return nil }
+
+func (r *sortValuesRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestCursors(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(sortValues{
+		{int64(3), "Dune"},
+		{int64(8), nil},
+	})}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after := cursorFor(cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Children of Dune"), ID: 1})
+	before := cursorFor(cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Heretics"), ID: 9, Before: true})
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		ids      []uint
+		wantNext *cursor
+		wantPrev *cursor
+	}{
+		{
+			name:     "first page",
+			query:    url.Values{"cursor": {""}, "sortBy": {"title"}, "orderBy": {"asc"}, "limit": {"2"}},
+			ids:      []uint{3, 8},
+			wantNext: &cursor{SortBy: "title", OrderBy: ASC, ID: 8},
+		},
+		{
+			name:  "only page",
+			query: url.Values{"cursor": {""}, "sortBy": {"title"}, "orderBy": {"asc"}, "limit": {"3"}},
+			ids:   []uint{3, 8},
+		},
+		{
+			name:     "page after a cursor",
+			query:    url.Values{"cursor": {after}, "limit": {"2"}},
+			ids:      []uint{3, 8},
+			wantNext: &cursor{SortBy: "title", OrderBy: ASC, ID: 8},
+			wantPrev: &cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 3, Before: true},
+		},
+		{
+			name:     "last page after a cursor",
+			query:    url.Values{"cursor": {after}, "limit": {"5"}},
+			ids:      []uint{3, 8},
+			wantPrev: &cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 3, Before: true},
+		},
+		{
+			name:     "page before a cursor",
+			query:    url.Values{"cursor": {before}, "limit": {"2"}},
+			ids:      []uint{3, 8},
+			wantNext: &cursor{SortBy: "title", OrderBy: ASC, ID: 8},
+			wantPrev: &cursor{SortBy: "title", OrderBy: ASC, Value: stringPtr("Dune"), ID: 3, Before: true},
+		},
+		{
+			name:     "first page before a cursor",
+			query:    url.Values{"cursor": {before}, "limit": {"5"}},
+			ids:      []uint{3, 8},
+			wantNext: &cursor{SortBy: "title", OrderBy: ASC, ID: 8},
+		},
+		{
+			name:  "empty page",
+			query: url.Values{"cursor": {after}, "limit": {"2"}},
+			ids:   []uint{},
+		},
+		{
+			name:  "offset mode",
+			query: url.Values{"sortBy": {"title"}, "limit": {"2"}},
+			ids:   []uint{3, 8},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, tt.query.Encode())
+			sorted := q.Sort(db.Model(&testBook{}), testSorters())
+
+			next, prev, err := q.Cursors(sorted, tt.ids)
+			if err != nil {
+				t.Fatalf("Cursors() error = %v", err)
+			}
+
+			assertCursor(t, "next", next, tt.wantNext)
+			assertCursor(t, "prev", prev, tt.wantPrev)
+		})
+	}
+}
+
+func assertCursor(t *testing.T, name, encoded string, want *cursor) {
+	t.Helper()
+
+	if want == nil {
+		if encoded != "" {
+			t.Errorf("%s cursor = %s, want none", name, encoded)
+		}
+		return
+	}
+
+	q := &Query{}
+	q.setCursor(encoded)
+	if q.cursor == nil {
+		t.Fatalf("%s cursor %q does not decode: %v", name, encoded, q.cursorErr)
+	}
+	if !reflect.DeepEqual(*q.cursor, *want) {
+		t.Errorf("%s cursor = %+v, want %+v", name, *q.cursor, *want)
+	}
+}
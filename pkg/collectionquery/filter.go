@@ -3,42 +3,76 @@ package collection
 import (
 	"fmt"
 	"lms-backend/internal/orm"
-	"lms-backend/util/sliceutil"
+	"lms-backend/pkg/error/externalerrors"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
-var (
-	FilterRegex      = regexp.MustCompile(`filter\[[^\]]*\]`)
-	FilterValueRegex = regexp.MustCompile(`filter\[([^\]]*)\]`)
-)
+const filterPrefix = "filter["
+
+// filter[<key>] or filter[<key>][<operator>]
+var FilterKeyRegex = regexp.MustCompile(`^filter\[([^\]]+)\](?:\[([^\]]*)\])?$`)
 
-type Filter func(string) func(*gorm.DB) *gorm.DB
+// Apply returns the scope that filters the query by the condition.
+type Apply func(Condition) func(*gorm.DB) *gorm.DB
 
-// FilterMap is a map of filter keys to filter functions
+// Filter declares how a filter key is typed and applied.
+//
+// Operators are the operators accepted for the key. The first one is used when the
+// request does not name an operator, e.g. filter[title]=x.
+// Values are the accepted values of an EnumField.
+type Filter struct {
+	Type      FieldType
+	Operators []Operator
+	Values    []string
+	Apply     Apply
+}
+
+// FilterMap is a map of filter keys to filters
 //
 // Key should the the filter key (i.e. "filter[<key>]")
 type FilterMap map[string]Filter
 
+// NewFilter declares a filter with its own way of applying the condition, for filters
+// that cannot be expressed on a single column. The first operator is the default.
+func NewFilter(fieldType FieldType, apply Apply, operators ...Operator) Filter {
+	return Filter{
+		Type:      fieldType,
+		Operators: operators,
+		Apply:     apply,
+	}
+}
+
+// WithOperators limits the filter to the given operators, the first being the default.
+func (f Filter) WithOperators(operators ...Operator) Filter {
+	f.Operators = operators
+	return f
+}
+
 // Filters the database query based on the collection query
 //
-// The filters map should be a map of filter keys (i.e. "filter[<key>]") to
-// filter functions. The filter functions should take a string as an argument
-// and return a function that takes a *gorm.DB and returns a *gorm.DB.
-
+// Each filter[<key>][<operator>]=<value> is validated against the filters map and applied
+// with bound parameters. The operator may be left out to use the key's default,
+// i.e. filter[<key>]=<value>. Filters with an empty value are ignored.
+//
 // Example:
 //
-//	filters := map[string]Filter{
+//	filters := FilterMap{
 //
-//		"age": (x) -> db.Where("age = ?", x),
+//		"title": StringFilter("title"),
 //
-//		"name": (x) -> db.Where("name ILIKE ?", x),
+//		"due_date": DateFilter("loans.due_date"),
 //
 //	}
 //
+//	?filter[title]=dune&filter[due_date][lt]=2024-01-31
+//
+// A bad request error is returned for unknown keys, operators not accepted by the key and
+// values not of the key's type.
+//
 // joinQueries is a list of join queries to be applied to the database query via the db.Joins() method.
 //
 // Example:
@@ -48,167 +82,143 @@ type FilterMap map[string]Filter
 //		"JOIN users ON users.id = posts.user_id",
 //
 //	}
-func (q *Query) Filter(db *gorm.DB, filters FilterMap, joinQueries ...string) *gorm.DB {
+func (q *Query) Filter(db *gorm.DB, filters FilterMap, joinQueries ...string) (*gorm.DB, error) {
 	db = db.Scopes(orm.JoinAllIfNotJoined(joinQueries))
-	// Key should be the column name and value should be the value to filter by
-	for key, value := range q.Queries {
-		// Test if the key is a filter key (filter[...])
-		if !FilterRegex.MatchString(key) {
-			continue
-		}
 
-		matches := FilterValueRegex.FindStringSubmatch(key)
+	// Apply the filters in a fixed order so that the same query always gives the same SQL
+	keys := make([]string, 0, len(q.Queries))
+	for key := range q.Queries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 
-		// If there are no matches or the first match is empty, skip this key
-		if len(matches) < 1 || matches[1] == "" {
+	for _, key := range keys {
+		if !strings.HasPrefix(key, filterPrefix) {
 			continue
 		}
 
-		// The first match is the whole string, the second match is the filter key
-		filterKey := matches[1]
-		if filter, ok := filters[filterKey]; ok {
-			db = db.Scopes(filter(value))
+		matches := FilterKeyRegex.FindStringSubmatch(key)
+		if matches == nil {
+			return nil, externalerrors.BadRequest(fmt.Sprintf(
+				"%s is not a valid filter, use filter[<field>] or filter[<field>][<operator>]", key,
+			))
 		}
-	}
-
-	return db
-}
 
-func genericFilter(columnName, operator string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("%s %s ?", columnName, operator),
-				value,
-			)
+		filterKey, operator := matches[1], matches[2]
+		filter, ok := filters[filterKey]
+		if !ok {
+			return nil, externalerrors.BadRequest(fmt.Sprintf("%s is not a filterable field", filterKey))
 		}
-	}
-}
 
-func StringLikeFilter(columnName string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("%s ILIKE ?", columnName),
-				fmt.Sprintf("%%%s%%", value),
-			)
+		value := q.Queries[key]
+		if value == "" {
+			continue
 		}
-	}
-}
-
-func StringEqualFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, "=", joinQueries...)
-}
 
-// When the value is a comma-separated list of values, this filter will return
-// a function that will filter the database query by the column name being equal
-// to any of the values in the list.
-//
-// One column, multiple values
-func MultipleStringEqualFilter(columnName string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		// Split the value by commas and surround each value with single quotes
-		values := sliceutil.Map(strings.Split(value, ","), func(s string) string {
-			return fmt.Sprintf("'%s'", s)
-		})
-
-		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("%s IN ?", columnName),
-				fmt.Sprintf("(%s)", strings.Join(values, ",")),
-			)
+		condition, err := parseCondition(filterKey, operator, value, filter)
+		if err != nil {
+			return nil, err
 		}
-	}
-}
-
-// When the value is a comma-separated list of values, this filter will return
-// a function that will filter the database query by the column name being similar
-// to any of the values in the list.
-//
-// One column, multiple values
-func MultipleStringLikeFilter(columnName string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		// Split the value by commas and convert them to ILIKE conditions (i.e. "column ILIKE '%value%'")
-		conditions := sliceutil.Map(strings.Split(value, ","), func(s string) string {
-			return fmt.Sprintf("%s ILIKE %%%s%%", columnName, s)
-		})
 
-		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("(%s)", strings.Join(conditions, " OR ")),
-			)
-		}
+		db = db.Scopes(filter.Apply(*condition))
 	}
-}
 
-// When the value is a single value, this filter will return
-// a function that will filter the database query if the column name is similar to the value.
-//
-// Multiple columns, one value
-func MultipleColumnStringLikeFilter(columnNames []string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		// Split the value by commas and convert them to ILIKE conditions (i.e. "column ILIKE '%value%'")
-		conditions := sliceutil.Map(columnNames, func(s string) string {
-			return fmt.Sprintf("%s ILIKE '%%%s%%'", s, value)
-		})
+	return db, nil
+}
 
+// columnFilter compares the column to the condition with the given operators, the first
+// being the default.
+func columnFilter(fieldType FieldType, columnName string, operators []Operator, joinQueries ...string) Filter {
+	return NewFilter(fieldType, func(c Condition) func(*gorm.DB) *gorm.DB {
 		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("(%s)", strings.Join(conditions, " OR ")),
-			)
+			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries), ColumnCondition(columnName, c))
 		}
-	}
+	}, operators...)
 }
 
-func GenericBoolFilter(value bool, columnName string, operator string) func(*gorm.DB) *gorm.DB {
+// ColumnCondition filters the query by comparing the column to the condition.
+//
+// columnName is trusted SQL. The condition values are always bound as parameters.
+func ColumnCondition(columnName string, c Condition) func(*gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		return db.Where(
-			fmt.Sprintf("%s %s ?", columnName, operator),
-			value,
-		)
+		switch c.Operator {
+		case Eq:
+			return db.Where(columnName+" = ?", c.Value())
+		case Ne:
+			return db.Where(columnName+" <> ?", c.Value())
+		case Lt:
+			return db.Where(columnName+" < ?", c.Value())
+		case Lte:
+			return db.Where(columnName+" <= ?", c.Value())
+		case Gt:
+			return db.Where(columnName+" > ?", c.Value())
+		case Gte:
+			return db.Where(columnName+" >= ?", c.Value())
+		case Like:
+			return db.Where(columnName+" ILIKE ?", "%"+orm.EscapeLike(c.StringValue())+"%")
+		case In:
+			return db.Where(columnName+" IN ?", c.Values)
+		case Between:
+			return db.Where(columnName+" BETWEEN ? AND ?", c.Values[0], c.Values[1])
+		case Null:
+			if c.BoolValue() {
+				return db.Where(columnName + " IS NULL")
+			}
+			return db.Where(columnName + " IS NOT NULL")
+		default:
+			return db.Where("1 = 0") // Return a condition that will never be true
+		}
 	}
 }
 
-func IntEqualFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, "=", joinQueries...)
+// StringFilter filters by the text column, by default if it contains the value ignoring case.
+func StringFilter(columnName string, joinQueries ...string) Filter {
+	return columnFilter(StringField, columnName, []Operator{Like, Eq, Ne, In, Null}, joinQueries...)
 }
 
-func IntGreaterThanOrEqualFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, ">=", joinQueries...)
+// IntFilter filters by the integer column, by default if it is any of the comma-separated values.
+func IntFilter(columnName string, joinQueries ...string) Filter {
+	return columnFilter(IntField, columnName, []Operator{In, Eq, Ne, Lt, Lte, Gt, Gte, Between, Null}, joinQueries...)
 }
 
-func IntLessThanOrEqualFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, "<=", joinQueries...)
+// DateFilter filters by the date of the timestamp column, by default if it is on the given date.
+//
+// Values are dates in the form YYYY-MM-DD.
+func DateFilter(columnName string, joinQueries ...string) Filter {
+	return columnFilter(DateField, fmt.Sprintf("(%s)::DATE", columnName),
+		[]Operator{Eq, Ne, Lt, Lte, Gt, Gte, Between, Null}, joinQueries...)
 }
 
-func IntGreaterThanFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, ">", joinQueries...)
+// EnumFilter filters by the column, by default if it is any of the comma-separated values.
+// Values other than the given ones are rejected.
+func EnumFilter(columnName string, values []string, joinQueries ...string) Filter {
+	filter := columnFilter(EnumField, columnName, []Operator{In, Eq, Ne, Null}, joinQueries...)
+	filter.Values = values
+	return filter
 }
 
-func IntLessThanFilter(columnName string, joinQueries ...string) Filter {
-	return genericFilter(columnName, "<", joinQueries...)
+// BoolFilter filters by the boolean column.
+func BoolFilter(columnName string, joinQueries ...string) Filter {
+	return columnFilter(BoolField, columnName, []Operator{Eq, Null}, joinQueries...)
 }
 
-// When the value is a comma-separated list of values, this filter will return
-// a function that will filter the database query by the column name equal
-// to any of the values in the list.
-//
-// One column, multiple values
-func MultipleIntEqualFilter(columnName string, joinQueries ...string) Filter {
-	return func(value string) func(db *gorm.DB) *gorm.DB {
-		// Split the value by commas and convert them to ILIKE conditions (i.e. "column ILIKE '%value%'")
-		conditions := sliceutil.Map(strings.Split(value, ","), func(s string) string {
-			val, err := strconv.Atoi(s)
-			if err != nil {
-				return "1 = 0" // Return a condition that will never be true
-			}
-			return fmt.Sprintf("%s = %d", columnName, val)
-		})
+// AnyColumnLikeFilter filters the query if any of the columns contains the value ignoring case.
+//
+// Multiple columns, one value
+func AnyColumnLikeFilter(columnNames []string, joinQueries ...string) Filter {
+	return NewFilter(StringField, func(c Condition) func(*gorm.DB) *gorm.DB {
+		like := "%" + orm.EscapeLike(c.StringValue()) + "%"
+
+		conditions := make([]string, 0, len(columnNames))
+		values := make([]interface{}, 0, len(columnNames))
+		for _, columnName := range columnNames {
+			conditions = append(conditions, columnName+" ILIKE ?")
+			values = append(values, like)
+		}
 
 		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).Where(
-				fmt.Sprintf("(%s)", strings.Join(conditions, " OR ")),
-			)
+			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).
+				Where("("+strings.Join(conditions, " OR ")+")", values...)
 		}
-	}
+	}, Like)
 }
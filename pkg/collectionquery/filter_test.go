@@ -0,0 +1,105 @@
+package collection
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func testFilters() FilterMap {
+	return FilterMap{
+		"title":      StringFilter("title"),
+		"page_count": IntFilter("page_count"),
+		"due_date":   DateFilter("due_date"),
+		"available":  BoolFilter("available"),
+		"language":   StringFilter("language").WithOperators(In, Eq),
+	}
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+		want  string
+	}{
+		{
+			name:  "default operators",
+			query: url.Values{"filter[title]": {"dune"}, "filter[page_count]": {"100,200"}},
+			want:  `WHERE page_count IN (100,200) AND title ILIKE '%dune%'`,
+		},
+		{
+			name: "operators",
+			query: url.Values{
+				"filter[page_count][between]": {"100,200"},
+				"filter[due_date][lt]":        {"2024-01-31"},
+				"filter[available][eq]":       {"true"},
+			},
+			want: `WHERE available = true AND (due_date)::DATE < '2024-01-31 00:00:00' AND (page_count BETWEEN 100 AND 200)`,
+		},
+		{
+			name:  "like escaped",
+			query: url.Values{"filter[title]": {"100%_off"}},
+			want:  `WHERE title ILIKE '%100\%\_off%'`,
+		},
+		{
+			name:  "empty values and other parameters ignored",
+			query: url.Values{"filter[title]": {""}, "sortBy": {"title"}, "limit": {"5"}},
+			want:  `SELECT * FROM "test_books" LIMIT`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, tt.query.Encode())
+			sql, err := toSQL(newDryRunDB(t), func(db *gorm.DB) (*gorm.DB, error) {
+				db, err := q.Filter(db, testFilters())
+				return db.Limit(1), err
+			})
+			if err != nil {
+				t.Fatalf("Filter() error = %v", err)
+			}
+			if !strings.Contains(sql, tt.want) {
+				t.Errorf("SQL = %s, want it to contain %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterBadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query url.Values
+	}{
+		{name: "unknown key", query: url.Values{"filter[author]": {"herbert"}}},
+		{name: "unknown key with operator", query: url.Values{"filter[author][eq]": {"herbert"}}},
+		{name: "malformed key", query: url.Values{"filter[title][like][x]": {"dune"}}},
+		{name: "unclosed key", query: url.Values{"filter[title": {"dune"}}},
+		{name: "empty key", query: url.Values{"filter[]": {"dune"}}},
+		{name: "operator not allowed", query: url.Values{"filter[title][gt]": {"dune"}}},
+		{name: "operator narrowed", query: url.Values{"filter[language][like]": {"en"}}},
+		{name: "unknown operator", query: url.Values{"filter[page_count][regex]": {"1"}}},
+		{name: "int value", query: url.Values{"filter[page_count][gte]": {"many"}}},
+		{name: "int in value", query: url.Values{"filter[page_count]": {"1,two"}}},
+		{name: "date value", query: url.Values{"filter[due_date]": {"2024-13-01"}}},
+		{name: "date between value", query: url.Values{"filter[due_date][between]": {"2024-01-01"}}},
+		{name: "bool value", query: url.Values{"filter[available]": {"1.0"}}},
+		{name: "null value", query: url.Values{"filter[available][null]": {"nope"}}},
+		{
+			name:  "one bad filter among good ones",
+			query: url.Values{"filter[title]": {"dune"}, "filter[page_count][lt]": {"abc"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newQuery(t, tt.query.Encode())
+			db, err := q.Filter(newDryRunDB(t), testFilters())
+			if db != nil {
+				t.Errorf("Filter() returned a query, want nil")
+			}
+			assertBadRequest(t, err)
+		})
+	}
+}
@@ -2,13 +2,55 @@ package collection
 
 import (
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Paginates the database query based on the collection query
 //
+// In cursor mode the page starts after, or ends before, the row of the cursor instead of
+// at an offset, so deep pages stay fast and rows are not skipped or repeated as rows are
+// added or removed. Use Cursors to get the cursors of the neighbouring pages.
+//
 // To be called after the Filter() and Sort() methods.
 func (q *Query) Paginate(db *gorm.DB) *gorm.DB {
-	return db.Scopes(func(*gorm.DB) *gorm.DB {
-		return db.Offset(q.offset).Limit(q.limit)
-	})
+	if !q.cursorMode {
+		return db.Scopes(func(*gorm.DB) *gorm.DB {
+			return db.Offset(q.offset).Limit(q.limit)
+		})
+	}
+
+	if q.cursorErr != nil {
+		return db.Scopes(func(db *gorm.DB) *gorm.DB {
+			_ = db.AddError(q.cursorErr)
+			return db
+		})
+	}
+
+	if q.cursor == nil {
+		return db.Limit(q.limit)
+	}
+
+	larger := q.orderBy == ASC
+	if !q.cursor.Before {
+		return db.Scopes(q.beyondCursor(q.cursor, larger)).Limit(q.limit)
+	}
+
+	// The page before the cursor is the first rows in reverse order, which are then
+	// selected in the usual order. The reverse order is added before the sort scopes
+	// run, so that it takes precedence over the usual order.
+	reversed := ASC
+	if q.orderBy == ASC {
+		reversed = DESC
+	}
+
+	subQuery := db.Session(&gorm.Session{})
+	if q.sortColumn != "" {
+		subQuery = subQuery.Order(q.sortColumn + " " + reversed)
+	}
+	subQuery = subQuery.Order(clause.OrderByColumn{Column: primaryKey, Desc: reversed == DESC}).
+		Scopes(q.beyondCursor(q.cursor, !larger)).
+		Select("?", primaryKey).
+		Limit(q.limit)
+
+	return db.Where("? IN (?)", primaryKey, subQuery)
 }
@@ -5,35 +5,62 @@ import (
 	"lms-backend/internal/orm"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type Sorter func(string) func(*gorm.DB) *gorm.DB
+// Sorter sorts by a column or SQL expression, after joining the join queries.
+type Sorter struct {
+	Column      string
+	joinQueries []string
+}
 
 type SortMap map[string]Sorter
 
+// primaryKey is the primary key column of the queried model.
+var primaryKey = clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey}
+
 // Sorts the database query based on the collection query
 //
+// In cursor mode the query is always sorted, by the primary key if no sort is given,
+// and ties are broken by the primary key.
+//
 // To be called after the Filter() method.
 func (q *Query) Sort(db *gorm.DB, sorters SortMap, joinQueries ...string) *gorm.DB {
-	if q.sortBy == "" {
+	if q.sortBy == "" && !q.cursorMode {
 		return db
 	}
 
 	db = db.Scopes(orm.JoinAllIfNotJoined(joinQueries))
 
 	sorter, ok := sorters[q.sortBy]
-	if !ok {
+	if ok {
+		q.sortColumn = sorter.Column
+		db = db.Scopes(sorter.scope(q.orderBy))
+	}
+
+	if !q.cursorMode {
 		return db
 	}
 
-	return db.Scopes(sorter(q.orderBy))
+	return db.Scopes(orderByPrimaryKey(q.orderBy))
 }
 
 func SortBy(columnName string, joinQueries ...string) Sorter {
-	return func(order string) func(db *gorm.DB) *gorm.DB {
-		return func(db *gorm.DB) *gorm.DB {
-			return db.Scopes(orm.JoinAllIfNotJoined(joinQueries)).
-				Order(fmt.Sprintf("%s %s", columnName, order))
-		}
+	return Sorter{
+		Column:      columnName,
+		joinQueries: joinQueries,
+	}
+}
+
+func (s Sorter) scope(order Order) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(orm.JoinAllIfNotJoined(s.joinQueries)).
+			Order(fmt.Sprintf("%s %s", s.Column, order))
+	}
+}
+
+func orderByPrimaryKey(order Order) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: primaryKey, Desc: order == DESC})
 	}
 }
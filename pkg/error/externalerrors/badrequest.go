@@ -4,6 +4,6 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func BadRequest(message string) error {
-	return fiber.NewError(fiber.StatusBadRequest, message)
+func BadRequest(message string) *Error {
+	return New(fiber.StatusBadRequest, BadRequestCode, message)
 }
@@ -0,0 +1,102 @@
+package externalerrors
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Code identifies the kind of an error for clients. Codes are never renamed once
+// clients depend on them.
+type Code = string
+
+// Codes of errors that only have a status
+const (
+	BadRequestCode          Code = "BAD_REQUEST"
+	UnauthorizedCode        Code = "UNAUTHORIZED"
+	ForbiddenCode           Code = "FORBIDDEN"
+	NotFoundCode            Code = "NOT_FOUND"
+	ConflictCode            Code = "CONFLICT"
+	PreconditionFailedCode  Code = "PRECONDITION_FAILED"
+	UnprocessableEntityCode Code = "UNPROCESSABLE_ENTITY"
+	// The request must be conditional, e.g. with If-Match
+	PreconditionRequiredCode Code = "PRECONDITION_REQUIRED"
+	TooManyRequestsCode      Code = "TOO_MANY_REQUESTS"
+	InternalServerErrorCode  Code = "INTERNAL_SERVER_ERROR"
+)
+
+// Codes of the errors of the library
+const (
+	ValidationFailed   Code = "VALIDATION_FAILED"
+	InvalidCredentials Code = "INVALID_CREDENTIALS"
+	RecordNotFound     Code = "RECORD_NOT_FOUND"
+
+	LoanLimitReached        Code = "LOAN_LIMIT_REACHED"
+	ReservationLimitReached Code = "RESERVATION_LIMIT_REACHED"
+	BookmarkLimitReached    Code = "BOOKMARK_LIMIT_REACHED"
+	LoanDurationExceeded    Code = "LOAN_DURATION_EXCEEDED"
+	BookAlreadyLoaned       Code = "BOOK_ALREADY_LOANED"
+	BookAlreadyReserved     Code = "BOOK_ALREADY_RESERVED"
+
+	CopyOnLoan            Code = "COPY_ON_LOAN"
+	CopyOnReserve         Code = "COPY_ON_RESERVE"
+	CopyInTransit         Code = "COPY_IN_TRANSIT"
+	CopyNotOnLoan         Code = "COPY_NOT_ON_LOAN"
+	CopyNotOnReserve      Code = "COPY_NOT_ON_RESERVE"
+	CopyNotInTransit      Code = "COPY_NOT_IN_TRANSIT"
+	CopyNotAvailable      Code = "COPY_NOT_AVAILABLE"
+	CopyAtDestination     Code = "COPY_AT_DESTINATION"
+	ReservationNotPending Code = "RESERVATION_NOT_PENDING"
+	TransferNotInTransit  Code = "TRANSFER_NOT_IN_TRANSIT"
+	BranchHasCopies       Code = "BRANCH_HAS_COPIES"
+	BranchHasReservations Code = "BRANCH_HAS_RESERVATIONS"
+	BranchHasStaff        Code = "BRANCH_HAS_STAFF"
+	DefaultBranch         Code = "DEFAULT_BRANCH"
+
+	// The record changed since the version the request was made from
+	VersionConflict      Code = "VERSION_CONFLICT"
+	IdempotencyKeyInUse  Code = "IDEMPOTENCY_KEY_IN_USE"
+	IdempotencyKeyReused Code = "IDEMPOTENCY_KEY_REUSED"
+	RateLimitExceeded    Code = "RATE_LIMIT_EXCEEDED"
+)
+
+// Codes of the errors of a field
+const (
+	Required      Code = "REQUIRED"
+	InvalidFormat Code = "INVALID_FORMAT"
+	InvalidValue  Code = "INVALID_VALUE"
+	AlreadyExists Code = "ALREADY_EXISTS"
+	// The value is repeated in a list of the request
+	Duplicated Code = "DUPLICATED"
+	// The ID in the body is not the one in the url
+	MismatchedID Code = "MISMATCHED_ID"
+)
+
+var (
+	statusCodes = map[int]Code{
+		fiber.StatusBadRequest:           BadRequestCode,
+		fiber.StatusUnauthorized:         UnauthorizedCode,
+		fiber.StatusForbidden:            ForbiddenCode,
+		fiber.StatusNotFound:             NotFoundCode,
+		fiber.StatusConflict:             ConflictCode,
+		fiber.StatusPreconditionFailed:   PreconditionFailedCode,
+		fiber.StatusUnprocessableEntity:  UnprocessableEntityCode,
+		fiber.StatusPreconditionRequired: PreconditionRequiredCode,
+		fiber.StatusTooManyRequests:      TooManyRequestsCode,
+		fiber.StatusInternalServerError:  InternalServerErrorCode,
+	}
+
+	nonLetterRegex = regexp.MustCompile(`[^A-Z]+`)
+)
+
+// StatusCode is the code of errors that only have a status, e.g. NOT_FOUND for 404.
+func StatusCode(status int) Code {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+
+	// e.g. METHOD_NOT_ALLOWED
+	return strings.Trim(nonLetterRegex.ReplaceAllString(strings.ToUpper(http.StatusText(status)), "_"), "_")
+}
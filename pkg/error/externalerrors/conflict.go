@@ -0,0 +1,9 @@
+package externalerrors
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func Conflict(message string) *Error {
+	return New(fiber.StatusConflict, ConflictCode, message)
+}
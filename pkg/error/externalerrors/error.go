@@ -0,0 +1,59 @@
+package externalerrors
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// Error is an error shown to the client, with a code that the client can act on
+// instead of parsing the message.
+//
+// Validation errors also hold the error of each field at fault.
+type Error struct {
+	Status  int
+	Code    Code
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError is the error of a field of the request, named by its JSON path,
+// e.g. contributors[0].name.
+type FieldError struct {
+	Field   string
+	Code    Code
+	Message string
+}
+
+// New is an error with the status of the response and the code of the error.
+func New(status int, code Code, message string) *Error {
+	return &Error{
+		Status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap lets the error be handled like the fiber error of its status.
+func (e *Error) Unwrap() error {
+	return fiber.NewError(e.Status, e.Message)
+}
+
+// WithField names the field at fault, for errors other than validation errors,
+// e.g. Conflict(msg).WithField("isbn", AlreadyExists).
+func (e *Error) WithField(field string, code Code) *Error {
+	e.Fields = append(e.Fields, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: e.Message,
+	})
+	return e
+}
+
+// WithCode replaces the code of the error, e.g. BadRequest(msg).WithCode(LoanLimitReached).
+func (e *Error) WithCode(code Code) *Error {
+	e.Code = code
+	return e
+}
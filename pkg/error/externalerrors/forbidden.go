@@ -4,6 +4,6 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func Forbidden(message string) error {
-	return fiber.NewError(fiber.StatusForbidden, message)
+func Forbidden(message string) *Error {
+	return New(fiber.StatusForbidden, ForbiddenCode, message)
 }
@@ -0,0 +1,9 @@
+package externalerrors
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func PreconditionRequired(message string) *Error {
+	return New(fiber.StatusPreconditionRequired, PreconditionRequiredCode, message)
+}
@@ -0,0 +1,9 @@
+package externalerrors
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+func TooManyRequests(message string) *Error {
+	return New(fiber.StatusTooManyRequests, TooManyRequestsCode, message)
+}
@@ -4,6 +4,6 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func Unauthorized(message string) error {
-	return fiber.NewError(fiber.StatusUnauthorized, message)
+func Unauthorized(message string) *Error {
+	return New(fiber.StatusUnauthorized, UnauthorizedCode, message)
 }
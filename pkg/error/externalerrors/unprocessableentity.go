@@ -4,6 +4,6 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func UnprocessableEntity(message string) error {
-	return fiber.NewError(fiber.StatusUnprocessableEntity, message)
+func UnprocessableEntity(message string) *Error {
+	return New(fiber.StatusUnprocessableEntity, UnprocessableEntityCode, message)
 }
@@ -0,0 +1,85 @@
+package externalerrors
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Validation collects the errors of every field of a request, so that the client
+// can be told about all of them at once.
+//
+// Example:
+//
+//	v := externalerrors.Validation{}
+//	if p.Title == "" {
+//		v.Add("title", externalerrors.Required, "title is required")
+//	}
+//	v.Nest("person_attributes", p.PersonParams.Validate())
+//	return v.Err()
+type Validation struct {
+	fields []FieldError
+}
+
+// Add adds the error of the field.
+func (v *Validation) Add(field string, code Code, message string) {
+	v.fields = append(v.fields, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+	})
+}
+
+// Nest adds the error of validating a nested object, with the paths of its fields
+// under field. An empty field adds the errors of an embedded object as they are.
+func (v *Validation) Nest(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	var e *Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &e) && len(e.Fields) > 0:
+		for _, fieldErr := range e.Fields {
+			v.Add(joinPath(field, fieldErr.Field), fieldErr.Code, fieldErr.Message)
+		}
+	case errors.As(err, &e):
+		v.Add(field, e.Code, e.Message)
+	case errors.As(err, &fiberErr):
+		v.Add(field, StatusCode(fiberErr.Code), fiberErr.Message)
+	default:
+		v.Add(field, InvalidValue, err.Error())
+	}
+}
+
+func joinPath(parent, field string) string {
+	switch {
+	case parent == "":
+		return field
+	case field == "", strings.HasPrefix(field, "["):
+		return parent + field
+	default:
+		return parent + "." + field
+	}
+}
+
+// Err is a bad request error with the error of every field, or nil if all fields are valid.
+func (v *Validation) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(v.fields))
+	for _, fieldErr := range v.fields {
+		messages = append(messages, fieldErr.Message)
+	}
+
+	return &Error{
+		Status:  fiber.StatusBadRequest,
+		Code:    ValidationFailed,
+		Message: strings.Join(messages, "; "),
+		Fields:  v.fields,
+	}
+}
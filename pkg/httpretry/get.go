@@ -0,0 +1,52 @@
+// Package httpretry retries idempotent HTTP requests that fail transiently.
+package httpretry
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultAttempts = 3
+	baseBackoff     = 200 * time.Millisecond
+)
+
+// retryable reports whether a response is worth asking for again.
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// Get sends a GET request, retrying network errors, 429 and 5xx responses with a growing backoff.
+// The last response or error is returned once the attempts are used up or ctx is done.
+func Get(ctx context.Context, client *http.Client, url string, attempts int) (*http.Response, error) {
+	var res *http.Response
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err = client.Do(req)
+		if err == nil && !retryable(res.StatusCode) {
+			return res, nil
+		}
+
+		if attempt >= attempts {
+			return res, err
+		}
+
+		if res != nil {
+			res.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(baseBackoff * time.Duration(attempt)):
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer responds with the statuses in turn, repeating the last one.
+func newTestServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := int(requests.Add(1)) - 1
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		w.WriteHeader(statuses[i])
+		_, _ = io.WriteString(w, http.StatusText(statuses[i]))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		attempts     int
+		wantStatus   int
+		wantRequests int32
+	}{
+		{name: "ok", statuses: []int{200}, attempts: 3, wantStatus: 200, wantRequests: 1},
+		{name: "server error then ok", statuses: []int{500, 503, 200}, attempts: 3, wantStatus: 200, wantRequests: 3},
+		{name: "too many requests then ok", statuses: []int{429, 200}, attempts: 3, wantStatus: 200, wantRequests: 2},
+		{name: "client error not retried", statuses: []int{404, 200}, attempts: 3, wantStatus: 404, wantRequests: 1},
+		{name: "attempts used up", statuses: []int{502}, attempts: 2, wantStatus: 502, wantRequests: 2},
+		{name: "single attempt", statuses: []int{500, 200}, attempts: 1, wantStatus: 500, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newTestServer(t, tt.statuses...)
+
+			res, err := Get(context.Background(), server.Client(), server.URL, tt.attempts)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			defer res.Body.Close()
+
+			if res.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", res.StatusCode, tt.wantStatus)
+			}
+			// The body of the last response is left for the caller to read
+			if body, err := io.ReadAll(res.Body); err != nil || string(body) != http.StatusText(tt.wantStatus) {
+				t.Errorf("body = %q, %v, want %q", body, err, http.StatusText(tt.wantStatus))
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestGetNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	start := time.Now()
+	res, err := Get(context.Background(), http.DefaultClient, url, 2)
+	if err == nil {
+		res.Body.Close()
+		t.Fatal("Get() error = nil, want the error of the last attempt")
+	}
+	if elapsed := time.Since(start); elapsed < baseBackoff {
+		t.Errorf("Get() returned after %s, want it to back off before retrying", elapsed)
+	}
+}
+
+func TestGetContextDone(t *testing.T) {
+	server, requests := newTestServer(t, 503)
+
+	ctx, cancel := context.WithTimeout(context.Background(), baseBackoff/2)
+	defer cancel()
+
+	res, err := Get(ctx, server.Client(), server.URL, 5)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		if res != nil {
+			res.Body.Close()
+		}
+		t.Fatalf("Get() error = %v, want the error of the context", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %d, want no retry once the context is done", got)
+	}
+}
+
+func TestGetInvalidURL(t *testing.T) {
+	if _, err := Get(context.Background(), http.DefaultClient, "://missing-scheme", 3); err == nil {
+		t.Fatal("Get() error = nil, want an error")
+	}
+}
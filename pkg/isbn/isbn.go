@@ -0,0 +1,109 @@
+package isbn
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidLength    = errors.New("isbn must have 10 or 13 digits")
+	ErrInvalidCharacter = errors.New("isbn contains invalid characters")
+	ErrInvalidPrefix    = errors.New("isbn-13 must start with 978 or 979")
+	ErrInvalidChecksum  = errors.New("isbn check digit is invalid")
+)
+
+// Normalize validates an ISBN-10 or ISBN-13 and returns it as an ISBN-13
+// without hyphens or spaces.
+func Normalize(isbn string) (string, error) {
+	digits := strip(isbn)
+
+	switch len(digits) {
+	case 10:
+		if err := validateISBN10(digits); err != nil {
+			return "", err
+		}
+		return toISBN13(digits), nil
+	case 13:
+		if err := validateISBN13(digits); err != nil {
+			return "", err
+		}
+		return digits, nil
+	default:
+		return "", ErrInvalidLength
+	}
+}
+
+// IsValid reports whether isbn is a valid ISBN-10 or ISBN-13.
+func IsValid(isbn string) bool {
+	_, err := Normalize(isbn)
+	return err == nil
+}
+
+func strip(isbn string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(isbn)))
+}
+
+func validateISBN10(digits string) error {
+	sum := 0
+	for i, r := range digits {
+		var d int
+		switch {
+		case r >= '0' && r <= '9':
+			d = int(r - '0')
+		case r == 'X' && i == 9: // X is only allowed as the check digit
+			d = 10
+		default:
+			return ErrInvalidCharacter
+		}
+		sum += (10 - i) * d
+	}
+
+	if sum%11 != 0 {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+func validateISBN13(digits string) error {
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return ErrInvalidCharacter
+		}
+	}
+
+	if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+		return ErrInvalidPrefix
+	}
+
+	if checkDigit13(digits[:12]) != digits[12] {
+		return ErrInvalidChecksum
+	}
+
+	return nil
+}
+
+// checkDigit13 computes the ISBN-13 check digit of the first 12 digits.
+func checkDigit13(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 0 {
+			sum += d
+		} else {
+			sum += 3 * d
+		}
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+func toISBN13(isbn10 string) string {
+	prefix := "978" + isbn10[:9]
+	return prefix + string(checkDigit13(prefix))
+}
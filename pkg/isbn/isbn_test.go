@@ -0,0 +1,81 @@
+package isbn
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		isbn string
+		want string
+		err  error
+	}{
+		{name: "isbn-13", isbn: "9780306406157", want: "9780306406157"},
+		{name: "isbn-13 with hyphens", isbn: "978-0-306-40615-7", want: "9780306406157"},
+		{name: "isbn-13 with 979 prefix", isbn: "979-10-90636-07-1", want: "9791090636071"},
+		{name: "isbn-10 converted", isbn: "0306406152", want: "9780306406157"},
+		{name: "isbn-10 with spaces", isbn: " 0 306 40615 2 ", want: "9780306406157"},
+		{name: "isbn-10 with X check digit", isbn: "080442957X", want: "9780804429573"},
+		{name: "isbn-10 with lowercase x", isbn: "0-8044-2957-x", want: "9780804429573"},
+		{name: "isbn-10 converted to check digit 0", isbn: "0131103628", want: "9780131103627"},
+		{name: "empty", isbn: "", err: ErrInvalidLength},
+		{name: "too short", isbn: "030640615", err: ErrInvalidLength},
+		{name: "too long", isbn: "97803064061570", err: ErrInvalidLength},
+		{name: "isbn-10 bad checksum", isbn: "0306406153", err: ErrInvalidChecksum},
+		{name: "isbn-13 bad checksum", isbn: "9780306406158", err: ErrInvalidChecksum},
+		{name: "isbn-10 letter", isbn: "03064O6152", err: ErrInvalidCharacter},
+		{name: "isbn-10 X not last", isbn: "X306406152", err: ErrInvalidCharacter},
+		{name: "isbn-13 X", isbn: "978030640615X", err: ErrInvalidCharacter},
+		{name: "isbn-13 bad prefix", isbn: "9770306406157", err: ErrInvalidPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Normalize(tt.isbn)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Normalize(%q) error = %v, want %v", tt.isbn, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.isbn, got, tt.want)
+			}
+			if IsValid(tt.isbn) != (tt.err == nil) {
+				t.Errorf("IsValid(%q) = %t, want %t", tt.isbn, !(tt.err == nil), tt.err == nil)
+			}
+		})
+	}
+}
+
+func TestCheckDigit13(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   byte
+	}{
+		{digits: "978030640615", want: '7'},
+		{digits: "978080442957", want: '3'},
+		{digits: "978013110362", want: '7'},
+		{digits: "979109063607", want: '1'},
+		{digits: "978000000000", want: '2'},
+	}
+
+	for _, tt := range tests {
+		if got := checkDigit13(tt.digits); got != tt.want {
+			t.Errorf("checkDigit13(%q) = %c, want %c", tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestToISBN13(t *testing.T) {
+	tests := map[string]string{
+		"0306406152": "9780306406157",
+		"080442957X": "9780804429573",
+		"0131103628": "9780131103627",
+	}
+
+	for isbn10, want := range tests {
+		if got := toISBN13(isbn10); got != want {
+			t.Errorf("toISBN13(%q) = %q, want %q", isbn10, got, want)
+		}
+	}
+}
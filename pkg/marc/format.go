@@ -0,0 +1,61 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// RecordReader is implemented by Reader and XMLReader.
+type RecordReader interface {
+	Read() (*Record, error)
+}
+
+// RecordWriter is implemented by Writer and XMLWriter.
+type RecordWriter interface {
+	Write(*Record) error
+}
+
+// NewRecordReader returns a reader for the format.
+// An empty format is detected from the first non-whitespace byte of the input.
+func NewRecordReader(r io.Reader, format Format) (RecordReader, error) {
+	if format == "" {
+		br := bufio.NewReader(r)
+		format = detectFormat(br)
+		r = br
+	}
+
+	switch format {
+	case FormatISO2709:
+		return NewReader(r), nil
+	case FormatXML:
+		return NewXMLReader(r), nil
+	default:
+		return nil, fmt.Errorf("unsupported marc format %q", format)
+	}
+}
+
+func detectFormat(br *bufio.Reader) Format {
+	peeked, _ := br.Peek(512) //nolint:errcheck // a short read is handled below
+	if bytes.HasPrefix(bytes.TrimSpace(peeked), []byte("<")) {
+		return FormatXML
+	}
+
+	return FormatISO2709
+}
+
+// ReadAll reads every record of the input.
+func ReadAll(r RecordReader) ([]*Record, error) {
+	records := []*Record{}
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", len(records)+1, err)
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,218 @@
+package marc
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	subfieldDelimiter  = 0x1F
+	fieldTerminator    = 0x1E
+	recordTerminator   = 0x1D
+	directoryEntrySize = 12
+)
+
+// Reader reads ISO 2709 records one at a time.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next record, or io.EOF when there are no more records.
+func (r *Reader) Read() (*Record, error) {
+	data, err := r.r.ReadBytes(recordTerminator)
+	if err == io.EOF {
+		// Tolerate trailing whitespace or a missing final record terminator
+		if len(bytes.TrimSpace(data)) == 0 {
+			return nil, io.EOF
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return parseISO2709(bytes.TrimLeft(data, "\r\n"))
+}
+
+func parseISO2709(data []byte) (*Record, error) {
+	if len(data) < leaderLength {
+		return nil, fmt.Errorf("record is shorter than the leader")
+	}
+
+	leader := string(data[:leaderLength])
+	baseAddress, err := parseDigits(leader[12:17])
+	if err != nil || baseAddress <= leaderLength || baseAddress > len(data) {
+		return nil, fmt.Errorf("invalid base address of data %q", leader[12:17])
+	}
+
+	directory := data[leaderLength : baseAddress-1]
+	if len(directory)%directoryEntrySize != 0 {
+		return nil, fmt.Errorf("invalid directory length %d", len(directory))
+	}
+
+	record := &Record{Leader: leader}
+	fields := data[baseAddress:]
+	for i := 0; i < len(directory); i += directoryEntrySize {
+		entry := string(directory[i : i+directoryEntrySize])
+		tag := entry[:3]
+
+		length, err := parseDigits(entry[3:7])
+		if err != nil {
+			return nil, fmt.Errorf("invalid length for field %s", tag)
+		}
+
+		start, err := parseDigits(entry[7:12])
+		if err != nil {
+			return nil, fmt.Errorf("invalid starting position for field %s", tag)
+		}
+
+		if start+length > len(fields) {
+			return nil, fmt.Errorf("field %s extends beyond the end of the record", tag)
+		}
+
+		value := bytes.TrimRight(fields[start:start+length], string([]byte{fieldTerminator, recordTerminator}))
+		if IsControlTag(tag) {
+			record.AddControlField(tag, string(value))
+			continue
+		}
+
+		record.DataFields = append(record.DataFields, parseDataField(tag, value))
+	}
+
+	return record, nil
+}
+
+// parseDigits parses a number of the leader or directory, which is only made of digits.
+// Signs and spaces are rejected, so that lengths and offsets are never negative.
+func parseDigits(s string) (int, error) {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%q is not a number", s)
+		}
+	}
+
+	return strconv.Atoi(s)
+}
+
+func parseDataField(tag string, value []byte) DataField {
+	field := DataField{Tag: tag, Ind1: " ", Ind2: " "}
+	if len(value) >= 2 {
+		field.Ind1 = string(value[0])
+		field.Ind2 = string(value[1])
+		value = value[2:]
+	}
+
+	for _, part := range bytes.Split(value, []byte{subfieldDelimiter}) {
+		if len(part) == 0 {
+			continue
+		}
+		field.Subfields = append(field.Subfields, Subfield{
+			Code:  string(part[0]),
+			Value: string(part[1:]),
+		})
+	}
+
+	return field
+}
+
+// Writer writes records in ISO 2709 form.
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+func (w *Writer) Write(record *Record) error {
+	data, err := encodeISO2709(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(data)
+	return err
+}
+
+func encodeISO2709(record *Record) ([]byte, error) {
+	var directory, fields bytes.Buffer
+
+	addField := func(tag string, value []byte) error {
+		if len(tag) != 3 {
+			return fmt.Errorf("invalid tag %q", tag)
+		}
+		value = append(value, fieldTerminator)
+		if len(value) > 9999 {
+			return fmt.Errorf("field %s is too long", tag)
+		}
+		fmt.Fprintf(&directory, "%s%04d%05d", tag, len(value), fields.Len())
+		fields.Write(value)
+		return nil
+	}
+
+	for _, f := range record.ControlFields {
+		if err := addField(f.Tag, []byte(f.Value)); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range record.DataFields {
+		var value bytes.Buffer
+		value.WriteString(indicator(f.Ind1))
+		value.WriteString(indicator(f.Ind2))
+		for _, s := range f.Subfields {
+			value.WriteByte(subfieldDelimiter)
+			value.WriteString(s.Code)
+			value.WriteString(s.Value)
+		}
+		if err := addField(f.Tag, value.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	directory.WriteByte(fieldTerminator)
+
+	baseAddress := leaderLength + directory.Len()
+	recordLength := baseAddress + fields.Len() + 1
+	if recordLength > 99999 {
+		return nil, fmt.Errorf("record is too long")
+	}
+
+	leader := record.Leader
+	if len(leader) != leaderLength {
+		leader = DefaultLeader
+	}
+	leader = fmt.Sprintf("%05d%s%05d%s", recordLength, leader[5:12], baseAddress, leader[17:])
+
+	var data bytes.Buffer
+	data.WriteString(leader)
+	data.Write(directory.Bytes())
+	data.Write(fields.Bytes())
+	data.WriteByte(recordTerminator)
+
+	return data.Bytes(), nil
+}
+
+func indicator(ind string) string {
+	if len(ind) != 1 {
+		return " "
+	}
+
+	return ind
+}
+
+// cleanLeader replaces missing or malformed leaders so that records read from
+// MARCXML can be written back out as ISO 2709.
+func cleanLeader(leader string) string {
+	leader = strings.TrimRight(leader, "\r\n")
+	if len(leader) != leaderLength {
+		return DefaultLeader
+	}
+
+	return leader
+}
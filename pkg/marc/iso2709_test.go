@@ -0,0 +1,124 @@
+package marc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestISO2709RoundTrip(t *testing.T) {
+	want := []*Record{testRecord(), NewRecord()}
+	want[1].AddDataField("245", "0", "0", Subfield{Code: "a", Value: "Untitled"})
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, record := range want {
+		if err := w.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	got, err := ReadAll(NewReader(&buf))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ReadAll() returned %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		assertSameRecord(t, got[i], want[i])
+	}
+
+	first, err := encodeISO2709(want[0])
+	if err != nil {
+		t.Fatalf("encodeISO2709() error = %v", err)
+	}
+	if length := got[0].Leader[:5]; length != fmt.Sprintf("%05d", len(first)) {
+		t.Errorf("record length in leader = %s, want %d", length, len(first))
+	}
+}
+
+func TestReaderToleratesTrailingWhitespace(t *testing.T) {
+	data, err := encodeISO2709(testRecord())
+	if err != nil {
+		t.Fatalf("encodeISO2709() error = %v", err)
+	}
+
+	r := NewReader(strings.NewReader("\r\n" + string(data) + "\r\n"))
+	if _, err := r.Read(); err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Fatalf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestReaderMalformed(t *testing.T) {
+	// A valid record with a single 245 field, which is altered by each test
+	valid := "00048nam a2200037 i 4500" + "245001000000" + "\x1e" + "10\x1faTitle\x1e" + "\x1d"
+	if _, err := parseISO2709([]byte(valid)); err != nil {
+		t.Fatalf("parseISO2709() of the valid record error = %v", err)
+	}
+
+	replace := func(at int, s string) string {
+		return valid[:at] + s + valid[at+len(s):]
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "shorter than the leader", data: "00048nam a2200"},
+		{name: "non-digit base address", data: replace(12, "00a37")},
+		{name: "signed base address", data: replace(12, "-0037")},
+		{name: "base address inside the leader", data: replace(12, "00010")},
+		{name: "base address beyond the record", data: replace(12, "99999")},
+		{name: "directory length", data: replace(12, "00036")},
+		{name: "negative field length", data: replace(27, "-001")},
+		{name: "signed field length", data: replace(27, "+012")},
+		{name: "non-digit field length", data: replace(27, "00x2")},
+		{name: "negative starting position", data: replace(31, "-0001")},
+		{name: "space in starting position", data: replace(31, " 0000")},
+		{name: "field beyond the record", data: replace(27, "0099")},
+		{name: "starting position beyond the record", data: replace(31, "00050")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := NewReader(strings.NewReader(tt.data)).Read()
+			if err == nil {
+				t.Fatalf("Read() = %+v, want an error", record)
+			}
+		})
+	}
+}
+
+func TestWriterInvalidRecord(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *Record
+	}{
+		{
+			name:   "invalid tag",
+			record: &Record{ControlFields: []ControlField{{Tag: "01", Value: "42"}}},
+		},
+		{
+			name:   "field too long",
+			record: &Record{ControlFields: []ControlField{{Tag: "001", Value: strings.Repeat("a", 9999)}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewWriter(&buf).Write(tt.record); err == nil {
+				t.Fatal("Write() error = nil, want an error")
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Write() wrote %d bytes of an invalid record", buf.Len())
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package marc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testRecord returns a record with control fields, repeated data fields and
+// non-ASCII values, as exported by the catalogue.
+func testRecord() *Record {
+	record := NewRecord()
+	record.AddControlField("001", "42")
+	record.AddControlField("008", "240101s2023    my            000 0 eng d")
+	record.AddDataField("020", " ", " ", Subfield{Code: "a", Value: "9780306406157"})
+	record.AddDataField("100", "1", " ", Subfield{Code: "a", Value: "Abdullah, Siti"})
+	record.AddDataField("245", "1", "0",
+		Subfield{Code: "a", Value: "Sejarah Melaka :"},
+		Subfield{Code: "b", Value: "zaman kesultanan – édition révisée"},
+	)
+	record.AddDataField("650", " ", "0", Subfield{Code: "a", Value: "Malaysia"})
+	record.AddDataField("650", " ", "0", Subfield{Code: "a", Value: "History"})
+	return record
+}
+
+// assertSameRecord compares the fields of the records, as the leader of written records
+// is updated with their length and base address.
+func assertSameRecord(t *testing.T, got, want *Record) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got.ControlFields, want.ControlFields) {
+		t.Errorf("control fields = %+v, want %+v", got.ControlFields, want.ControlFields)
+	}
+	if !reflect.DeepEqual(got.DataFields, want.DataFields) {
+		t.Errorf("data fields = %+v, want %+v", got.DataFields, want.DataFields)
+	}
+	if len(got.Leader) != leaderLength {
+		t.Errorf("leader %q has length %d, want %d", got.Leader, len(got.Leader), leaderLength)
+	}
+	if got.Leader[5:12] != want.Leader[5:12] || got.Leader[17:] != want.Leader[17:] {
+		t.Errorf("leader = %q, want the type and encoding of %q", got.Leader, want.Leader)
+	}
+}
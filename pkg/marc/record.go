@@ -0,0 +1,139 @@
+// Package marc reads and writes MARC 21 bibliographic records
+// in ISO 2709 (binary) and MARCXML form.
+package marc
+
+import (
+	"strings"
+)
+
+type Format = string
+
+const (
+	FormatISO2709 Format = "marc"
+	FormatXML     Format = "marcxml"
+)
+
+// Record is a MARC record: a leader followed by control fields (tags 001-009)
+// and data fields (tags 010-999).
+type Record struct {
+	Leader        string
+	ControlFields []ControlField
+	DataFields    []DataField
+}
+
+type ControlField struct {
+	Tag   string
+	Value string
+}
+
+type DataField struct {
+	Tag       string
+	Ind1      string
+	Ind2      string
+	Subfields []Subfield
+}
+
+type Subfield struct {
+	Code  string
+	Value string
+}
+
+const (
+	leaderLength = 24
+	// DefaultLeader describes a new, Unicode encoded, monograph language material record.
+	// Lengths and the base address are filled in when the record is written.
+	DefaultLeader = "00000nam a2200000 i 4500"
+)
+
+// NewRecord returns an empty record with the default leader.
+func NewRecord() *Record {
+	return &Record{Leader: DefaultLeader}
+}
+
+// IsControlTag reports whether the tag identifies a control field (001-009).
+func IsControlTag(tag string) bool {
+	return strings.HasPrefix(tag, "00")
+}
+
+// ControlField returns the value of the first control field with the tag.
+func (r *Record) ControlField(tag string) string {
+	for _, f := range r.ControlFields {
+		if f.Tag == tag {
+			return f.Value
+		}
+	}
+
+	return ""
+}
+
+// Fields returns all data fields with the tag in the order they appear.
+func (r *Record) Fields(tag string) []DataField {
+	fields := []DataField{}
+	for _, f := range r.DataFields {
+		if f.Tag == tag {
+			fields = append(fields, f)
+		}
+	}
+
+	return fields
+}
+
+// SubfieldValue returns the first value of the subfield in the first data field with the tag.
+func (r *Record) SubfieldValue(tag, code string) string {
+	for _, f := range r.Fields(tag) {
+		if v := f.Subfield(code); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func (r *Record) AddControlField(tag, value string) {
+	r.ControlFields = append(r.ControlFields, ControlField{Tag: tag, Value: value})
+}
+
+// AddDataField appends a data field, skipping subfields with empty values.
+// Fields without any remaining subfields are not added.
+func (r *Record) AddDataField(tag, ind1, ind2 string, subfields ...Subfield) {
+	nonEmpty := []Subfield{}
+	for _, s := range subfields {
+		if s.Value != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+
+	if len(nonEmpty) == 0 {
+		return
+	}
+
+	r.DataFields = append(r.DataFields, DataField{
+		Tag:       tag,
+		Ind1:      ind1,
+		Ind2:      ind2,
+		Subfields: nonEmpty,
+	})
+}
+
+// Subfield returns the first value of the subfield with the code.
+func (f DataField) Subfield(code string) string {
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			return s.Value
+		}
+	}
+
+	return ""
+}
+
+// SubfieldValues returns all values of the subfields with the code.
+func (f DataField) SubfieldValues(code string) []string {
+	values := []string{}
+	for _, s := range f.Subfields {
+		if s.Code == code {
+			values = append(values, s.Value)
+		}
+	}
+
+	return values
+}
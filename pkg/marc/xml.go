@@ -0,0 +1,150 @@
+package marc
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+const (
+	XMLNamespace = "http://www.loc.gov/MARC21/slim"
+)
+
+type xmlRecord struct {
+	XMLName       xml.Name          `xml:"record"`
+	Leader        string            `xml:"leader"`
+	ControlFields []xmlControlField `xml:"controlfield"`
+	DataFields    []xmlDataField    `xml:"datafield"`
+}
+
+type xmlControlField struct {
+	Tag   string `xml:"tag,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlDataField struct {
+	Tag       string        `xml:"tag,attr"`
+	Ind1      string        `xml:"ind1,attr"`
+	Ind2      string        `xml:"ind2,attr"`
+	Subfields []xmlSubfield `xml:"subfield"`
+}
+
+type xmlSubfield struct {
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// XMLReader reads the records of a MARCXML document one at a time.
+// Both a <collection> of records and a single <record> root are accepted.
+type XMLReader struct {
+	d *xml.Decoder
+}
+
+func NewXMLReader(r io.Reader) *XMLReader {
+	return &XMLReader{d: xml.NewDecoder(r)}
+}
+
+// Read returns the next record, or io.EOF when there are no more records.
+func (r *XMLReader) Read() (*Record, error) {
+	for {
+		token, err := r.d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "record" {
+			continue
+		}
+
+		var x xmlRecord
+		if err := r.d.DecodeElement(&x, &start); err != nil {
+			return nil, err
+		}
+
+		return fromXMLRecord(&x), nil
+	}
+}
+
+func fromXMLRecord(x *xmlRecord) *Record {
+	record := &Record{Leader: cleanLeader(x.Leader)}
+	for _, f := range x.ControlFields {
+		record.AddControlField(f.Tag, f.Value)
+	}
+
+	for _, f := range x.DataFields {
+		field := DataField{Tag: f.Tag, Ind1: indicator(f.Ind1), Ind2: indicator(f.Ind2)}
+		for _, s := range f.Subfields {
+			field.Subfields = append(field.Subfields, Subfield{Code: s.Code, Value: s.Value})
+		}
+		record.DataFields = append(record.DataFields, field)
+	}
+
+	return record
+}
+
+func toXMLRecord(record *Record) *xmlRecord {
+	x := &xmlRecord{Leader: cleanLeader(record.Leader)}
+	for _, f := range record.ControlFields {
+		x.ControlFields = append(x.ControlFields, xmlControlField(f))
+	}
+
+	for _, f := range record.DataFields {
+		field := xmlDataField{Tag: f.Tag, Ind1: indicator(f.Ind1), Ind2: indicator(f.Ind2)}
+		for _, s := range f.Subfields {
+			field.Subfields = append(field.Subfields, xmlSubfield(s))
+		}
+		x.DataFields = append(x.DataFields, field)
+	}
+
+	return x
+}
+
+// XMLWriter writes records into a MARCXML <collection>.
+// Close must be called to end the document.
+type XMLWriter struct {
+	w       io.Writer
+	e       *xml.Encoder
+	started bool
+}
+
+func NewXMLWriter(w io.Writer) *XMLWriter {
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return &XMLWriter{w: w, e: e}
+}
+
+func (w *XMLWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	if _, err := io.WriteString(w.w, xml.Header); err != nil {
+		return err
+	}
+
+	return w.e.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "collection"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: XMLNamespace}},
+	})
+}
+
+func (w *XMLWriter) Write(record *Record) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	return w.e.Encode(toXMLRecord(record))
+}
+
+func (w *XMLWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if err := w.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "collection"}}); err != nil {
+		return err
+	}
+
+	return w.e.Flush()
+}
@@ -0,0 +1,144 @@
+package marc
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestXMLRoundTrip(t *testing.T) {
+	want := []*Record{testRecord(), NewRecord()}
+	want[1].AddDataField("245", "0", "0", Subfield{Code: "a", Value: "Fish & <chips>"})
+
+	var buf bytes.Buffer
+	w := NewXMLWriter(&buf)
+	for _, record := range want {
+		if err := w.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `<collection xmlns="`+XMLNamespace+`">`) {
+		t.Errorf("document does not start a MARCXML collection:\n%s", buf.String())
+	}
+
+	got, err := ReadAll(NewXMLReader(&buf))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ReadAll() returned %d records, want %d", len(got), len(want))
+	}
+	for i := range want {
+		assertSameRecord(t, got[i], want[i])
+	}
+}
+
+func TestXMLWriterEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewXMLWriter(&buf).Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	records, err := ReadAll(NewXMLReader(&buf))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 0 {
+		t.Errorf("ReadAll() returned %d records, want none", len(records))
+	}
+}
+
+func TestXMLReaderSingleRecord(t *testing.T) {
+	doc := `<?xml version="1.0"?>
+<record xmlns="http://www.loc.gov/MARC21/slim">
+  <leader>short</leader>
+  <controlfield tag="001">42</controlfield>
+  <datafield tag="245" ind1="1" ind2="">
+    <subfield code="a">Title</subfield>
+  </datafield>
+</record>`
+
+	r := NewXMLReader(strings.NewReader(doc))
+	record, err := r.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if record.Leader != DefaultLeader {
+		t.Errorf("Leader = %q, want the default leader for a malformed one", record.Leader)
+	}
+	if got := record.ControlField("001"); got != "42" {
+		t.Errorf("ControlField(001) = %q, want 42", got)
+	}
+
+	field := record.Fields("245")
+	if len(field) != 1 || field[0].Ind1 != "1" || field[0].Ind2 != " " || field[0].Subfield("a") != "Title" {
+		t.Errorf("Fields(245) = %+v, want indicators \"1\" and \" \" and title", field)
+	}
+
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestXMLReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{name: "unclosed record", doc: `<collection><record><leader>00000nam a2200000 i 4500</leader>`},
+		{name: "mismatched tags", doc: `<collection><record><controlfield tag="001">42</datafield></record></collection>`},
+		{name: "not xml", doc: `<collection><record><<`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadAll(NewXMLReader(strings.NewReader(tt.doc)))
+			if err == nil {
+				t.Fatal("ReadAll() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestNewRecordReaderDetectsFormat(t *testing.T) {
+	iso, err := encodeISO2709(testRecord())
+	if err != nil {
+		t.Fatalf("encodeISO2709() error = %v", err)
+	}
+
+	var xmlDoc bytes.Buffer
+	w := NewXMLWriter(&xmlDoc)
+	if err := w.Write(testRecord()); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for name, input := range map[string][]byte{"iso2709": iso, "marcxml": append([]byte("\n  "), xmlDoc.Bytes()...)} {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewRecordReader(bytes.NewReader(input), "")
+			if err != nil {
+				t.Fatalf("NewRecordReader() error = %v", err)
+			}
+
+			records, err := ReadAll(r)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("ReadAll() returned %d records, want 1", len(records))
+			}
+			assertSameRecord(t, records[0], testRecord())
+		})
+	}
+
+	if _, err := NewRecordReader(bytes.NewReader(iso), "csv"); err == nil {
+		t.Error("NewRecordReader() with an unsupported format error = nil, want an error")
+	}
+}
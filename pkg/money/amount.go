@@ -0,0 +1,120 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amount is an amount of money in minor units, e.g. cents, so that adding amounts and
+// reading them back from NUMERIC(12, 2) columns does not lose cents as floats do.
+//
+// Amounts are written as decimals with two places, e.g. 12.50, in JSON and SQL.
+type Amount int64
+
+const (
+	decimalPlaces = 2
+	minorUnits    = 100
+	// Digits of a NUMERIC(12, 2) column
+	maxDigits = 12
+)
+
+var (
+	ErrInvalidFormat   = errors.New("amount must be a decimal number, e.g. 12.50")
+	ErrTooManyDecimals = errors.New("amount must have at most two decimal places")
+	ErrOutOfRange      = errors.New("amount must be less than 10000000000")
+)
+
+// Parse parses a decimal amount with at most two decimal places, e.g. 12, 12.5 or -12.50.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" || !isDigits(whole) || (hasFraction && (fraction == "" || !isDigits(fraction))) {
+		return 0, ErrInvalidFormat
+	}
+	if len(fraction) > decimalPlaces {
+		return 0, ErrTooManyDecimals
+	}
+
+	digits := strings.TrimLeft(whole, "0") + fraction + strings.Repeat("0", decimalPlaces-len(fraction))
+	if len(digits) > maxDigits {
+		return 0, ErrOutOfRange
+	}
+	if digits == "" {
+		return 0, nil
+	}
+
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidFormat
+	}
+
+	if negative {
+		minor = -minor
+	}
+	return Amount(minor), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the amount as a decimal with two places, e.g. 12.50.
+func (a Amount) String() string {
+	sign := ""
+	minor := int64(a)
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, minor/minorUnits, minor%minorUnits)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or a string holding one.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	amount, err := Parse(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+
+	*a = amount
+	return nil
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(value interface{}) error {
+	var err error
+	switch v := value.(type) {
+	case []byte:
+		*a, err = Parse(string(v))
+	case string:
+		*a, err = Parse(v)
+	case int64:
+		*a = Amount(v * minorUnits)
+	default:
+		return fmt.Errorf("cannot scan %T into an amount", value)
+	}
+
+	return err
+}
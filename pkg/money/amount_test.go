@@ -0,0 +1,155 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Amount
+		wantErr error
+	}{
+		{input: "0", want: 0},
+		{input: "12", want: 1200},
+		{input: "12.5", want: 1250},
+		{input: "12.50", want: 1250},
+		{input: "0.01", want: 1},
+		{input: " 7.05 ", want: 705},
+		{input: "-12.34", want: -1234},
+		{input: "0009999999999.99", want: 999999999999},
+		{input: "12.345", wantErr: ErrTooManyDecimals},
+		{input: "0.001", wantErr: ErrTooManyDecimals},
+		{input: "", wantErr: ErrInvalidFormat},
+		{input: "-", wantErr: ErrInvalidFormat},
+		{input: ".5", wantErr: ErrInvalidFormat},
+		{input: "12.", wantErr: ErrInvalidFormat},
+		{input: "1e3", wantErr: ErrInvalidFormat},
+		{input: "+12", wantErr: ErrInvalidFormat},
+		{input: "1,000.00", wantErr: ErrInvalidFormat},
+		{input: "10000000000", wantErr: ErrOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{amount: 0, want: "0.00"},
+		{amount: 1, want: "0.01"},
+		{amount: 1250, want: "12.50"},
+		{amount: -5, want: "-0.05"},
+		{amount: -1234, want: "-12.34"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.amount.String(); got != tt.want {
+				t.Errorf("Amount(%d).String() = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	type copy struct {
+		Price Amount `json:"price"`
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Amount
+		wantErr bool
+		output  string
+	}{
+		{name: "number", input: `{"price":12.5}`, want: 1250, output: `{"price":12.50}`},
+		{name: "string", input: `{"price":"0.10"}`, want: 10, output: `{"price":0.10}`},
+		{name: "fractions of cents", input: `{"price":0.105}`, wantErr: true},
+		{name: "not a number", input: `{"price":"free"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c copy
+			err := json.Unmarshal([]byte(tt.input), &c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("json.Unmarshal(%s) error = %v, want error %t", tt.input, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if c.Price != tt.want {
+				t.Errorf("json.Unmarshal(%s) price = %d, want %d", tt.input, c.Price, tt.want)
+			}
+
+			output, err := json.Marshal(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != tt.output {
+				t.Errorf("json.Marshal() = %s, want %s", output, tt.output)
+			}
+		})
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    Amount
+		wantErr bool
+	}{
+		{name: "numeric", value: []byte("19.90"), want: 1990},
+		{name: "text", value: "0.05", want: 5},
+		{name: "integer", value: int64(3), want: 300},
+		{name: "float", value: 19.9, wantErr: true},
+		{name: "invalid numeric", value: []byte("abc"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+			err := got.Scan(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, want error %t", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountValueRoundTrip(t *testing.T) {
+	for _, amount := range []Amount{0, 1, 1990, -1234, 999999999999} {
+		value, err := amount.Value()
+		if err != nil {
+			t.Fatalf("Amount(%d).Value() error = %v", amount, err)
+		}
+
+		var got Amount
+		if err := got.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) error = %v", value, err)
+		}
+		if got != amount {
+			t.Errorf("Scan(Value()) of %d = %d", amount, got)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+// :name, :name? or :name<constraint>
+var fiberParamRegex = regexp.MustCompile(`:([A-Za-z0-9_]+)(?:<([^>]*)>)?\??`)
+
+// PathParam is a parameter in the pattern of a route.
+type PathParam struct {
+	Name string
+	// Constraint of the parameter, e.g. int for :book_id<int>
+	Constraint string
+}
+
+// Path converts the pattern of a fiber route to an OpenAPI path, e.g. /book/:book_id<int>/
+// to /book/{book_id}, and returns its parameters. Trailing slashes are removed, as fiber
+// matches the route either way.
+func Path(pattern string) (string, []PathParam) {
+	params := []PathParam{}
+	path := fiberParamRegex.ReplaceAllStringFunc(pattern, func(match string) string {
+		groups := fiberParamRegex.FindStringSubmatch(match)
+		params = append(params, PathParam{Name: groups[1], Constraint: groups[2]})
+		return "{" + groups[1] + "}"
+	})
+
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return path, params
+}
@@ -0,0 +1,203 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"lms-backend/pkg/money"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	amountType        = reflect.TypeOf(money.Amount(0))
+	numberType        = reflect.TypeOf(json.Number(""))
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	// Characters not allowed in the names of components
+	invalidNameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+// Schemas generates the schemas of Go types as encoding/json would marshal them.
+//
+// Named structs are added to the components once and referred to by name,
+// e.g. bookview.DetailedView, so that recursive types end.
+type Schemas struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func NewSchemas() *Schemas {
+	return &Schemas{
+		components: map[string]*Schema{},
+		names:      map[reflect.Type]string{},
+	}
+}
+
+// Components are the schemas of the named structs generated so far.
+func (s *Schemas) Components() map[string]*Schema {
+	return s.components
+}
+
+// Of is the schema of the JSON encoding of v.
+func (s *Schemas) Of(v interface{}) *Schema {
+	return s.typeSchema(reflect.TypeOf(v), "json")
+}
+
+// FormOf is the schema of the struct v sent as form fields, named by their form tags.
+func (s *Schemas) FormOf(v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return s.structSchema(t, "form")
+}
+
+func (s *Schemas) typeSchema(t reflect.Type, tagKey string) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == amountType, t == numberType:
+		// Written with two decimal places, e.g. 12.50
+		return &Schema{Type: "number"}
+	case t.Implements(jsonMarshalerType), reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// Could be anything
+		return &Schema{}
+	case t.Implements(textMarshalerType), reflect.PtrTo(t).Implements(textMarshalerType):
+		return String()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.typeSchema(t.Elem(), tagKey)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return ArrayOf(s.typeSchema(t.Elem(), tagKey))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.typeSchema(t.Elem(), tagKey)}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t, tagKey)
+		}
+		return s.namedStructSchema(t, tagKey)
+	default:
+		// Interfaces
+		return &Schema{}
+	}
+}
+
+func (s *Schemas) namedStructSchema(t reflect.Type, tagKey string) *Schema {
+	if name, ok := s.names[t]; ok {
+		return Ref(name)
+	}
+
+	name := componentName(t)
+	for i := 2; s.components[name] != nil; i++ {
+		// Same name in another package of the same name
+		name = componentName(t) + strconv.Itoa(i)
+	}
+
+	s.names[t] = name
+	// Reserved before generating the fields, which may refer back to the struct
+	s.components[name] = &Schema{}
+	*s.components[name] = *s.structSchema(t, tagKey)
+
+	return Ref(name)
+}
+
+// componentName is the package and name of the type, e.g. bookview.DetailedView.
+func componentName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+
+	return invalidNameRegex.ReplaceAllString(pkg+"."+t.Name(), "_")
+}
+
+// field is a property of a struct, found at the depth of embedding.
+type field struct {
+	schema *Schema
+	depth  int
+}
+
+func (s *Schemas) structSchema(t reflect.Type, tagKey string) *Schema {
+	fields := map[string]field{}
+	s.addFields(fields, t, tagKey, 0)
+
+	properties := make(map[string]*Schema, len(fields))
+	for name, f := range fields {
+		properties[name] = f.schema
+	}
+
+	return Object(properties)
+}
+
+// addFields adds the fields of the struct, and those of embedded structs as encoding/json
+// promotes them. Fields of shallower structs hide those of deeper ones.
+func (s *Schemas) addFields(fields map[string]field, t reflect.Type, tagKey string, depth int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get(tagKey)
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if f.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			s.addFields(fields, fieldType, tagKey, depth+1)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		if existing, ok := fields[name]; ok && existing.depth <= depth {
+			continue
+		}
+
+		schema := s.typeSchema(f.Type, tagKey)
+		if strings.Contains(options, "string") {
+			// Numbers and booleans quoted with the string option
+			schema = String()
+		}
+
+		fields[name] = field{schema: schema, depth: depth}
+	}
+}
@@ -0,0 +1,211 @@
+// Package xlsx reads and writes the cell values of Office Open XML spreadsheets.
+//
+// Only what is needed to import and export tabular data is supported: the first worksheet,
+// shared and inline strings, numbers and booleans. Formatting is ignored.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	workbookPath      = "xl/workbook.xml"
+	workbookRelsPath  = "xl/_rels/workbook.xml.rels"
+	sharedStringsPath = "xl/sharedStrings.xml"
+	defaultSheetPath  = "xl/worksheets/sheet1.xml"
+)
+
+type workbook struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// richText is a shared or inline string, either plain (<t>) or made of runs (<r><t>).
+type richText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (r richText) String() string {
+	if len(r.Runs) == 0 {
+		return r.T
+	}
+
+	var sb strings.Builder
+	for _, run := range r.Runs {
+		sb.WriteString(run.T)
+	}
+
+	return sb.String()
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+type worksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref       string   `xml:"r,attr"`
+			Type      string   `xml:"t,attr"`
+			Value     string   `xml:"v"`
+			InlineStr richText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadRows returns the cell values of the first worksheet, one slice per row.
+// Missing rows and cells are returned as empty values.
+func ReadRows(r io.ReaderAt, size int64) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not an xlsx file: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	strs := []string{}
+	if f, ok := files[sharedStringsPath]; ok {
+		var sst sharedStrings
+		if err := decode(f, &sst); err != nil {
+			return nil, err
+		}
+		for _, item := range sst.Items {
+			strs = append(strs, item.String())
+		}
+	}
+
+	sheet, ok := files[firstSheetPath(files)]
+	if !ok {
+		return nil, fmt.Errorf("xlsx file has no worksheet")
+	}
+
+	var ws worksheet
+	if err := decode(sheet, &ws); err != nil {
+		return nil, err
+	}
+
+	rows := [][]string{}
+	for _, row := range ws.Rows {
+		index := row.Index - 1
+		if index < len(rows) {
+			index = len(rows)
+		}
+		for len(rows) < index {
+			rows = append(rows, []string{})
+		}
+
+		values := []string{}
+		for _, cell := range row.Cells {
+			column := len(values)
+			if cell.Ref != "" {
+				column = columnIndex(cell.Ref)
+			}
+			for len(values) < column {
+				values = append(values, "")
+			}
+
+			value, err := cellValue(cell.Type, cell.Value, cell.InlineStr, strs)
+			if err != nil {
+				return nil, fmt.Errorf("cell %s: %w", cell.Ref, err)
+			}
+			values = append(values, value)
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows, nil
+}
+
+func cellValue(cellType, value string, inline richText, strs []string) (string, error) {
+	switch cellType {
+	case "s":
+		i, err := strconv.Atoi(value)
+		if err != nil || i < 0 || i >= len(strs) {
+			return "", fmt.Errorf("invalid shared string index %q", value)
+		}
+		return strs[i], nil
+	case "inlineStr":
+		return inline.String(), nil
+	case "b":
+		if value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	default:
+		return value, nil
+	}
+}
+
+// firstSheetPath resolves the path of the first sheet listed in the workbook.
+func firstSheetPath(files map[string]*zip.File) string {
+	var wb workbook
+	var rels relationships
+
+	wbFile, ok := files[workbookPath]
+	if !ok || decode(wbFile, &wb) != nil || len(wb.Sheets) == 0 {
+		return defaultSheetPath
+	}
+
+	relsFile, ok := files[workbookRelsPath]
+	if !ok || decode(relsFile, &rels) != nil {
+		return defaultSheetPath
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/")
+		}
+		return path.Join("xl", rel.Target)
+	}
+
+	return defaultSheetPath
+}
+
+// columnIndex returns the zero-based column of a cell reference such as "AB12".
+func columnIndex(ref string) int {
+	column := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+	}
+
+	return column - 1
+}
+
+func decode(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	return xml.NewDecoder(rc).Decode(v)
+}
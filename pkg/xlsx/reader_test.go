@@ -0,0 +1,264 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Books" sheetId="1" r:id="rId3"/><sheet name="Other" sheetId="2" r:id="rId1"/></sheets></workbook>`
+	sharedStringsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" count="3" uniqueCount="3">` +
+		`<si><t>Title</t></si>` +
+		`<si><t>ISBN</t></si>` +
+		`<si><r><t>Sejarah </t></r><r><rPr><b/></rPr><t>Melaka</t></r></si>` +
+		`</sst>`
+)
+
+// sheetXML wraps rows in a worksheet.
+func sheetXML(rows string) string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + rows + `</sheetData></worksheet>`
+}
+
+// relsXML lists the worksheets of a workbook by relationship ID.
+func relsXML(targets map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for id, target := range targets {
+		sb.WriteString(`<Relationship Id="` + id + `" Target="` + target + `"/>`)
+	}
+	sb.WriteString(`</Relationships>`)
+	return sb.String()
+}
+
+// zipFile returns an archive with the files, keyed by their path.
+func zipFile(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestReadRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  [][]string
+	}{
+		{
+			name: "shared, rich and inline strings",
+			files: map[string]string{
+				sharedStringsPath: sharedStringsXML,
+				defaultSheetPath: sheetXML(
+					`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c></row>` +
+						`<row r="2"><c r="A2" t="s"><v>2</v></c><c r="B2" t="inlineStr"><is><t>9780306406157</t></is></c></row>`,
+				),
+			},
+			want: [][]string{{"Title", "ISBN"}, {"Sejarah Melaka", "9780306406157"}},
+		},
+		{
+			name: "numbers and booleans",
+			files: map[string]string{
+				defaultSheetPath: sheetXML(
+					`<row r="1"><c r="A1"><v>42</v></c><c r="B1" t="n"><v>1.5</v></c>` +
+						`<c r="C1" t="b"><v>1</v></c><c r="D1" t="b"><v>0</v></c></row>`,
+				),
+			},
+			want: [][]string{{"42", "1.5", "TRUE", "FALSE"}},
+		},
+		{
+			name: "missing rows and cells",
+			files: map[string]string{
+				defaultSheetPath: sheetXML(
+					`<row r="2"><c r="B2"><v>1</v></c><c r="D2"><v>2</v></c></row>` +
+						`<row r="4"><c r="AA4"><v>3</v></c></row>`,
+				),
+			},
+			want: [][]string{
+				{},
+				{"", "1", "", "2"},
+				{},
+				append(make([]string, 26), "3"),
+			},
+		},
+		{
+			name: "rows and cells without references",
+			files: map[string]string{
+				defaultSheetPath: sheetXML(`<row><c><v>1</v></c><c><v>2</v></c></row><row><c><v>3</v></c></row>`),
+			},
+			want: [][]string{{"1", "2"}, {"3"}},
+		},
+		{
+			name: "first sheet of the workbook",
+			files: map[string]string{
+				workbookPath:              workbookXML,
+				workbookRelsPath:          relsXML(map[string]string{"rId1": "worksheets/sheet1.xml", "rId3": "worksheets/books.xml"}),
+				defaultSheetPath:          sheetXML(`<row r="1"><c r="A1"><v>other</v></c></row>`),
+				"xl/worksheets/books.xml": sheetXML(`<row r="1"><c r="A1"><v>books</v></c></row>`),
+			},
+			want: [][]string{{"books"}},
+		},
+		{
+			name: "absolute sheet target",
+			files: map[string]string{
+				workbookPath:              workbookXML,
+				workbookRelsPath:          relsXML(map[string]string{"rId3": "/xl/worksheets/books.xml"}),
+				"xl/worksheets/books.xml": sheetXML(`<row r="1"><c r="A1"><v>books</v></c></row>`),
+			},
+			want: [][]string{{"books"}},
+		},
+		{
+			name: "workbook without relationships",
+			files: map[string]string{
+				workbookPath:     workbookXML,
+				defaultSheetPath: sheetXML(`<row r="1"><c r="A1"><v>default</v></c></row>`),
+			},
+			want: [][]string{{"default"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := zipFile(t, tt.files)
+			got, err := ReadRows(r, r.Size())
+			if err != nil {
+				t.Fatalf("ReadRows() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadRows() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRowsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "no worksheet",
+			files: map[string]string{workbookPath: workbookXML},
+		},
+		{
+			name: "shared string index out of range",
+			files: map[string]string{
+				sharedStringsPath: sharedStringsXML,
+				defaultSheetPath:  sheetXML(`<row r="1"><c r="A1" t="s"><v>3</v></c></row>`),
+			},
+		},
+		{
+			name: "negative shared string index",
+			files: map[string]string{
+				sharedStringsPath: sharedStringsXML,
+				defaultSheetPath:  sheetXML(`<row r="1"><c r="A1" t="s"><v>-1</v></c></row>`),
+			},
+		},
+		{
+			name:  "shared string without shared strings",
+			files: map[string]string{defaultSheetPath: sheetXML(`<row r="1"><c r="A1" t="s"><v>0</v></c></row>`)},
+		},
+		{
+			name:  "malformed worksheet",
+			files: map[string]string{defaultSheetPath: `<worksheet><sheetData><row>`},
+		},
+		{
+			name: "malformed shared strings",
+			files: map[string]string{
+				sharedStringsPath: `<sst><si>`,
+				defaultSheetPath:  sheetXML(""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := zipFile(t, tt.files)
+			if rows, err := ReadRows(r, r.Size()); err == nil {
+				t.Fatalf("ReadRows() = %q, want an error", rows)
+			}
+		})
+	}
+
+	t.Run("not a zip file", func(t *testing.T) {
+		r := strings.NewReader("title,isbn\n")
+		if _, err := ReadRows(r, r.Size()); err == nil {
+			t.Fatal("ReadRows() error = nil, want an error")
+		}
+	})
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	want := [][]string{
+		{"Title", "ISBN", "Notes"},
+		{"Fish & <Chips>", "9780306406157", "  leading spaces"},
+		{},
+		{"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "AA"},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, `Books "2024"`)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, row := range want {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	got, err := ReadRows(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadRows() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadRows() = %q, want %q", got, want)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want int
+	}{
+		{ref: "A1", want: 0},
+		{ref: "Z9", want: 25},
+		{ref: "AA10", want: 26},
+		{ref: "AZ1", want: 51},
+		{ref: "XFD1048576", want: 16383},
+	}
+
+	for _, tt := range tests {
+		if got := columnIndex(tt.ref); got != tt.want {
+			t.Errorf("columnIndex(%q) = %d, want %d", tt.ref, got, tt.want)
+		}
+		if got, want := cellRef(tt.want, 1), strings.TrimRight(tt.ref, "0123456789")+"1"; got != want {
+			t.Errorf("cellRef(%d, 1) = %q, want %q", tt.want, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+const (
+	contentTypesPath = "[Content_Types].xml"
+	rootRelsPath     = "_rels/.rels"
+
+	contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	worksheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	worksheetEnd = `</sheetData></worksheet>`
+)
+
+// Writer writes rows to the single worksheet of a spreadsheet as they come,
+// so that large exports need not be held in memory.
+//
+// Every cell is written as an inline string. Close must be called to finish the file.
+type Writer struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+	buf     bytes.Buffer
+}
+
+// NewWriter starts a spreadsheet with one worksheet of the given name.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	archive := zip.NewWriter(w)
+
+	var sheetNameXML bytes.Buffer
+	if err := xml.EscapeText(&sheetNameXML, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	parts := []struct {
+		path    string
+		content string
+	}{
+		{contentTypesPath, contentTypesXML},
+		{rootRelsPath, rootRelsXML},
+		{workbookPath, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + sheetNameXML.String() + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{workbookRelsPath, workbookRelsXML},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create(defaultSheetPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, worksheetStart); err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		archive: archive,
+		sheet:   sheet,
+	}, nil
+}
+
+// Write writes a row of cells.
+func (w *Writer) Write(cells []string) error {
+	w.row++
+	w.buf.Reset()
+
+	w.buf.WriteString(`<row r="` + strconv.Itoa(w.row) + `">`)
+	for i, cell := range cells {
+		w.buf.WriteString(`<c r="` + cellRef(i, w.row) + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(&w.buf, []byte(cell)); err != nil {
+			return err
+		}
+		w.buf.WriteString(`</t></is></c>`)
+	}
+	w.buf.WriteString(`</row>`)
+
+	_, err := w.sheet.Write(w.buf.Bytes())
+	return err
+}
+
+// Close finishes the worksheet and the file. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, worksheetEnd); err != nil {
+		return err
+	}
+
+	return w.archive.Close()
+}
+
+// cellRef is the A1 reference of the zero-based column in the one-based row.
+func cellRef(column, row int) string {
+	var letters []byte
+	for column++; column > 0; column = (column - 1) / 26 {
+		letters = append([]byte{byte('A' + (column-1)%26)}, letters...)
+	}
+
+	return string(letters) + strconv.Itoa(row)
+}
@@ -0,0 +1,17 @@
+package sliceutil
+
+// UniqueBy returns the elements of arr whose key has not been seen before, keeping their order.
+func UniqueBy[S any, K comparable](arr []S, key func(S) K) []S {
+	seen := map[K]bool{}
+	res := []S{}
+	for _, v := range arr {
+		k := key(v)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		res = append(res, v)
+	}
+
+	return res
+}